@@ -21,6 +21,7 @@ import (
 	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/pubsub"
 	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/version"
@@ -81,6 +82,9 @@ type BaseApp struct {
 	// Snapshot for state sync related fields
 	StateSyncHelper *store.StateSyncHelper // manage state sync related status
 
+	// may be nil, events published by modules are positioned by block height
+	pubServer *pubsub.Server
+
 	// flag for sealing
 	sealed bool
 }
@@ -166,6 +170,34 @@ func (app *BaseApp) MountStore(key sdk.StoreKey, typ sdk.StoreType) {
 	app.cms.MountStoreWithDB(key, typ, nil)
 }
 
+// Register a migration of a mounted store, it runs in the upgrade BeginBlocker of migration.Upgrade once the app
+// sets StoreMigrator to sdk.UpgradeMgr
+func (app *BaseApp) RegisterStoreMigration(migration sdk.StoreMigration) {
+	cms, ok := app.cms.(store.MigratableStore)
+	if !ok {
+		panic("the multistore does not support migrations")
+	}
+	cms.RegisterMigration(migration)
+}
+
+// StoreMigrator returns the migrator running the registered store migrations, which the app sets to sdk.UpgradeMgr.
+// It is nil if the multistore does not support migrations.
+func (app *BaseApp) StoreMigrator() sdk.StoreMigrator {
+	if cms, ok := app.cms.(store.MigratableStore); ok {
+		return cms
+	}
+	return nil
+}
+
+// Run the store migrations of upgrade against a copy of the latest committed state and return the resulting commit ID
+func (app *BaseApp) DryRunStoreMigrations(upgrade string, header abci.Header) (sdk.CommitID, error) {
+	cms, ok := app.cms.(store.MigratableStore)
+	if !ok {
+		return sdk.CommitID{}, errors.New("the multistore does not support migrations")
+	}
+	return cms.DryRunMigrations(upgrade, header, app.Logger)
+}
+
 // only load latest multi store application version
 func (app *BaseApp) LoadCMSLatestVersion() error {
 	err := app.cms.LoadLatestVersion()
@@ -514,6 +546,9 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 	}
 
 	sdk.UpgradeMgr.SetHeight(req.Header.Height)
+	if app.pubServer != nil {
+		app.pubServer.SetHeight(req.Header.Height)
+	}
 
 	// Initialize the DeliverTx state. If this is the first block, it should
 	// already be initialized in InitChain. Otherwise app.DeliverState will be
@@ -957,6 +992,9 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	app.Logger.Debug("Commit synced",
 		"commit", commitID,
 	)
+	if app.pubServer != nil {
+		app.pubServer.Commit(header.Height)
+	}
 
 	// Reset the Check state to the latest committed
 	// NOTE: safe because Tendermint holds a lock on the mempool for Commit.
@@ -4,6 +4,7 @@ package baseapp
 import (
 	"fmt"
 
+	"github.com/cosmos/cosmos-sdk/pubsub"
 	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	dbm "github.com/tendermint/tendermint/libs/db"
@@ -100,6 +101,13 @@ func (app *BaseApp) SetPubKeyPeerFilter(pf sdk.PeerFilter) {
 	app.pubkeyPeerFilter = pf
 }
 
+func (app *BaseApp) SetPubsubServer(server *pubsub.Server) {
+	if app.sealed {
+		panic("SetPubsubServer() on sealed BaseApp")
+	}
+	app.pubServer = server
+}
+
 func (app *BaseApp) Router() Router {
 	if app.sealed {
 		panic("Router() on sealed BaseApp")
@@ -174,6 +174,33 @@ func (h *Header) ExtractSignerFromHeader(chainID *big.Int) (signer Address, err
 	return
 }
 
+// Hash returns the block hash of the header, which is the keccak256 hash of its RLP encoding.
+func (h *Header) Hash() (hash Hash) {
+	hasher := sha3.NewLegacyKeccak256()
+	err := rlp.Encode(hasher, []interface{}{
+		h.ParentHash,
+		h.UncleHash,
+		h.Coinbase,
+		h.Root,
+		h.TxHash,
+		h.ReceiptHash,
+		h.Bloom,
+		big.NewInt(h.Difficulty),
+		big.NewInt(h.Number),
+		h.GasLimit,
+		h.GasUsed,
+		h.Time,
+		h.Extra,
+		h.MixDigest,
+		h.Nonce,
+	})
+	if err != nil {
+		panic("can't encode: " + err.Error())
+	}
+	hasher.Sum(hash[:0])
+	return hash
+}
+
 // Keccak256 calculates and returns the Keccak256 hash of the input data.
 func Keccak256(data ...[]byte) []byte {
 	d := sha3.NewLegacyKeccak256()
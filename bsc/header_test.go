@@ -8,10 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestHeader_UnmarshalJSON(t *testing.T) {
-	chainID := big.NewInt(56)
-	h := &Header{}
-	jsonStr := `{"parentHash":"0xa9c482b74a276389681eabff076b19bef53cae9b5e44f02224e70e3bfc4e9142",
+const mainnetHeaderJson = `{"parentHash":"0xa9c482b74a276389681eabff076b19bef53cae9b5e44f02224e70e3bfc4e9142",
 				"sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347",
 				"miner":"0x72b61c6014342d914470ec7ac2975be345796c2b",
 				"stateRoot":"0xacd5bca0bc33ed07cb35a635fa674e4ce06211ba201500564dd14dcdaf53e5a9",
@@ -28,7 +25,11 @@ func TestHeader_UnmarshalJSON(t *testing.T) {
 				"nonce":"0x0000000000000000",
 				"baseFeePerGas":null,
 				"hash":"0x8b6eeece6cedbb23038e7e5c2ce647fbdffa04972247d60a7564e81897e8bc30"}`
-	err := h.UnmarshalJSON([]byte(jsonStr))
+
+func TestHeader_UnmarshalJSON(t *testing.T) {
+	chainID := big.NewInt(56)
+	h := &Header{}
+	err := h.UnmarshalJSON([]byte(mainnetHeaderJson))
 	require.NoError(t, err)
 
 	signature, err := h.GetSignature()
@@ -39,3 +40,13 @@ func TestHeader_UnmarshalJSON(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "0x72b61c6014342d914470eC7aC2975bE345796c2b", signer.String())
 }
+
+func TestHeader_Hash(t *testing.T) {
+	h := &Header{}
+	require.NoError(t, h.UnmarshalJSON([]byte(mainnetHeaderJson)))
+	require.Equal(t, "0x8b6eeece6cedbb23038e7e5c2ce647fbdffa04972247d60a7564e81897e8bc30", h.Hash().Hex())
+
+	// the hash changes with the seal of the header
+	h.Extra[len(h.Extra)-1] = 0x00
+	require.NotEqual(t, "0x8b6eeece6cedbb23038e7e5c2ce647fbdffa04972247d60a7564e81897e8bc30", h.Hash().Hex())
+}
@@ -0,0 +1,50 @@
+package bsc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+const (
+	// extraVanity is the number of bytes of the extra-data prefix reserved for the signer vanity
+	extraVanity = 32
+
+	// DefaultEpoch is the number of blocks after which the Parlia validator set is updated
+	DefaultEpoch = 200
+
+	// DiffInTurn is the difficulty of a block sealed by the in-turn validator, DiffNoTurn of the others
+	DiffInTurn = 2
+	DiffNoTurn = 1
+)
+
+// ParseValidators returns the Parlia validator set carried in the extra-data of an epoch header, which is
+// the list of the validator addresses between the vanity and the seal of the extra-data.
+func (h *Header) ParseValidators() ([]Address, error) {
+	if len(h.Extra) < extraVanity+extraSeal {
+		return nil, errors.New("extra-data is shorter than its vanity and seal")
+	}
+	validatorBytes := h.Extra[extraVanity : len(h.Extra)-extraSeal]
+	if len(validatorBytes) == 0 || len(validatorBytes)%AddressLength != 0 {
+		return nil, fmt.Errorf("invalid length of validator set in extra-data: %d", len(validatorBytes))
+	}
+	validators := make([]Address, len(validatorBytes)/AddressLength)
+	for i := range validators {
+		copy(validators[i][:], validatorBytes[i*AddressLength:(i+1)*AddressLength])
+	}
+	return validators, nil
+}
+
+// SortValidators sorts a validator set in ascending order of address, the order in which Parlia validators
+// take turns.
+func SortValidators(validators []Address) {
+	sort.Slice(validators, func(i, j int) bool {
+		return bytes.Compare(validators[i][:], validators[j][:]) < 0
+	})
+}
+
+// InTurnValidator returns the validator whose turn it is to seal the block of number, validators must be sorted.
+func InTurnValidator(validators []Address, number int64) Address {
+	return validators[number%int64(len(validators))]
+}
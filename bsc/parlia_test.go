@@ -0,0 +1,37 @@
+package bsc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseValidators(t *testing.T) {
+	validators := []Address{{0x01}, {0x02}, {0x03}}
+	extra := make([]byte, extraVanity)
+	for _, validator := range validators {
+		extra = append(extra, validator.Bytes()...)
+	}
+	h := &Header{Extra: append(extra, make([]byte, extraSeal)...)}
+	parsed, err := h.ParseValidators()
+	require.NoError(t, err)
+	require.Equal(t, validators, parsed)
+
+	h.Extra = append(extra[:len(extra)-1], make([]byte, extraSeal)...)
+	_, err = h.ParseValidators()
+	require.Error(t, err)
+
+	// headers of the blocks in an epoch carry no validators
+	h = &Header{}
+	require.NoError(t, h.UnmarshalJSON([]byte(mainnetHeaderJson)))
+	_, err = h.ParseValidators()
+	require.Error(t, err)
+}
+
+func TestInTurnValidator(t *testing.T) {
+	validators := []Address{{0x03}, {0x01}, {0x02}}
+	SortValidators(validators)
+	require.Equal(t, []Address{{0x01}, {0x02}, {0x03}}, validators)
+	require.Equal(t, Address{0x01}, InTurnValidator(validators, 201))
+	require.Equal(t, Address{0x03}, InTurnValidator(validators, 200))
+}
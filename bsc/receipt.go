@@ -0,0 +1,88 @@
+package bsc
+
+import (
+	"errors"
+
+	"github.com/cosmos/cosmos-sdk/bsc/rlp"
+)
+
+// ReceiptStatusSuccessful is the status of a receipt whose transaction was executed successfully.
+const ReceiptStatusSuccessful = uint64(1)
+
+// Log is an event emitted by a contract as it is committed to by the receipts root of a block.
+type Log struct {
+	Address Address
+	Topics  []Hash
+	Data    []byte
+}
+
+// Receipt is the consensus part of a transaction receipt, which is committed to by the receipts root of a block.
+type Receipt struct {
+	Type              uint8
+	PostStateOrStatus []byte
+	CumulativeGasUsed uint64
+	Bloom             Bloom
+	Logs              []*Log
+}
+
+type receiptRLP struct {
+	PostStateOrStatus []byte
+	CumulativeGasUsed uint64
+	Bloom             Bloom
+	Logs              []*Log
+}
+
+// DecodeReceipt decodes the consensus encoding of a receipt, which is the value of the receipt in the
+// receipts trie. Typed receipts are prefixed with their type.
+func DecodeReceipt(bz []byte) (*Receipt, error) {
+	if len(bz) == 0 {
+		return nil, errors.New("empty receipt")
+	}
+	receipt := &Receipt{}
+	// the encoding of a legacy receipt is a list, which starts with a byte not less than 0xc0
+	if bz[0] <= 0x7f {
+		receipt.Type = bz[0]
+		bz = bz[1:]
+	}
+	var dec receiptRLP
+	if err := rlp.DecodeBytes(bz, &dec); err != nil {
+		return nil, err
+	}
+	receipt.PostStateOrStatus = dec.PostStateOrStatus
+	receipt.CumulativeGasUsed = dec.CumulativeGasUsed
+	receipt.Bloom = dec.Bloom
+	receipt.Logs = dec.Logs
+	return receipt, nil
+}
+
+// EncodeReceipt returns the consensus encoding of a receipt.
+func EncodeReceipt(receipt *Receipt) ([]byte, error) {
+	bz, err := rlp.EncodeToBytes(&receiptRLP{
+		PostStateOrStatus: receipt.PostStateOrStatus,
+		CumulativeGasUsed: receipt.CumulativeGasUsed,
+		Bloom:             receipt.Bloom,
+		Logs:              receipt.Logs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Type == 0 {
+		return bz, nil
+	}
+	return append([]byte{receipt.Type}, bz...), nil
+}
+
+// Successful tells if the transaction of the receipt was executed successfully, receipts of the
+// blocks before Byzantium have an intermediate state root instead of a status.
+func (r *Receipt) Successful() bool {
+	return len(r.PostStateOrStatus) == 1 && uint64(r.PostStateOrStatus[0]) == ReceiptStatusSuccessful
+}
+
+// ReceiptKey returns the key of the receipt of the transaction at index in the receipts trie of a block.
+func ReceiptKey(index uint64) []byte {
+	key, err := rlp.EncodeToBytes(index)
+	if err != nil {
+		panic("can't encode: " + err.Error())
+	}
+	return key
+}
@@ -0,0 +1,145 @@
+package bsc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/bsc/rlp"
+)
+
+// EmptyRootHash is the root hash of an empty merkle patricia trie.
+var EmptyRootHash = BytesToHash(Keccak256([]byte{0x80}))
+
+// VerifyProof checks a merkle patricia trie proof of key under root. The proof is the list of the RLP encoded
+// trie nodes on the path from the root to the key, in any order. It returns the value of key, or nil if the
+// proof shows that key is not in the trie.
+func VerifyProof(root Hash, key []byte, proof [][]byte) ([]byte, error) {
+	if root == EmptyRootHash {
+		return nil, nil
+	}
+	nodes := make(map[Hash][]byte, len(proof))
+	for _, node := range proof {
+		nodes[BytesToHash(Keccak256(node))] = node
+	}
+
+	path := keyToNibbles(key)
+	node, ok := nodes[root]
+	if !ok {
+		return nil, fmt.Errorf("missing trie node %x", root.Bytes())
+	}
+	for {
+		elems, _, err := rlp.SplitList(node)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trie node: %v", err)
+		}
+		count, err := rlp.CountValues(elems)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trie node: %v", err)
+		}
+
+		var child []byte
+		switch count {
+		case 2:
+			compactKey, rest, err := rlp.SplitString(elems)
+			if err != nil {
+				return nil, fmt.Errorf("invalid key of short node: %v", err)
+			}
+			nodeKey, isLeaf := compactToNibbles(compactKey)
+			if len(path) < len(nodeKey) || !bytes.Equal(path[:len(nodeKey)], nodeKey) {
+				return nil, nil
+			}
+			path = path[len(nodeKey):]
+			if isLeaf {
+				if len(path) != 0 {
+					return nil, nil
+				}
+				value, _, err := rlp.SplitString(rest)
+				if err != nil {
+					return nil, fmt.Errorf("invalid value of leaf node: %v", err)
+				}
+				return value, nil
+			}
+			child, _, err = splitChild(rest)
+			if err != nil {
+				return nil, err
+			}
+		case 17:
+			rest := elems
+			for i := 0; i < 17; i++ {
+				var elem []byte
+				elem, rest, err = splitChild(rest)
+				if err != nil {
+					return nil, err
+				}
+				if len(path) == 0 && i == 16 {
+					value, _, err := rlp.SplitString(elem)
+					if err != nil {
+						return nil, fmt.Errorf("invalid value of full node: %v", err)
+					}
+					if len(value) == 0 {
+						return nil, nil
+					}
+					return value, nil
+				}
+				if len(path) > 0 && i == int(path[0]) {
+					child = elem
+					break
+				}
+			}
+			path = path[1:]
+		default:
+			return nil, fmt.Errorf("invalid trie node with %d elements", count)
+		}
+
+		// a child is either the hash of a node, or a node embedded in its parent if its encoding is
+		// shorter than a hash
+		kind, content, _, err := rlp.Split(child)
+		if err != nil {
+			return nil, fmt.Errorf("invalid child of trie node: %v", err)
+		}
+		switch {
+		case kind == rlp.List:
+			node = child
+		case len(content) == 0:
+			return nil, nil
+		case len(content) == HashLength:
+			if node, ok = nodes[BytesToHash(content)]; !ok {
+				return nil, fmt.Errorf("missing trie node %x", content)
+			}
+		default:
+			return nil, errors.New("invalid reference to trie node")
+		}
+	}
+}
+
+// splitChild returns the raw encoding of the first element of b and the elements after it.
+func splitChild(b []byte) ([]byte, []byte, error) {
+	_, _, rest, err := rlp.Split(b)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid trie node: %v", err)
+	}
+	return b[:len(b)-len(rest)], rest, nil
+}
+
+func keyToNibbles(key []byte) []byte {
+	nibbles := make([]byte, 2*len(key))
+	for i, b := range key {
+		nibbles[2*i] = b / 16
+		nibbles[2*i+1] = b % 16
+	}
+	return nibbles
+}
+
+// compactToNibbles decodes a hex prefix encoded key, the flag nibble tells if the key is odd and of a leaf.
+func compactToNibbles(compact []byte) ([]byte, bool) {
+	if len(compact) == 0 {
+		return nil, false
+	}
+	nibbles := keyToNibbles(compact)
+	isLeaf := nibbles[0] >= 2
+	if nibbles[0]&1 == 1 {
+		return nibbles[1:], isLeaf
+	}
+	return nibbles[2:], isLeaf
+}
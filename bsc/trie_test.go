@@ -0,0 +1,135 @@
+package bsc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/bsc/rlp"
+)
+
+// hexPrefix encodes the nibbles of the key of a short node with the flag of its kind.
+func hexPrefix(nibbles []byte, leaf bool) []byte {
+	flag := byte(0)
+	if leaf {
+		flag = 2
+	}
+	if len(nibbles)%2 == 1 {
+		flag++
+	} else {
+		nibbles = append([]byte{0}, nibbles...)
+	}
+	compact := []byte{flag<<4 | nibbles[0]}
+	for i := 1; i < len(nibbles); i += 2 {
+		compact = append(compact, nibbles[i]<<4|nibbles[i+1])
+	}
+	return compact
+}
+
+// nodeRef returns how a node is referenced by its parent, by its hash or embedded if it is short.
+func nodeRef(node []byte) interface{} {
+	if len(node) < HashLength {
+		return rlp.RawValue(node)
+	}
+	return Keccak256(node)
+}
+
+func shortNode(t *testing.T, nibbles []byte, leaf bool, value interface{}) []byte {
+	node, err := rlp.EncodeToBytes([]interface{}{hexPrefix(nibbles, leaf), value})
+	require.NoError(t, err)
+	return node
+}
+
+func fullNode(t *testing.T, children map[int][]byte) []byte {
+	elems := make([]interface{}, 17)
+	for i := range elems {
+		if child, ok := children[i]; ok {
+			elems[i] = nodeRef(child)
+		} else {
+			elems[i] = []byte{}
+		}
+	}
+	node, err := rlp.EncodeToBytes(elems)
+	require.NoError(t, err)
+	return node
+}
+
+func testReceipt(t *testing.T, txType uint8, data []byte) []byte {
+	bz, err := EncodeReceipt(&Receipt{
+		Type:              txType,
+		PostStateOrStatus: []byte{0x01},
+		CumulativeGasUsed: 21000,
+		Logs:              []*Log{{Address: Address{0x20}, Topics: []Hash{{0x01}}, Data: data}},
+	})
+	require.NoError(t, err)
+	return bz
+}
+
+func TestVerifyReceiptProof(t *testing.T) {
+	receipts := [][]byte{
+		testReceipt(t, 0, []byte("first")),
+		testReceipt(t, 2, []byte("second")),
+		testReceipt(t, 0, []byte("third")),
+	}
+	// the keys of the receipts 0, 1 and 2 are 0x80, 0x01 and 0x02
+	leaf0 := shortNode(t, []byte{0}, true, receipts[0])
+	leaf1 := shortNode(t, nil, true, receipts[1])
+	leaf2 := shortNode(t, nil, true, receipts[2])
+	branch := fullNode(t, map[int][]byte{1: leaf1, 2: leaf2})
+	root := fullNode(t, map[int][]byte{0: branch, 8: leaf0})
+	rootHash := BytesToHash(Keccak256(root))
+
+	value, err := VerifyProof(rootHash, ReceiptKey(0), [][]byte{root, leaf0})
+	require.NoError(t, err)
+	require.Equal(t, receipts[0], value)
+
+	value, err = VerifyProof(rootHash, ReceiptKey(1), [][]byte{leaf1, branch, root})
+	require.NoError(t, err)
+	require.Equal(t, receipts[1], value)
+	receipt, err := DecodeReceipt(value)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, receipt.Type)
+	require.True(t, receipt.Successful())
+	require.Equal(t, []byte("second"), receipt.Logs[0].Data)
+	require.Equal(t, Address{0x20}, receipt.Logs[0].Address)
+
+	// the absence of a receipt is proved by the nodes on its path
+	value, err = VerifyProof(rootHash, ReceiptKey(3), [][]byte{root, branch})
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	// proofs with missing or tampered nodes are rejected
+	_, err = VerifyProof(rootHash, ReceiptKey(2), [][]byte{root, branch})
+	require.Error(t, err)
+	tampered := shortNode(t, nil, true, testReceipt(t, 0, []byte("forged")))
+	_, err = VerifyProof(rootHash, ReceiptKey(2), [][]byte{root, branch, tampered})
+	require.Error(t, err)
+	_, err = VerifyProof(Hash{0x01}, ReceiptKey(1), [][]byte{root, branch, leaf1})
+	require.Error(t, err)
+}
+
+func TestVerifyProofEmbeddedNodes(t *testing.T) {
+	// nodes shorter than a hash are embedded in their parents
+	leaf0 := shortNode(t, nil, true, []byte{0x0a})
+	leaf1 := shortNode(t, nil, true, []byte{0x0b})
+	branch := fullNode(t, map[int][]byte{0: leaf0, 1: leaf1})
+	require.True(t, len(branch) < HashLength)
+	extension := shortNode(t, []byte{8}, false, nodeRef(branch))
+	rootHash := BytesToHash(Keccak256(extension))
+	proof := [][]byte{extension}
+
+	value, err := VerifyProof(rootHash, []byte{0x81}, proof)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x0b}, value)
+
+	for _, key := range [][]byte{{0x82}, {0x91}, {0x81, 0x01}} {
+		value, err = VerifyProof(rootHash, key, proof)
+		require.NoError(t, err)
+		require.Nil(t, value)
+	}
+
+	require.Equal(t, "0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421", EmptyRootHash.Hex())
+	value, err = VerifyProof(EmptyRootHash, []byte{0x80}, nil)
+	require.NoError(t, err)
+	require.Nil(t, value)
+}
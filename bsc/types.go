@@ -5,6 +5,7 @@ import (
 	"database/sql/driver"
 	"encoding/binary"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"golang.org/x/crypto/sha3"
 	"math/big"
@@ -80,6 +81,16 @@ func (h Hash) MarshalText() ([]byte, error) {
 	return Bytes(h[:]).MarshalText()
 }
 
+// MarshalJSON returns the quoted hex representation of h, so that codecs not aware of MarshalText
+// encode it as it is decoded.
+func (h Hash) MarshalJSON() ([]byte, error) {
+	text, err := h.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
 // SetBytes sets the hash to the value of b.
 // If b is larger than len(h), b will be cropped from the left.
 func (h *Hash) SetBytes(b []byte) {
@@ -179,6 +190,15 @@ func (a Address) MarshalText() ([]byte, error) {
 	return Bytes(a[:]).MarshalText()
 }
 
+// MarshalJSON returns the quoted hex representation of a.
+func (a Address) MarshalJSON() ([]byte, error) {
+	text, err := a.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
 // UnmarshalText parses a hash in hex syntax.
 func (a *Address) UnmarshalText(input []byte) error {
 	return UnmarshalFixedText("Address", input, a[:])
@@ -0,0 +1,39 @@
+package bsc
+
+import (
+	"golang.org/x/crypto/sha3"
+
+	"github.com/cosmos/cosmos-sdk/bsc/rlp"
+)
+
+// VoteData is the data of a fast finality vote of a BSC validator, which justifies the target block from the
+// source block.
+type VoteData struct {
+	SourceNumber uint64 `json:"source_number"`
+	SourceHash   Hash   `json:"source_hash"`
+	TargetNumber uint64 `json:"target_number"`
+	TargetHash   Hash   `json:"target_hash"`
+}
+
+// Hash returns the hash of the vote data, which is signed by the BLS vote key of the validator.
+func (d *VoteData) Hash() (hash Hash) {
+	hasher := sha3.NewLegacyKeccak256()
+	if err := rlp.Encode(hasher, d); err != nil {
+		panic("can't encode: " + err.Error())
+	}
+	hasher.Sum(hash[:0])
+	return hash
+}
+
+// IsMaliciousVotePair tells if two votes of a validator violate the fast finality rules: a validator must not
+// vote twice for the same target number, nor vote a span surrounding another of its votes.
+func IsMaliciousVotePair(a, b *VoteData) bool {
+	if a.SourceNumber >= a.TargetNumber || b.SourceNumber >= b.TargetNumber || *a == *b {
+		return false
+	}
+	if a.TargetNumber == b.TargetNumber {
+		return true
+	}
+	return (a.SourceNumber < b.SourceNumber && b.TargetNumber < a.TargetNumber) ||
+		(b.SourceNumber < a.SourceNumber && a.TargetNumber < b.TargetNumber)
+}
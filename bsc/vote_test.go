@@ -0,0 +1,28 @@
+package bsc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsMaliciousVotePair(t *testing.T) {
+	vote := func(source, target uint64) *VoteData {
+		return &VoteData{SourceNumber: source, SourceHash: Hash{byte(source)}, TargetNumber: target, TargetHash: Hash{byte(target)}}
+	}
+	require.False(t, IsMaliciousVotePair(vote(1, 2), vote(1, 2)))
+	require.False(t, IsMaliciousVotePair(vote(1, 2), vote(2, 3)))
+	require.False(t, IsMaliciousVotePair(vote(2, 2), vote(1, 2)))
+	require.False(t, IsMaliciousVotePair(vote(1, 3), vote(2, 4)))
+
+	// a double vote for the same target
+	other := vote(1, 3)
+	other.TargetHash = Hash{0xff}
+	require.True(t, IsMaliciousVotePair(vote(1, 3), other))
+	require.True(t, IsMaliciousVotePair(vote(1, 3), vote(2, 3)))
+	// a surround vote
+	require.True(t, IsMaliciousVotePair(vote(1, 5), vote(2, 4)))
+	require.True(t, IsMaliciousVotePair(vote(2, 4), vote(1, 5)))
+
+	require.NotEqual(t, vote(1, 3).Hash(), other.Hash())
+}
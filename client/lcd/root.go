@@ -18,6 +18,7 @@ import (
 	gov "github.com/cosmos/cosmos-sdk/x/gov/client/rest"
 	slashing "github.com/cosmos/cosmos-sdk/x/slashing/client/rest"
 	stake "github.com/cosmos/cosmos-sdk/x/stake/client/rest"
+	upgrade "github.com/cosmos/cosmos-sdk/x/upgrade/client/rest"
 	"github.com/gorilla/mux"
 	"github.com/rakyll/statik/fs"
 	"github.com/spf13/cobra"
@@ -157,6 +158,7 @@ func createHandler(cdc *codec.Codec) *mux.Router {
 	stake.RegisterRoutes(cliCtx, r, cdc, kb)
 	slashing.RegisterRoutes(cliCtx, r, cdc, kb)
 	gov.RegisterRoutes(cliCtx, r, cdc)
+	upgrade.RegisterRoutes(cliCtx, r, cdc, "upgrade")
 
 	return r
 }
@@ -22,10 +22,12 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/ibc"
 	"github.com/cosmos/cosmos-sdk/x/mint"
+	"github.com/cosmos/cosmos-sdk/x/oracle"
 	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/sidechain"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/stake"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 const (
@@ -63,6 +65,8 @@ type GaiaApp struct {
 	tkeyParams       *sdk.TransientStoreKey
 	keyIbc           *sdk.KVStoreKey
 	keySide          *sdk.KVStoreKey
+	keyUpgrade       *sdk.KVStoreKey
+	keyOracle        *sdk.KVStoreKey
 
 	// Manage getting and setting accounts
 	accountKeeper       auth.AccountKeeper
@@ -75,6 +79,8 @@ type GaiaApp struct {
 	govKeeper           gov.Keeper
 	paramsKeeper        params.Keeper
 	ibcKeeper           ibc.Keeper
+	upgradeKeeper       upgrade.Keeper
+	oracleKeeper        oracle.Keeper
 }
 
 // NewGaiaApp returns a reference to an initialized GaiaApp.
@@ -102,6 +108,8 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 		tkeyParams:       sdk.NewTransientStoreKey("transient_params"),
 		keyIbc:           sdk.NewKVStoreKey("ibc"),
 		keySide:          sdk.NewKVStoreKey("sc"),
+		keyUpgrade:       sdk.NewKVStoreKey(upgrade.StoreKey),
+		keyOracle:        sdk.NewKVStoreKey("oracle"),
 	}
 
 	// define the accountKeeper
@@ -121,8 +129,10 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 		app.cdc,
 		app.keyParams, app.tkeyParams,
 	)
+	scKeeper := sidechain.NewKeeper(app.keySide, app.paramsKeeper.Subspace(sidechain.DefaultParamspace), app.cdc)
 	app.ibcKeeper = ibc.NewKeeper(app.keyIbc, app.paramsKeeper.Subspace(ibc.DefaultParamspace), ibc.DefaultCodespace,
-		sidechain.NewKeeper(app.keySide, app.paramsKeeper.Subspace(sidechain.DefaultParamspace), app.cdc))
+		scKeeper)
+	app.ibcKeeper.SetBankKeeper(app.bankKeeper)
 	app.stakeKeeper = stake.NewKeeper(
 		app.cdc,
 		app.keyStake, app.keyStakeReward, app.tkeyStake,
@@ -155,10 +165,15 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 		app.RegisterCodespace(gov.DefaultCodespace),
 		app.Pool,
 	)
+	app.upgradeKeeper = upgrade.NewKeeper(app.cdc, app.keyUpgrade, app.RegisterCodespace(upgrade.DefaultCodespace))
+	app.upgradeKeeper.SetGovKeeper(&app.govKeeper)
+	app.govKeeper.AddHooks(gov.ProposalTypeSoftwareUpgrade, upgrade.NewUpgradePlanHooks(app.upgradeKeeper))
 
 	// register the staking hooks
 	app.stakeKeeper = app.stakeKeeper.WithHooks(
 		NewHooks(app.distrKeeper.Hooks(), app.slashingKeeper.Hooks()))
+	app.oracleKeeper = oracle.NewKeeper(app.cdc, app.keyOracle, app.paramsKeeper.Subspace(oracle.DefaultParamSpace),
+		app.stakeKeeper, scKeeper, app.ibcKeeper, app.bankKeeper, app.Pool)
 
 	// register message routes
 	app.Router().
@@ -170,16 +185,19 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 
 	app.QueryRouter().
 		AddRoute("gov", gov.NewQuerier(app.govKeeper)).
-		AddRoute("stake", stake.NewQuerier(app.stakeKeeper, app.cdc))
+		AddRoute("stake", stake.NewQuerier(app.stakeKeeper, app.cdc)).
+		AddRoute(upgrade.StoreKey, upgrade.NewQuerier(app.upgradeKeeper))
 
 	// initialize BaseApp
 	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyStake, app.keyStakeReward, app.keyMint, app.keyDistr,
-		app.keySlashing, app.keyGov, app.keyFeeCollection, app.keyParams, app.keyIbc)
+		app.keySlashing, app.keyGov, app.keyFeeCollection, app.keyParams, app.keyIbc, app.keyUpgrade, app.keyOracle)
 	app.SetInitChainer(app.initChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
 	app.SetAnteHandler(auth.NewAnteHandler(app.accountKeeper))
 	app.MountStoresTransient(app.tkeyParams, app.tkeyStake, app.tkeyDistr)
 	app.SetEndBlocker(app.EndBlocker)
+	sdk.UpgradeMgr.SetStoreMigrator(app.StoreMigrator())
+	oracle.RegisterStoreMigrations(app.BaseApp, app.oracleKeeper)
 
 	err := app.LoadCMSLatestVersion()
 	if err != nil {
@@ -194,6 +212,12 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 		cmn.Exit(err.Error())
 	}
 
+	// plans scheduled by governance have to be known before any block is executed
+	err = app.upgradeKeeper.LoadPlans(app.NewContext(sdk.RunTxModeCheck, abci.Header{}))
+	if err != nil {
+		cmn.Exit(err.Error())
+	}
+
 	return app
 }
 
@@ -213,6 +237,9 @@ func MakeCodec() *codec.Codec {
 
 // application updates every end block
 func (app *GaiaApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	// apply the upgrades of this height first, halts if the binary can't
+	sdk.UpgradeMgr.BeginBlocker(ctx)
+
 	tags := slashing.BeginBlocker(ctx, req, app.slashingKeeper)
 
 	// distribute rewards from previous block
@@ -233,6 +260,8 @@ func (app *GaiaApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.R
 	gov.EndBlocker(ctx, app.govKeeper)
 	validatorUpdates, _ := stake.EndBlocker(ctx, app.stakeKeeper)
 	ibc.EndBlocker(ctx, app.ibcKeeper)
+	oracle.EndBlocker(ctx, app.oracleKeeper)
+	app.upgradeKeeper.EndBlock(ctx)
 
 	// Add these new validators to the addr -> pubkey map.
 	app.slashingKeeper.AddValidators(ctx, validatorUpdates)
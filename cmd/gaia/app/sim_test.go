@@ -63,6 +63,7 @@ func appStateFn(r *rand.Rand, accs []simulation.Account) json.RawMessage {
 	govGenesis := gov.DefaultGenesisState()
 	stakeGenesis := stake.DefaultGenesisState()
 	slashingGenesis := slashing.DefaultGenesisState()
+	slashingGenesis.Params = slashingsim.RandomizedParams(r, slashingGenesis.Params)
 	var validators []stake.Validator
 	var delegations []stake.Delegation
 
@@ -126,7 +127,7 @@ func invariants(app *GaiaApp) []simulation.Invariant {
 		banksim.NonnegativeBalanceInvariant(app.accountKeeper),
 		govsim.AllInvariants(),
 		stakesim.AllInvariants(app.bankKeeper, app.stakeKeeper, app.distrKeeper, app.accountKeeper),
-		slashingsim.AllInvariants(),
+		slashingsim.AllInvariants(app.slashingKeeper),
 	}
 }
 
@@ -144,6 +145,7 @@ func BenchmarkFullGaiaSimulation(b *testing.B) {
 		os.RemoveAll(dir)
 	}()
 	app := NewGaiaApp(logger, db, nil)
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ProgressiveSlashing, 1)
 
 	// Run randomized simulation
 	// TODO parameterize numbers, save for a later PR
@@ -181,6 +183,7 @@ func TestFullGaiaSimulation(t *testing.T) {
 	}
 	db := dbm.NewMemDB()
 	app := NewGaiaApp(logger, db, nil)
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ProgressiveSlashing, 1)
 	require.Equal(t, "GaiaApp", app.Name())
 
 	// Run randomized simulation
@@ -216,6 +219,7 @@ func TestAppStateDeterminism(t *testing.T) {
 			logger := log.NewNopLogger()
 			db := dbm.NewMemDB()
 			app := NewGaiaApp(logger, db, nil)
+			sdk.UpgradeMgr.AddUpgradeHeight(sdk.ProgressiveSlashing, 1)
 
 			// Run randomized simulation
 			simulation.SimulateFromSeed(
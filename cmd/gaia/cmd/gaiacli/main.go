@@ -20,15 +20,21 @@ import (
 	bankcmd "github.com/cosmos/cosmos-sdk/x/bank/client/cli"
 	distrcmd "github.com/cosmos/cosmos-sdk/x/distribution/client/cli"
 	govcmd "github.com/cosmos/cosmos-sdk/x/gov/client/cli"
+	ibccmd "github.com/cosmos/cosmos-sdk/x/ibc/client/cli"
+	lightclientcmd "github.com/cosmos/cosmos-sdk/x/lightclient/client/cli"
+	oraclecmd "github.com/cosmos/cosmos-sdk/x/oracle/client/cli"
 	slashingcmd "github.com/cosmos/cosmos-sdk/x/slashing/client/cli"
 	stakecmd "github.com/cosmos/cosmos-sdk/x/stake/client/cli"
+	upgradecmd "github.com/cosmos/cosmos-sdk/x/upgrade/client/cli"
 )
 
 const (
 	storeAcc      = "acc"
 	storeGov      = "gov"
+	storeIBC      = "ibc"
 	storeSlashing = "slashing"
 	storeStake    = "stake"
+	storeUpgrade  = "upgrade"
 )
 
 // rootCmd is the entry point for this binary
@@ -83,6 +89,10 @@ func main() {
 		govcmd.GetCmdQueryVote(storeGov, cdc),
 		govcmd.GetCmdQueryVotes(storeGov, cdc),
 	)...)
+	queryCmd.AddCommand(upgradecmd.GetQueryCmd(storeUpgrade, cdc))
+	oraclecmd.AddCommands(queryCmd, cdc)
+	ibccmd.AddCommands(queryCmd, storeIBC, cdc)
+	lightclientcmd.AddCommands(queryCmd, cdc)
 
 	//Add query commands
 	txCmd := &cobra.Command{
@@ -9,6 +9,7 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/x/ibc"
+	"github.com/cosmos/cosmos-sdk/x/oracle"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -141,6 +142,7 @@ type GaiaApp struct {
 	tkeyParams     *sdk.TransientStoreKey
 	keyIbc         *sdk.KVStoreKey
 	keySide        *sdk.KVStoreKey
+	keyOracle      *sdk.KVStoreKey
 
 	// Manage getting and setting accounts
 	accountKeeper  auth.AccountKeeper
@@ -149,6 +151,7 @@ type GaiaApp struct {
 	slashingKeeper slashing.Keeper
 	paramsKeeper   params.Keeper
 	ibcKeeper      ibc.Keeper
+	oracleKeeper   oracle.Keeper
 }
 
 func NewGaiaApp(logger log.Logger, db dbm.DB, baseAppOptions ...func(*bam.BaseApp)) *GaiaApp {
@@ -171,6 +174,7 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, baseAppOptions ...func(*bam.BaseAp
 		tkeyParams:     sdk.NewTransientStoreKey("transient_params"),
 		keyIbc:         sdk.NewKVStoreKey("ibc"),
 		keySide:        sdk.NewKVStoreKey("sc"),
+		keyOracle:      sdk.NewKVStoreKey("oracle"),
 	}
 
 	// define the accountKeeper
@@ -183,10 +187,13 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, baseAppOptions ...func(*bam.BaseAp
 	// add handlers
 	app.bankKeeper = bank.NewBaseKeeper(app.accountKeeper)
 	app.paramsKeeper = params.NewKeeper(app.cdc, app.keyParams, app.tkeyParams)
-	app.ibcKeeper = ibc.NewKeeper(app.keyIbc, app.paramsKeeper.Subspace(ibc.DefaultParamspace), ibc.DefaultCodespace, sidechain.NewKeeper(app.keySide, app.paramsKeeper.Subspace(sidechain.DefaultParamspace), app.cdc))
+	scKeeper := sidechain.NewKeeper(app.keySide, app.paramsKeeper.Subspace(sidechain.DefaultParamspace), app.cdc)
+	app.ibcKeeper = ibc.NewKeeper(app.keyIbc, app.paramsKeeper.Subspace(ibc.DefaultParamspace), ibc.DefaultCodespace, scKeeper)
 
 	app.stakeKeeper = stake.NewKeeper(app.cdc, app.keyStake, app.keyStakeReward, app.tkeyStake, app.bankKeeper, nil, app.paramsKeeper.Subspace(stake.DefaultParamspace), app.RegisterCodespace(stake.DefaultCodespace), sdk.ChainID(0), "")
 	app.slashingKeeper = slashing.NewKeeper(app.cdc, app.keySlashing, app.stakeKeeper, app.paramsKeeper.Subspace(slashing.DefaultParamspace), app.RegisterCodespace(slashing.DefaultCodespace), app.bankKeeper)
+	app.oracleKeeper = oracle.NewKeeper(app.cdc, app.keyOracle, app.paramsKeeper.Subspace(oracle.DefaultParamSpace),
+		app.stakeKeeper, scKeeper, app.ibcKeeper, app.bankKeeper, app.Pool)
 
 	// register message routes
 	app.Router().
@@ -198,7 +205,7 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, baseAppOptions ...func(*bam.BaseAp
 	app.SetBeginBlocker(app.BeginBlocker)
 	app.SetEndBlocker(app.EndBlocker)
 	app.SetAnteHandler(auth.NewAnteHandler(app.accountKeeper))
-	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyStake, app.keySlashing, app.keyParams)
+	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyStake, app.keySlashing, app.keyParams, app.keyOracle)
 	app.MountStore(app.tkeyParams, sdk.StoreTypeTransient)
 	err := app.LoadLatestVersion(app.keyMain)
 	if err != nil {
@@ -238,6 +245,7 @@ func (app *GaiaApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.R
 	ctx = ctx.WithEventManager(sdk.NewEventManager())
 	validatorUpdates, _ := stake.EndBlocker(ctx, app.stakeKeeper)
 	ibc.EndBlocker(ctx, app.ibcKeeper)
+	oracle.EndBlocker(ctx, app.oracleKeeper)
 
 	return abci.ResponseEndBlock{
 		ValidatorUpdates: validatorUpdates,
@@ -0,0 +1,197 @@
+// Package bridge streams pubsub events to clients running out of the node process over WebSocket.
+//
+// A client opens a WebSocket connection and sends a Request naming itself, the encoding and its
+// subscriptions. Every client is a durable subscriber of the pubsub server, its cursors only move
+// when it acknowledges events, so events which were sent but not acknowledged before a disconnection
+// are sent again when the client comes back with the same client ID.
+package bridge
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/pubsub"
+)
+
+const writeWait = 10 * time.Second
+
+// Subscription of a client, FromHeight replays the journal from that height instead of the stored cursor.
+type Subscription struct {
+	Pattern    pubsub.Topic `json:"pattern"`
+	Filter     string       `json:"filter,omitempty"`
+	FromHeight int64        `json:"from_height,omitempty"`
+}
+
+// Request is the first message sent by a client.
+type Request struct {
+	ClientID      pubsub.ClientID `json:"client_id"`
+	Encoding      string          `json:"encoding"`
+	Subscriptions []Subscription  `json:"subscriptions"`
+}
+
+// Message is sent to clients, either the answer to the Request or an event.
+type Message struct {
+	Ready   bool            `json:"ready,omitempty"`
+	Error   string          `json:"error,omitempty"`
+	Pattern pubsub.Topic    `json:"pattern,omitempty"`
+	Topic   pubsub.Topic    `json:"topic,omitempty"`
+	Height  int64           `json:"height"`
+	Index   int64           `json:"index"`
+	Type    string          `json:"type,omitempty"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// Ack is sent by clients once the events of Pattern up to Height and Index are processed.
+type Ack struct {
+	Pattern pubsub.Topic `json:"pattern"`
+	Height  int64        `json:"height"`
+	Index   int64        `json:"index"`
+}
+
+// Bridge is an http.Handler serving WebSocket clients.
+type Bridge struct {
+	server    *pubsub.Server
+	encodings map[string]Encoding
+	upgrader  websocket.Upgrader
+	logger    log.Logger
+}
+
+// NewBridge creates a bridge of server, which must have a journal. JSON is always supported,
+// other encodings like AminoEncoding can be added.
+func NewBridge(server *pubsub.Server, logger log.Logger, encodings ...Encoding) (*Bridge, error) {
+	if server.Journal() == nil {
+		return nil, pubsub.ErrNoJournal
+	}
+	bridge := &Bridge{
+		server:    server,
+		encodings: map[string]Encoding{EncodingJSON: JSONEncoding{}},
+		logger:    logger,
+	}
+	for _, encoding := range encodings {
+		bridge.encodings[encoding.Name()] = encoding
+	}
+	return bridge, nil
+}
+
+func (b *Bridge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := b.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		b.logger.Error("failed to upgrade connection", "remote", r.RemoteAddr, "err", err)
+		return
+	}
+	defer conn.Close()
+
+	c := &connection{conn: conn, logger: b.logger.With("remote", r.RemoteAddr)}
+	var req Request
+	if err := conn.ReadJSON(&req); err != nil {
+		c.logger.Error("failed to read request", "err", err)
+		return
+	}
+	sub, err := b.subscribe(c, req)
+	if err != nil {
+		c.write(Message{Error: err.Error()})
+		return
+	}
+	defer sub.UnsubscribeAll()
+
+	if err := c.write(Message{Ready: true}); err != nil {
+		return
+	}
+	for {
+		var ack Ack
+		if err := conn.ReadJSON(&ack); err != nil {
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				c.logger.Error("failed to read ack", "client", req.ClientID, "err", err)
+			}
+			return
+		}
+		if err := sub.Ack(ack.Pattern, pubsub.Position{Height: ack.Height, Index: ack.Index}); err != nil {
+			c.write(Message{Error: err.Error()})
+			return
+		}
+	}
+}
+
+func (b *Bridge) subscribe(c *connection, req Request) (*pubsub.Subscriber, error) {
+	if req.ClientID == "" {
+		return nil, errors.New("client_id is empty")
+	}
+	if len(req.Subscriptions) == 0 {
+		return nil, errors.New("no subscription")
+	}
+	encoding, ok := b.encodings[req.Encoding]
+	if !ok {
+		return nil, fmt.Errorf("unsupported encoding %q", req.Encoding)
+	}
+
+	options := make([][]pubsub.SubscribeOption, 0, len(req.Subscriptions))
+	for _, s := range req.Subscriptions {
+		var opts []pubsub.SubscribeOption
+		if s.Filter != "" {
+			filter, err := pubsub.NewFilter(s.Filter)
+			if err != nil {
+				return nil, fmt.Errorf("invalid filter of %s: %v", s.Pattern, err)
+			}
+			opts = append(opts, pubsub.WithFilter(filter))
+		}
+		if s.FromHeight > 0 {
+			opts = append(opts, pubsub.FromHeight(s.FromHeight))
+		}
+		options = append(options, opts)
+	}
+
+	sub, err := b.server.NewSubscriber(req.ClientID, c.logger,
+		pubsub.Durable(), pubsub.ManualAck(), pubsub.WithPolicy(pubsub.PolicySpill))
+	if err != nil {
+		return nil, err
+	}
+	for i, s := range req.Subscriptions {
+		pattern := s.Pattern
+		err = sub.SubscribeWithPosition(pattern, func(pos pubsub.Position, event pubsub.Event) {
+			data, err := encoding.Marshal(event)
+			if err != nil {
+				c.logger.Error("failed to encode event", "type", pubsub.EventName(event), "err", err)
+				return
+			}
+			c.write(Message{
+				Pattern: pattern,
+				Topic:   event.GetTopic(),
+				Height:  pos.Height,
+				Index:   pos.Index,
+				Type:    pubsub.EventName(event),
+				Data:    data,
+			})
+		}, options[i]...)
+		if err != nil {
+			sub.UnsubscribeAll()
+			return nil, err
+		}
+	}
+	return sub, nil
+}
+
+type connection struct {
+	mtx    sync.Mutex
+	conn   *websocket.Conn
+	logger log.Logger
+}
+
+func (c *connection) write(msg Message) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+	err := c.conn.WriteJSON(msg)
+	if err != nil {
+		c.logger.Error("failed to write message", "err", err)
+		// the reader fails as well and releases the subscriber
+		c.conn.Close()
+	}
+	return err
+}
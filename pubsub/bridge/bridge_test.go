@@ -0,0 +1,121 @@
+package bridge
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/pubsub"
+)
+
+func setupBridge(t *testing.T) (*pubsub.Server, string) {
+	server := pubsub.NewServer(nil, pubsub.WithJournal(pubsub.NewJournal(dbm.NewMemDB(), 0)))
+	require.Nil(t, server.Start())
+	t.Cleanup(func() { server.Stop() })
+
+	bridge, err := NewBridge(server, log.NewNopLogger(), AminoEncoding{Cdc: codec.New()})
+	require.Nil(t, err)
+	ts := httptest.NewServer(bridge)
+	t.Cleanup(ts.Close)
+	return server, "ws" + strings.TrimPrefix(ts.URL, "http")
+}
+
+func dial(t *testing.T, url string, req Request, encoding Encoding) *Client {
+	var client *Client
+	// the previous connection of the same client may not be released yet
+	require.Eventually(t, func() bool {
+		var err error
+		client, err = Dial(url, req, encoding)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	return client
+}
+
+func TestBridgeAckAndRedeliver(t *testing.T) {
+	server, url := setupBridge(t)
+	req := Request{
+		ClientID:      "indexer",
+		Encoding:      EncodingJSON,
+		Subscriptions: []Subscription{{Pattern: "cross-*", Filter: "ChainId = 'bsc'"}},
+	}
+	client := dial(t, url, req, JSONEncoding{})
+
+	server.SetHeight(1)
+	server.Publish(pubsub.CrossTransferEvent{ChainId: "bsc", RelayerFee: 1})
+	server.Publish(pubsub.CrossTransferEvent{ChainId: "eth", RelayerFee: 2})
+	server.Publish(pubsub.CrossTransferEvent{ChainId: "bsc", RelayerFee: 3})
+
+	msg, event, err := client.Next()
+	require.Nil(t, err)
+	require.Equal(t, pubsub.Topic("cross-*"), msg.Pattern)
+	require.Equal(t, pubsub.CrossTransferTopic, msg.Topic)
+	require.Equal(t, int64(1), msg.Height)
+	require.Equal(t, int64(0), msg.Index)
+	require.Equal(t, pubsub.CrossTransferEvent{ChainId: "bsc", RelayerFee: 1}, event)
+	require.Nil(t, client.Ack(msg))
+
+	msg, event, err = client.Next()
+	require.Nil(t, err)
+	require.Equal(t, int64(2), msg.Index)
+	require.Equal(t, int64(3), event.(pubsub.CrossTransferEvent).RelayerFee)
+	// the second event is not acknowledged before the client goes away
+	require.Nil(t, client.Close())
+
+	server.Publish(pubsub.CrossTransferEvent{ChainId: "bsc", RelayerFee: 4})
+
+	client = dial(t, url, req, JSONEncoding{})
+	defer client.Close()
+	var fees []int64
+	for i := 0; i < 2; i++ {
+		_, event, err = client.Next()
+		require.Nil(t, err)
+		fees = append(fees, event.(pubsub.CrossTransferEvent).RelayerFee)
+	}
+	require.Equal(t, []int64{3, 4}, fees)
+}
+
+func TestBridgeAminoReplay(t *testing.T) {
+	server, url := setupBridge(t)
+	server.SetHeight(1)
+	server.Publish(pubsub.CrossTransferEvent{ChainId: "bsc", RelayerFee: 1})
+	server.SetHeight(2)
+	server.Publish(pubsub.CrossTransferEvent{ChainId: "bsc", RelayerFee: 2, To: []pubsub.CrossReceiver{{Addr: "addr", Amount: 2}}})
+
+	encoding := AminoEncoding{Cdc: codec.New()}
+	client := dial(t, url, Request{
+		ClientID:      "pipeline",
+		Encoding:      EncodingAmino,
+		Subscriptions: []Subscription{{Pattern: pubsub.CrossTransferTopic, FromHeight: 2}},
+	}, encoding)
+	defer client.Close()
+
+	msg, event, err := client.Next()
+	require.Nil(t, err)
+	require.Equal(t, int64(2), msg.Height)
+	require.Equal(t, pubsub.CrossTransferEvent{ChainId: "bsc", RelayerFee: 2, To: []pubsub.CrossReceiver{{Addr: "addr", Amount: 2}}}, event)
+}
+
+func TestBridgeBadRequest(t *testing.T) {
+	_, url := setupBridge(t)
+
+	_, err := Dial(url, Request{ClientID: "c", Encoding: EncodingJSON}, JSONEncoding{})
+	require.EqualError(t, err, "no subscription")
+
+	_, err = Dial(url, Request{ClientID: "c", Encoding: "xml",
+		Subscriptions: []Subscription{{Pattern: "stake"}}}, JSONEncoding{})
+	require.NotNil(t, err)
+
+	_, err = Dial(url, Request{ClientID: "c", Encoding: EncodingJSON,
+		Subscriptions: []Subscription{{Pattern: "stake", Filter: "Chain ="}}}, JSONEncoding{})
+	require.NotNil(t, err)
+
+	server := pubsub.NewServer(nil)
+	_, err = NewBridge(server, log.NewNopLogger())
+	require.Equal(t, pubsub.ErrNoJournal, err)
+}
@@ -0,0 +1,69 @@
+package bridge
+
+import (
+	"errors"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/cosmos/cosmos-sdk/pubsub"
+)
+
+// Client is a reference client of the bridge, it's not safe for concurrent use.
+type Client struct {
+	conn     *websocket.Conn
+	encoding Encoding
+}
+
+// Dial connects to the bridge at url, e.g. "ws://localhost:26660/events", and sends req.
+// encoding has to match req.Encoding.
+func Dial(url string, req Request, encoding Encoding) (*Client, error) {
+	if req.Encoding != encoding.Name() {
+		return nil, errors.New("encoding does not match the request")
+	}
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.WriteJSON(req); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	var msg Message
+	if err := conn.ReadJSON(&msg); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if !msg.Ready {
+		conn.Close()
+		return nil, errors.New(msg.Error)
+	}
+	return &Client{conn: conn, encoding: encoding}, nil
+}
+
+// Next blocks until the next event is received.
+func (c *Client) Next() (Message, pubsub.Event, error) {
+	var msg Message
+	if err := c.conn.ReadJSON(&msg); err != nil {
+		return msg, nil, err
+	}
+	if msg.Error != "" {
+		return msg, nil, errors.New(msg.Error)
+	}
+	event, err := c.encoding.Unmarshal(msg.Type, msg.Data)
+	return msg, event, err
+}
+
+// Ack acknowledges msg and every message received before it for the same pattern.
+func (c *Client) Ack(msg Message) error {
+	return c.conn.WriteJSON(Ack{Pattern: msg.Pattern, Height: msg.Height, Index: msg.Index})
+}
+
+func (c *Client) Close() error {
+	err := c.conn.WriteMessage(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	if err != nil {
+		c.conn.Close()
+		return err
+	}
+	return c.conn.Close()
+}
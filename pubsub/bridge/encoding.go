@@ -0,0 +1,69 @@
+package bridge
+
+import (
+	"encoding/json"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/pubsub"
+)
+
+const (
+	EncodingJSON  = "json"
+	EncodingAmino = "amino"
+)
+
+// Encoding serializes events streamed by the bridge, the payload must be valid JSON
+// since it is embedded in a Message.
+type Encoding interface {
+	Name() string
+	Marshal(event pubsub.Event) (json.RawMessage, error)
+	Unmarshal(name string, data json.RawMessage) (pubsub.Event, error)
+}
+
+// JSONEncoding streams events as plain JSON objects.
+type JSONEncoding struct{}
+
+var _ Encoding = JSONEncoding{}
+
+func (JSONEncoding) Name() string {
+	return EncodingJSON
+}
+
+func (JSONEncoding) Marshal(event pubsub.Event) (json.RawMessage, error) {
+	return json.Marshal(event)
+}
+
+func (JSONEncoding) Unmarshal(name string, data json.RawMessage) (pubsub.Event, error) {
+	return pubsub.DecodeEvent(name, func(ptr interface{}) error {
+		return json.Unmarshal(data, ptr)
+	})
+}
+
+// AminoEncoding streams events as base64 strings of their length prefixed amino binary encoding.
+type AminoEncoding struct {
+	Cdc *codec.Codec
+}
+
+var _ Encoding = AminoEncoding{}
+
+func (AminoEncoding) Name() string {
+	return EncodingAmino
+}
+
+func (e AminoEncoding) Marshal(event pubsub.Event) (json.RawMessage, error) {
+	bz, err := e.Cdc.MarshalBinaryLengthPrefixed(event)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(bz)
+}
+
+func (e AminoEncoding) Unmarshal(name string, data json.RawMessage) (pubsub.Event, error) {
+	var bz []byte
+	if err := json.Unmarshal(data, &bz); err != nil {
+		return nil, err
+	}
+	return pubsub.DecodeEvent(name, func(ptr interface{}) error {
+		return e.Cdc.UnmarshalBinaryLengthPrefixed(bz, ptr)
+	})
+}
@@ -6,12 +6,19 @@ const (
 	CrossTransferTopic = Topic("cross-transfer")
 )
 
+func init() {
+	RegisterEvent(CrossTransferEvent{})
+}
+
 type Event interface {
 	GetTopic() Topic
 }
 
 type Handler func(Event)
 
+// PositionHandler also receives the position of the event in the journal, e.g. to acknowledge it later.
+type PositionHandler func(Position, Event)
+
 type CrossReceiver struct {
 	Addr   string
 	Amount int64
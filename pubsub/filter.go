@@ -0,0 +1,136 @@
+package pubsub
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/pubsub/query"
+)
+
+// ErrBadPattern is returned when subscribing a malformed topic pattern.
+var ErrBadPattern = errors.New("malformed topic pattern")
+
+// TopicAttribute is the attribute holding the topic of an event in filter expressions.
+const TopicAttribute = "Topic"
+
+// Match reports whether topic matches the pattern t. Patterns are hierarchical and
+// separated by '/', a '*' matches any sequence within one level, e.g. "stake/*" or "cross-*".
+// A topic without any wildcard only matches itself.
+func (t Topic) Match(topic Topic) bool {
+	matched, err := path.Match(string(t), string(topic))
+	return err == nil && matched
+}
+
+func (t Topic) validate() error {
+	if _, err := path.Match(string(t), ""); err != nil {
+		return ErrBadPattern
+	}
+	return nil
+}
+
+// Filter is a condition evaluated by the server against the fields of an event,
+// written in the tendermint query language, e.g. "ChainId = 'bsc' AND Denom = 'BNB'".
+// Fields of embedded structs are promoted and nested fields are joined by a dot, so
+// "To.Addr = 'bnb1...'" matches a CrossTransferEvent with any receiver at that address.
+type Filter struct {
+	query *query.Query
+}
+
+func NewFilter(expr string) (*Filter, error) {
+	q, err := query.New(expr)
+	if err != nil {
+		return nil, err
+	}
+	return &Filter{query: q}, nil
+}
+
+func MustNewFilter(expr string) *Filter {
+	filter, err := NewFilter(expr)
+	if err != nil {
+		panic(err)
+	}
+	return filter
+}
+
+func (f *Filter) String() string {
+	return f.query.String()
+}
+
+// Matches evaluates the filter against the attributes of an event, see EventAttributes.
+func (f *Filter) Matches(attrs map[string][]string) bool {
+	matched, err := f.query.Matches(attrs)
+	return err == nil && matched
+}
+
+// EventAttributes flattens the exported fields of event into the attributes filters are evaluated against.
+func EventAttributes(event Event) map[string][]string {
+	attrs := map[string][]string{
+		TopicAttribute: {string(event.GetTopic())},
+	}
+	collectAttributes(reflect.ValueOf(event), "", attrs)
+	return attrs
+}
+
+func collectAttributes(v reflect.Value, name string, attrs map[string][]string) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+	case reflect.Invalid:
+		return
+	}
+
+	if v.CanInterface() {
+		switch value := v.Interface().(type) {
+		case time.Time:
+			attrs[name] = append(attrs[name], value.Format(query.TimeLayout))
+			return
+		case fmt.Stringer:
+			attrs[name] = append(attrs[name], value.String())
+			return
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		collectAttributes(v.Elem(), name, attrs)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			// like encoding/json, exported fields of embedded structs are promoted even if the struct is unexported
+			if field.Anonymous && field.Type.Kind() == reflect.Struct {
+				collectAttributes(v.Field(i), name, attrs)
+			} else if field.PkgPath == "" {
+				collectAttributes(v.Field(i), attributeName(name, field.Name), attrs)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			attrs[name] = append(attrs[name], fmt.Sprintf("%X", v.Interface()))
+			return
+		}
+		for i := 0; i < v.Len(); i++ {
+			collectAttributes(v.Index(i), name, attrs)
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			collectAttributes(v.MapIndex(key), attributeName(name, fmt.Sprint(key.Interface())), attrs)
+		}
+	default:
+		if name != "" {
+			attrs[name] = append(attrs[name], fmt.Sprint(v.Interface()))
+		}
+	}
+}
+
+func attributeName(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return strings.Join([]string{prefix, name}, ".")
+}
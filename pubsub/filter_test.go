@@ -0,0 +1,86 @@
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopicMatch(t *testing.T) {
+	tests := []struct {
+		pattern Topic
+		topic   Topic
+		match   bool
+	}{
+		{"stake", "stake", true},
+		{"stake", "stake/delegate", false},
+		{"stake/*", "stake/delegate", true},
+		{"stake/*", "stake", false},
+		{"stake/*", "stake/delegate/side", false},
+		{"cross-*", CrossTransferTopic, true},
+		{"cross-*", "oracle-event", false},
+		{"*", "slashing", true},
+		{"[", "[", false},
+	}
+	for _, tc := range tests {
+		require.Equal(t, tc.match, tc.pattern.Match(tc.topic), "%s %s", tc.pattern, tc.topic)
+	}
+	require.Equal(t, ErrBadPattern, Topic("stake/[").validate())
+}
+
+func TestFilter(t *testing.T) {
+	event := CrossTransferEvent{
+		TxHash:     "ABCD",
+		ChainId:    "bsc",
+		Type:       "TCRS",
+		RelayerFee: 100,
+		From:       "bnb1from",
+		Denom:      "BNB",
+		To:         []CrossReceiver{{"bnb1to1", 10}, {"bnb1to2", 20}},
+	}
+	attrs := EventAttributes(event)
+	require.Equal(t, []string{string(CrossTransferTopic)}, attrs[TopicAttribute])
+	require.Equal(t, []string{"bsc"}, attrs["ChainId"])
+	require.Equal(t, []string{"bnb1to1", "bnb1to2"}, attrs["To.Addr"])
+	require.Equal(t, []string{"10", "20"}, attrs["To.Amount"])
+
+	tests := []struct {
+		expr  string
+		match bool
+	}{
+		{"ChainId = 'bsc'", true},
+		{"ChainId = 'eth'", false},
+		{"ChainId = 'bsc' AND Denom = 'BNB' AND From = 'bnb1from'", true},
+		{"ChainId = 'bsc' AND Denom = 'BTC'", false},
+		{"RelayerFee > 50", true},
+		{"To.Addr = 'bnb1to2'", true},
+		{"To.Amount >= 30", false},
+		{"Topic = 'cross-transfer'", true},
+		{"Unknown = 'x'", false},
+	}
+	for _, tc := range tests {
+		require.Equal(t, tc.match, MustNewFilter(tc.expr).Matches(attrs), tc.expr)
+	}
+
+	_, err := NewFilter("ChainId = ")
+	require.NotNil(t, err)
+}
+
+func TestEventAttributesEmbeddedAndMap(t *testing.T) {
+	type inner struct {
+		Chain string
+	}
+	type outer struct {
+		testEvent
+		inner
+		Rewards map[string]int64
+		hidden  string
+	}
+	attrs := EventAttributes(outer{testEvent: testEvent{Num: 3}, inner: inner{Chain: "bsc"},
+		Rewards: map[string]int64{"val1": 5}, hidden: "x"})
+	require.Equal(t, []string{"3"}, attrs["Num"])
+	require.Equal(t, []string{"5"}, attrs["Rewards.val1"])
+	_, ok := attrs["hidden"]
+	require.False(t, ok)
+	require.Equal(t, []string{"bsc"}, attrs["Chain"])
+}
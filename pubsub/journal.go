@@ -0,0 +1,275 @@
+package pubsub
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+var (
+	// ErrNoJournal is returned when a subscriber asks for replay or spill-to-disk
+	// but the server is running without a journal.
+	ErrNoJournal = errors.New("pubsub server has no journal")
+
+	// ErrStalePosition is returned when appending an event at a position lower
+	// than the head of the journal.
+	ErrStalePosition = errors.New("position is behind journal head")
+
+	eventPrefix  = []byte{0x01}
+	cursorPrefix = []byte{0x02}
+	prunedKey    = []byte{0x03}
+)
+
+// Position identifies an event in the journal: the block height it was
+// published in and its index among the events of that height.
+type Position struct {
+	Height int64
+	Index  int64
+}
+
+func (p Position) Less(o Position) bool {
+	if p.Height != o.Height {
+		return p.Height < o.Height
+	}
+	return p.Index < o.Index
+}
+
+// Next returns the position directly after p within the same height.
+func (p Position) Next() Position {
+	return Position{Height: p.Height, Index: p.Index + 1}
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Height, p.Index)
+}
+
+func (p Position) bytes() []byte {
+	bz := make([]byte, 16)
+	binary.BigEndian.PutUint64(bz[:8], uint64(p.Height))
+	binary.BigEndian.PutUint64(bz[8:], uint64(p.Index))
+	return bz
+}
+
+func positionFromBytes(bz []byte) Position {
+	return Position{
+		Height: int64(binary.BigEndian.Uint64(bz[:8])),
+		Index:  int64(binary.BigEndian.Uint64(bz[8:16])),
+	}
+}
+
+// Entry is a journaled event which has not been decoded yet.
+type Entry struct {
+	Pos   Position        `json:"-"`
+	Topic Topic           `json:"topic"`
+	Type  string          `json:"type"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// Decode returns the event stored in the entry, its type must be registered by RegisterEvent.
+func (e Entry) Decode() (Event, error) {
+	return decodeEvent(e.Type, e.Data)
+}
+
+// Journal is a persistent, append-only log of published events keyed by
+// height and index, it also keeps the cursors of durable subscribers.
+type Journal struct {
+	db dbm.DB
+
+	// number of recent heights to keep, 0 means keep everything
+	retainBlocks int64
+
+	mtx   sync.RWMutex
+	next  Position         // position after the last appended event
+	holds map[string]int64 // lowest height every reader still has to read
+}
+
+// NewJournal creates a journal on top of db, e.g. a goleveldb opened under the node home.
+// retainBlocks is the number of recent heights kept when the server moves to a new height,
+// 0 disables pruning.
+func NewJournal(db dbm.DB, retainBlocks int64) *Journal {
+	j := &Journal{
+		db:           db,
+		retainBlocks: retainBlocks,
+		holds:        make(map[string]int64),
+	}
+	iter := db.ReverseIterator(eventPrefix, prefixEnd(eventPrefix))
+	defer iter.Close()
+	if iter.Valid() {
+		j.next = positionFromBytes(iter.Key()[len(eventPrefix):]).Next()
+	}
+	return j
+}
+
+// Next returns the position after the last appended event.
+func (j *Journal) Next() Position {
+	j.mtx.RLock()
+	defer j.mtx.RUnlock()
+	return j.next
+}
+
+// Append writes event at pos, which should not be lower than any appended position.
+func (j *Journal) Append(pos Position, event Event) error {
+	name, data, err := encodeEvent(event)
+	if err != nil {
+		return err
+	}
+	bz, err := json.Marshal(Entry{Topic: event.GetTopic(), Type: name, Data: data})
+	if err != nil {
+		return err
+	}
+
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	if pos.Less(j.next) {
+		return ErrStalePosition
+	}
+	j.db.Set(eventKey(pos), bz)
+	j.next = pos.Next()
+	return nil
+}
+
+// Iterate calls fn for every entry from position from (inclusive) until fn returns false.
+func (j *Journal) Iterate(from Position, fn func(entry Entry) bool) error {
+	iter := j.db.Iterator(eventKey(from), prefixEnd(eventPrefix))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var entry Entry
+		if err := json.Unmarshal(iter.Value(), &entry); err != nil {
+			return err
+		}
+		entry.Pos = positionFromBytes(iter.Key()[len(eventPrefix):])
+		if !fn(entry) {
+			break
+		}
+	}
+	return nil
+}
+
+// Rewind drops all the events at or above height, they were published by a block
+// that was never committed and will be published again when it is replayed.
+func (j *Journal) Rewind(height int64) {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	start := Position{Height: height}
+	if j.next.Less(start) || j.next == start {
+		return
+	}
+	j.deleteRange(eventKey(start), prefixEnd(eventPrefix))
+	j.next = start
+}
+
+// Prune drops all the events below height.
+func (j *Journal) Prune(height int64) {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	j.deleteRange(eventPrefix, eventKey(Position{Height: height}))
+	if height > j.pruned() {
+		bz := make([]byte, 8)
+		binary.BigEndian.PutUint64(bz, uint64(height))
+		j.db.Set(prunedKey, bz)
+	}
+}
+
+// Pruned returns the height below which the events are pruned, 0 if the journal is never pruned.
+func (j *Journal) Pruned() int64 {
+	j.mtx.RLock()
+	defer j.mtx.RUnlock()
+	return j.pruned()
+}
+
+func (j *Journal) pruned() int64 {
+	bz := j.db.Get(prunedKey)
+	if bz == nil {
+		return 0
+	}
+	return int64(binary.BigEndian.Uint64(bz))
+}
+
+// Hold keeps the events at or above height from being pruned when the server moves to a new height,
+// until the reader name moves its hold or releases it.
+func (j *Journal) Hold(name string, height int64) {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	j.holds[name] = height
+}
+
+// Release drops the hold of the reader name.
+func (j *Journal) Release(name string) {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	delete(j.holds, name)
+}
+
+func (j *Journal) deleteRange(start, end []byte) {
+	iter := j.db.Iterator(start, end)
+	var keys [][]byte
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+
+	batch := j.db.NewBatch()
+	defer batch.Close()
+	for _, key := range keys {
+		batch.Delete(key)
+	}
+	batch.Write()
+}
+
+// newHeight is called by the server whenever it moves to a new height. The events of the retained heights
+// and the events some reader holds are kept.
+func (j *Journal) newHeight(height int64) {
+	j.Rewind(height)
+	if j.retainBlocks <= 0 || height <= j.retainBlocks {
+		return
+	}
+	pruneHeight := height - j.retainBlocks
+	j.mtx.RLock()
+	for _, hold := range j.holds {
+		if hold < pruneHeight {
+			pruneHeight = hold
+		}
+	}
+	j.mtx.RUnlock()
+	if pruneHeight > j.Pruned() {
+		j.Prune(pruneHeight)
+	}
+}
+
+// Cursor returns the next position the subscriber clientID should read for topic.
+func (j *Journal) Cursor(clientID ClientID, topic Topic) (Position, bool) {
+	bz := j.db.Get(cursorKey(clientID, topic))
+	if bz == nil {
+		return Position{}, false
+	}
+	return positionFromBytes(bz), true
+}
+
+func (j *Journal) SetCursor(clientID ClientID, topic Topic, pos Position) {
+	j.db.Set(cursorKey(clientID, topic), pos.bytes())
+}
+
+func (j *Journal) DeleteCursor(clientID ClientID, topic Topic) {
+	j.db.Delete(cursorKey(clientID, topic))
+}
+
+func eventKey(pos Position) []byte {
+	return append(eventPrefix, pos.bytes()...)
+}
+
+func cursorKey(clientID ClientID, topic Topic) []byte {
+	key := append(cursorPrefix, []byte(clientID)...)
+	key = append(key, 0x00)
+	return append(key, []byte(topic)...)
+}
+
+func prefixEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	end[len(end)-1]++
+	return end
+}
@@ -0,0 +1,157 @@
+package pubsub
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+const testT = Topic("test")
+
+type testEvent struct {
+	Num int
+}
+
+func (testEvent) GetTopic() Topic {
+	return testT
+}
+
+func init() {
+	RegisterEvent(testEvent{})
+}
+
+func TestJournalAppendAndIterate(t *testing.T) {
+	db := dbm.NewMemDB()
+	journal := NewJournal(db, 0)
+	require.Equal(t, Position{}, journal.Next())
+
+	require.Nil(t, journal.Append(Position{1, 0}, testEvent{Num: 1}))
+	require.Nil(t, journal.Append(Position{1, 1}, CrossTransferEvent{ChainId: "bsc"}))
+	require.Nil(t, journal.Append(Position{2, 0}, testEvent{Num: 2}))
+	require.Equal(t, ErrStalePosition, journal.Append(Position{1, 5}, testEvent{}))
+	require.NotNil(t, journal.Append(Position{3, 0}, unregisteredEvent{}))
+	require.Equal(t, Position{2, 1}, journal.Next())
+
+	var events []Event
+	err := journal.Iterate(Position{1, 1}, func(entry Entry) bool {
+		event, err := entry.Decode()
+		require.Nil(t, err)
+		events = append(events, event)
+		return true
+	})
+	require.Nil(t, err)
+	require.Equal(t, []Event{CrossTransferEvent{ChainId: "bsc"}, testEvent{Num: 2}}, events)
+
+	// the head is restored when the journal is reopened
+	require.Equal(t, Position{2, 1}, NewJournal(db, 0).Next())
+}
+
+func TestJournalRewindAndPrune(t *testing.T) {
+	journal := NewJournal(dbm.NewMemDB(), 2)
+	for h := int64(1); h <= 4; h++ {
+		require.Nil(t, journal.Append(Position{h, 0}, testEvent{Num: int(h)}))
+	}
+
+	journal.newHeight(4)
+	require.Equal(t, Position{4, 0}, journal.Next())
+
+	var heights []int64
+	err := journal.Iterate(Position{}, func(entry Entry) bool {
+		heights = append(heights, entry.Pos.Height)
+		return true
+	})
+	require.Nil(t, err)
+	require.Equal(t, []int64{2, 3}, heights)
+	require.Equal(t, int64(2), journal.Pruned())
+}
+
+func TestJournalHold(t *testing.T) {
+	journal := NewJournal(dbm.NewMemDB(), 2)
+	journal.Hold("reader", 1)
+	for h := int64(1); h <= 5; h++ {
+		journal.newHeight(h)
+		require.Nil(t, journal.Append(Position{h, 0}, testEvent{Num: int(h)}))
+	}
+	heights := func() []int64 {
+		var heights []int64
+		err := journal.Iterate(Position{}, func(entry Entry) bool {
+			heights = append(heights, entry.Pos.Height)
+			return true
+		})
+		require.Nil(t, err)
+		return heights
+	}
+
+	// the events the reader has not read are kept
+	journal.newHeight(6)
+	require.Equal(t, []int64{1, 2, 3, 4, 5}, heights())
+	require.Equal(t, int64(1), journal.Pruned())
+
+	journal.Hold("reader", 3)
+	journal.newHeight(6)
+	require.Equal(t, []int64{3, 4, 5}, heights())
+	require.Equal(t, int64(3), journal.Pruned())
+
+	journal.Release("reader")
+	journal.newHeight(6)
+	require.Equal(t, []int64{4, 5}, heights())
+}
+
+func TestJournalCursor(t *testing.T) {
+	journal := NewJournal(dbm.NewMemDB(), 0)
+	_, ok := journal.Cursor("client", testT)
+	require.False(t, ok)
+
+	journal.SetCursor("client", testT, Position{10, 3})
+	pos, ok := journal.Cursor("client", testT)
+	require.True(t, ok)
+	require.Equal(t, Position{10, 3}, pos)
+	_, ok = journal.Cursor("client", CrossTransferTopic)
+	require.False(t, ok)
+
+	journal.DeleteCursor("client", testT)
+	_, ok = journal.Cursor("client", testT)
+	require.False(t, ok)
+}
+
+type unregisteredEvent struct{}
+
+func (unregisteredEvent) GetTopic() Topic {
+	return testT
+}
+
+func TestAddCommitHookAfterStart(t *testing.T) {
+	server := NewServer(nil, WithJournal(NewJournal(dbm.NewMemDB(), 0)))
+	require.Nil(t, server.Start())
+	defer server.Stop()
+
+	// hooks are added while the server loop runs the ones added before
+	committed := make(chan int64, 20)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 10; i++ {
+			server.AddCommitHook(func(int64) {})
+		}
+	}()
+	for h := int64(1); h <= 10; h++ {
+		server.Commit(h)
+	}
+	<-done
+
+	server.AddCommitHook(func(height int64) { committed <- height })
+	server.Commit(11)
+	// earlier heights may still be queued when the hook is added
+	for {
+		select {
+		case height := <-committed:
+			if height == 11 {
+				return
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("commit hook is not called")
+		}
+	}
+}
@@ -22,8 +22,22 @@ var (
 	ErrSubscriptionNotFound = errors.New("subscription not found")
 
 	ErrNilHandler = errors.New("handler is nil")
+
+	// ErrNotDurable is returned when acknowledging events of a subscriber without durable cursors.
+	ErrNotDurable = errors.New("subscriber is not durable")
 )
 
+// Option configures a Server
+type Option func(*Server)
+
+// WithJournal makes the server persist every published event into journal,
+// which enables replay and spill-to-disk for subscribers.
+func WithJournal(journal *Journal) Option {
+	return func(server *Server) {
+		server.journal = journal
+	}
+}
+
 type operation int
 
 const (
@@ -31,6 +45,8 @@ const (
 	pub
 	unsub
 	shutdown
+	height
+	commit
 )
 
 type cmd struct {
@@ -40,9 +56,16 @@ type cmd struct {
 	topic      Topic
 	subscriber *Subscriber
 	clientID   ClientID
+	handler    PositionHandler
+	filter     *Filter
+	replay     bool
+	from       Position
 
 	// publish
 	event Event
+
+	// height
+	height int64
 }
 
 type Server struct {
@@ -51,7 +74,15 @@ type Server struct {
 	cmds chan cmd
 
 	subscribers   map[ClientID]map[Topic]bool        // clientID -> topic -> bool
-	subscriptions map[Topic]map[ClientID]*Subscriber // topic -> clientID -> subscriber
+	subscriptions map[Topic]map[ClientID]*Subscriber // topic pattern -> clientID -> subscriber
+
+	// may be nil
+	journal *Journal
+	// called in loop once a block is committed, guarded by hooksMtx as hooks are added from other goroutines
+	commitHooks []func(height int64)
+	hooksMtx    sync.Mutex
+	// position of the next published event, only accessed in loop
+	pos Position
 
 	// check if the subscriber has already been added before
 	// subscribing or unsubscribing
@@ -59,16 +90,26 @@ type Server struct {
 	wg  sync.WaitGroup
 }
 
-func NewServer(logger log.Logger) *Server {
+func NewServer(logger log.Logger, options ...Option) *Server {
 	server := &Server{
 		cmds:          make(chan cmd),
 		subscribers:   make(map[ClientID]map[Topic]bool),
 		subscriptions: make(map[Topic]map[ClientID]*Subscriber),
 	}
 	server.BaseService = *common.NewBaseService(logger, "pubsubServer", server)
+	for _, option := range options {
+		option(server)
+	}
+	if server.journal != nil {
+		server.pos = server.journal.Next()
+	}
 	return server
 }
 
+func (server *Server) Journal() *Journal {
+	return server.journal
+}
+
 func (server *Server) OnStart() error {
 	go server.loop()
 	return nil
@@ -105,16 +146,71 @@ func (server *Server) loop() {
 			}
 			// create subscription
 			server.subscriptions[cmd.topic][cmd.clientID] = cmd.subscriber
+			server.attach(cmd)
 		case pub:
 			server.push(cmd.event)
+		case height:
+			server.pos = Position{Height: cmd.height}
+			if server.journal != nil {
+				server.journal.newHeight(cmd.height)
+			}
+		case commit:
+			server.hooksMtx.Lock()
+			hooks := server.commitHooks
+			server.hooksMtx.Unlock()
+			for _, hook := range hooks {
+				hook(cmd.height)
+			}
+		}
+	}
+}
+
+// attach installs the handler of a new subscription and decides where the subscriber starts reading from:
+// the requested height, the stored cursor of a durable subscriber, or the next published event.
+func (server *Server) attach(cmd cmd) {
+	s := cmd.subscriber
+	sub := &subscription{pattern: cmd.topic, filter: cmd.filter, handler: cmd.handler, cursor: server.pos}
+	if cmd.replay {
+		sub.cursor = cmd.from
+		s.attach(sub, true)
+		return
+	}
+	if s.durable {
+		if cursor, ok := server.journal.Cursor(s.clientID, cmd.topic); ok {
+			sub.cursor = cursor
+			s.attach(sub, true)
+			return
 		}
+		server.journal.SetCursor(s.clientID, cmd.topic, server.pos)
 	}
+	s.attach(sub, false)
 }
 
 func (server *Server) push(event Event) {
-	for _, sub := range server.subscriptions[event.GetTopic()] {
-		sub.wg.Add(1)
-		sub.out <- event
+	pos := server.pos
+	server.pos = pos.Next()
+	if server.journal != nil {
+		if err := server.journal.Append(pos, event); err != nil {
+			server.Logger.Error("failed to journal event", "position", pos, "err", err)
+		}
+	}
+
+	// a subscriber matching the event with several patterns receives it once
+	topic := event.GetTopic()
+	subs := make(map[ClientID]*Subscriber)
+	for pattern, clientSubscriptions := range server.subscriptions {
+		if !pattern.Match(topic) {
+			continue
+		}
+		for clientID, sub := range clientSubscriptions {
+			subs[clientID] = sub
+		}
+	}
+	env := envelope{pos: pos, event: event}
+	for _, sub := range subs {
+		if sub.wants(&env) {
+			sub.push(env)
+		}
 	}
 	server.wg.Done()
 }
@@ -148,12 +244,46 @@ func (server *Server) remove(clientID ClientID, topic Topic) {
 	}
 	// remove client from topic map.
 	// if topic has no other clients subscribed, remove it.
+	clientSubscriptions[clientID].detach(topic)
 	delete(server.subscriptions[topic], clientID)
 	if len(server.subscriptions[topic]) == 0 {
 		delete(server.subscriptions, topic)
 	}
 }
 
+// SetHeight moves the server to a new block height, events published afterwards are positioned
+// under it. Events previously journaled at or above height are dropped, since the block is re-executed.
+func (server *Server) SetHeight(h int64) {
+	if !server.IsRunning() {
+		return
+	}
+
+	select {
+	case server.cmds <- cmd{op: height, height: h}:
+	case <-server.Quit():
+	}
+}
+
+// AddCommitHook registers hook to be called when a block is committed, after all the events of
+// that block are handled by the server. hook should not block, it's called in the server loop.
+func (server *Server) AddCommitHook(hook func(height int64)) {
+	server.hooksMtx.Lock()
+	defer server.hooksMtx.Unlock()
+	server.commitHooks = append(server.commitHooks, hook)
+}
+
+// Commit tells the server the block at height is committed.
+func (server *Server) Commit(h int64) {
+	if !server.IsRunning() {
+		return
+	}
+
+	select {
+	case server.cmds <- cmd{op: commit, height: h}:
+	case <-server.Quit():
+	}
+}
+
 func (server *Server) Publish(e Event) {
 	if !server.IsRunning() {
 		return
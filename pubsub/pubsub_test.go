@@ -4,10 +4,12 @@
 package pubsub
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
 
 	"github.com/tendermint/tendermint/libs/pubsub"
 )
@@ -70,9 +72,183 @@ func TestUnsubscribe(t *testing.T) {
 	require.False(t, server.HasSubscribed(clientId, blockT))
 }
 
-func startServer(t *testing.T) *Server {
-	pub := NewServer(nil)
+func startServer(t *testing.T, options ...Option) *Server {
+	pub := NewServer(nil, options...)
 	err := pub.Start()
 	require.Nil(t, err)
 	return pub
 }
+
+func TestSubscribeFromHeight(t *testing.T) {
+	server := startServer(t, WithJournal(NewJournal(dbm.NewMemDB(), 0)))
+	server.SetHeight(1)
+	server.Publish(testEvent{Num: 1})
+	server.Publish(testEvent{Num: 2})
+	server.SetHeight(2)
+	server.Publish(testEvent{Num: 3})
+
+	sub, err := server.NewSubscriber("test_client", nil)
+	require.Nil(t, err)
+	var got []int
+	err = sub.SubscribeFrom(testT, func(event Event) {
+		got = append(got, event.(testEvent).Num)
+	}, 2)
+	require.Nil(t, err)
+
+	server.Publish(testEvent{Num: 4})
+	sub.Wait()
+	require.Equal(t, []int{3, 4}, got)
+
+	_, err = startServer(t).NewSubscriber("test_client", nil, Durable())
+	require.Equal(t, ErrNoJournal, err)
+}
+
+func TestDurableSubscriber(t *testing.T) {
+	db := dbm.NewMemDB()
+	server := startServer(t, WithJournal(NewJournal(db, 0)))
+	server.SetHeight(1)
+
+	var got []int
+	handler := func(event Event) {
+		got = append(got, event.(testEvent).Num)
+	}
+	sub, err := server.NewSubscriber("test_client", nil, Durable())
+	require.Nil(t, err)
+	require.Nil(t, sub.Subscribe(testT, handler))
+	server.Publish(testEvent{Num: 1})
+	sub.Wait()
+	require.Nil(t, server.Stop())
+
+	// events published before the subscriber comes back are replayed from its cursor
+	server = startServer(t, WithJournal(NewJournal(db, 0)))
+	server.SetHeight(2)
+	server.Publish(testEvent{Num: 2})
+	server.Publish(testEvent{Num: 3})
+
+	sub, err = server.NewSubscriber("test_client", nil, Durable())
+	require.Nil(t, err)
+	require.Nil(t, sub.Subscribe(testT, handler))
+	server.Publish(testEvent{Num: 4})
+	sub.Wait()
+	require.Equal(t, []int{1, 2, 3, 4}, got)
+
+	cursor, ok := server.Journal().Cursor("test_client", testT)
+	require.True(t, ok)
+	require.Equal(t, Position{2, 3}, cursor)
+}
+
+func TestSpillPolicy(t *testing.T) {
+	server := startServer(t, WithJournal(NewJournal(dbm.NewMemDB(), 0)))
+	sub, err := server.NewSubscriber("test_client", nil, WithPolicy(PolicySpill), WithBufferSize(1))
+	require.Nil(t, err)
+
+	release := make(chan struct{})
+	var got []int
+	err = sub.Subscribe(testT, func(event Event) {
+		<-release
+		got = append(got, event.(testEvent).Num)
+	})
+	require.Nil(t, err)
+
+	// the publisher is never blocked by the slow subscriber
+	for i := 1; i <= 10; i++ {
+		server.Publish(testEvent{Num: i})
+	}
+	close(release)
+	server.Publish(testEvent{Num: 11})
+	sub.Wait()
+	require.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, got)
+}
+
+func TestDropPolicy(t *testing.T) {
+	server := startServer(t)
+	sub, err := server.NewSubscriber("test_client", nil, WithPolicy(PolicyDrop), WithBufferSize(1))
+	require.Nil(t, err)
+
+	release := make(chan struct{})
+	var got []int
+	err = sub.Subscribe(testT, func(event Event) {
+		<-release
+		got = append(got, event.(testEvent).Num)
+	})
+	require.Nil(t, err)
+
+	for i := 1; i <= 10; i++ {
+		server.Publish(testEvent{Num: i})
+	}
+	close(release)
+	sub.Wait()
+	require.True(t, sub.Dropped() > 0)
+	require.Equal(t, 10, len(got)+int(sub.Dropped()))
+}
+
+type subTopicEvent struct {
+	Topic Topic
+	Num   int
+}
+
+func (event subTopicEvent) GetTopic() Topic {
+	return event.Topic
+}
+
+func TestSubscribePatterns(t *testing.T) {
+	server := startServer(t)
+	sub, err := server.NewSubscriber("test_client", nil)
+	require.Nil(t, err)
+
+	var got []string
+	record := func(name string) Handler {
+		return func(event Event) {
+			got = append(got, fmt.Sprintf("%s:%d", name, event.(subTopicEvent).Num))
+		}
+	}
+	require.Nil(t, sub.Subscribe("stake/*", record("wildcard")))
+	require.Nil(t, sub.Subscribe("stake/delegate", record("exact")))
+	require.Nil(t, sub.Subscribe("*/delegate", record("suffix")))
+	require.Equal(t, ErrBadPattern, sub.Subscribe("stake/[", record("bad")))
+	require.Equal(t, ErrAlreadySubscribed, sub.Subscribe("stake/*", record("again")))
+
+	server.Publish(subTopicEvent{Topic: "stake/delegate", Num: 1})
+	server.Publish(subTopicEvent{Topic: "stake/undelegate", Num: 2})
+	server.Publish(subTopicEvent{Topic: "stake", Num: 3})
+	server.Publish(subTopicEvent{Topic: "gov/delegate", Num: 4})
+	server.Publish(subTopicEvent{Topic: "stake/delegate", Num: 5})
+	sub.Wait()
+
+	// events keep the publishing order, and an event matching several patterns
+	// is handled by each of them in the order they were subscribed
+	require.Equal(t, []string{
+		"wildcard:1", "exact:1", "suffix:1",
+		"wildcard:2",
+		"suffix:4",
+		"wildcard:5", "exact:5", "suffix:5",
+	}, got)
+}
+
+func TestSubscribeWithFilter(t *testing.T) {
+	server := startServer(t, WithJournal(NewJournal(dbm.NewMemDB(), 0)))
+	server.SetHeight(1)
+	server.Publish(CrossTransferEvent{ChainId: "bsc", Denom: "BNB", RelayerFee: 1})
+	server.Publish(CrossTransferEvent{ChainId: "eth", Denom: "BNB", RelayerFee: 2})
+
+	sub, err := server.NewSubscriber("test_client", nil)
+	require.Nil(t, err)
+	var bsc, all []int64
+	err = sub.Subscribe("cross-*", func(event Event) {
+		bsc = append(bsc, event.(CrossTransferEvent).RelayerFee)
+	}, WithFilter(MustNewFilter("ChainId = 'bsc'")), FromHeight(1))
+	require.Nil(t, err)
+	err = sub.Subscribe(CrossTransferTopic, func(event Event) {
+		all = append(all, event.(CrossTransferEvent).RelayerFee)
+	})
+	require.Nil(t, err)
+
+	server.Publish(CrossTransferEvent{ChainId: "bsc", Denom: "BNB", RelayerFee: 3})
+	server.Publish(CrossTransferEvent{ChainId: "eth", Denom: "BNB", RelayerFee: 4})
+	server.Publish(testEvent{Num: 5})
+	sub.Wait()
+
+	// the replay of the filtered subscription is not seen by the later live one
+	require.Equal(t, []int64{1, 3}, bsc)
+	require.Equal(t, []int64{3, 4}, all)
+}
@@ -0,0 +1,72 @@
+package pubsub
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// events need to be registered so that they can be restored from the journal
+var registry = struct {
+	mtx   sync.RWMutex
+	types map[string]reflect.Type
+}{types: make(map[string]reflect.Type)}
+
+// RegisterEvent registers the concrete type of event, it's safe to register a type more than once.
+func RegisterEvent(event Event) {
+	t := reflect.TypeOf(event)
+	registry.mtx.Lock()
+	defer registry.mtx.Unlock()
+	registry.types[typeName(t)] = t
+}
+
+// EventName returns the name an event is registered and journaled with.
+func EventName(event Event) string {
+	return typeName(reflect.TypeOf(event))
+}
+
+func typeName(t reflect.Type) string {
+	if t.Kind() == reflect.Ptr {
+		return "*" + typeName(t.Elem())
+	}
+	return t.PkgPath() + "." + t.Name()
+}
+
+// IsRegistered checks if the type of event is registered.
+func IsRegistered(event Event) bool {
+	registry.mtx.RLock()
+	defer registry.mtx.RUnlock()
+	_, ok := registry.types[EventName(event)]
+	return ok
+}
+
+// DecodeEvent restores an event of the registered type name, unmarshal is called
+// with a pointer to a zero value of that type.
+func DecodeEvent(name string, unmarshal func(ptr interface{}) error) (Event, error) {
+	registry.mtx.RLock()
+	t, ok := registry.types[name]
+	registry.mtx.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("event type %s is not registered", name)
+	}
+	ptr := reflect.New(t)
+	if err := unmarshal(ptr.Interface()); err != nil {
+		return nil, err
+	}
+	return ptr.Elem().Interface().(Event), nil
+}
+
+func encodeEvent(event Event) (string, []byte, error) {
+	if !IsRegistered(event) {
+		return "", nil, fmt.Errorf("event type %s is not registered", EventName(event))
+	}
+	data, err := json.Marshal(event)
+	return EventName(event), data, err
+}
+
+func decodeEvent(name string, data []byte) (Event, error) {
+	return DecodeEvent(name, func(ptr interface{}) error {
+		return json.Unmarshal(data, ptr)
+	})
+}
@@ -0,0 +1,279 @@
+package sink
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	checkpointSuffix = ".checkpoint"
+	segmentSuffix    = ".ndjson"
+)
+
+// checkpoint is stored next to the files of a sink, anything written after Offset of
+// Segment is a partially written block and is dropped when the sink is opened again.
+type checkpoint struct {
+	Height  int64  `json:"height"`
+	Segment string `json:"segment,omitempty"`
+	Offset  int64  `json:"offset"`
+}
+
+func readCheckpoint(path string) (checkpoint, error) {
+	var cp checkpoint
+	bz, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cp, nil
+	} else if err != nil {
+		return cp, err
+	}
+	err = json.Unmarshal(bz, &cp)
+	return cp, err
+}
+
+func writeCheckpoint(path string, cp checkpoint) error {
+	bz, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, bz, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func encodeRecords(records []Record) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// openAt opens path for appending after dropping anything beyond offset.
+func openAt(path string, offset int64) (*os.File, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := file.Truncate(offset); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if _, err := file.Seek(offset, 0); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return file, nil
+}
+
+func appendSync(file *os.File, bz []byte) error {
+	if _, err := file.Write(bz); err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
+//__________________________________________________________________
+
+// FileSink appends newline-delimited JSON records to a single file.
+type FileSink struct {
+	path string
+	file *os.File
+	cp   checkpoint
+}
+
+var _ Sink = (*FileSink)(nil)
+
+func NewFileSink(path string) (*FileSink, error) {
+	cp, err := readCheckpoint(path + checkpointSuffix)
+	if err != nil {
+		return nil, err
+	}
+	file, err := openAt(path, cp.Offset)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{path: path, file: file, cp: cp}, nil
+}
+
+func (s *FileSink) Name() string {
+	return "file:" + s.path
+}
+
+func (s *FileSink) Checkpoint() (int64, error) {
+	return s.cp.Height, nil
+}
+
+func (s *FileSink) WriteBlock(height int64, records []Record) error {
+	bz, err := encodeRecords(records)
+	if err != nil {
+		return err
+	}
+	if len(bz) > 0 {
+		if err := appendSync(s.file, bz); err != nil {
+			return err
+		}
+	}
+	cp := checkpoint{Height: height, Offset: s.cp.Offset + int64(len(bz))}
+	if err := writeCheckpoint(s.path+checkpointSuffix, cp); err != nil {
+		return err
+	}
+	s.cp = cp
+	return nil
+}
+
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
+
+//__________________________________________________________________
+
+// SegmentSink writes newline-delimited JSON records to segment files in a directory, named after
+// the first height they contain. A new segment is started once the current one reaches maxBytes,
+// a block never spans two segments. Only the latest maxSegments segments are kept, 0 keeps all of them.
+type SegmentSink struct {
+	dir         string
+	maxBytes    int64
+	maxSegments int
+	file        *os.File
+	cp          checkpoint
+}
+
+var _ Sink = (*SegmentSink)(nil)
+
+func NewSegmentSink(dir string, maxBytes int64, maxSegments int) (*SegmentSink, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	s := &SegmentSink{dir: dir, maxBytes: maxBytes, maxSegments: maxSegments}
+	cp, err := readCheckpoint(s.checkpointPath())
+	if err != nil {
+		return nil, err
+	}
+	s.cp = cp
+
+	// segments created after the checkpoint only hold partially written blocks
+	segments, err := s.segments()
+	if err != nil {
+		return nil, err
+	}
+	for _, segment := range segments {
+		if segment > cp.Segment {
+			if err := os.Remove(filepath.Join(dir, segment)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if cp.Segment != "" {
+		if s.file, err = openAt(filepath.Join(dir, cp.Segment), cp.Offset); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *SegmentSink) Name() string {
+	return "segment:" + s.dir
+}
+
+func (s *SegmentSink) Checkpoint() (int64, error) {
+	return s.cp.Height, nil
+}
+
+func (s *SegmentSink) WriteBlock(height int64, records []Record) error {
+	cp := s.cp
+	cp.Height = height
+	if len(records) > 0 {
+		if s.file == nil || (s.maxBytes > 0 && cp.Offset >= s.maxBytes) {
+			if err := s.rotate(height); err != nil {
+				return err
+			}
+			cp.Segment, cp.Offset = segmentName(height), 0
+		}
+		bz, err := encodeRecords(records)
+		if err != nil {
+			return err
+		}
+		if err := appendSync(s.file, bz); err != nil {
+			return err
+		}
+		cp.Offset += int64(len(bz))
+	}
+	if err := writeCheckpoint(s.checkpointPath(), cp); err != nil {
+		return err
+	}
+	s.cp = cp
+	return s.prune()
+}
+
+func (s *SegmentSink) rotate(height int64) error {
+	if s.file != nil {
+		if err := s.file.Close(); err != nil {
+			return err
+		}
+	}
+	file, err := os.OpenFile(filepath.Join(s.dir, segmentName(height)), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	s.file = file
+	return nil
+}
+
+// prune removes the oldest segments beyond maxSegments.
+func (s *SegmentSink) prune() error {
+	if s.maxSegments <= 0 {
+		return nil
+	}
+	segments, err := s.segments()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(segments)-s.maxSegments; i++ {
+		if err := os.Remove(filepath.Join(s.dir, segments[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// segments returns the names of the segment files in order.
+func (s *SegmentSink) segments() ([]string, error) {
+	files, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	var segments []string
+	for _, file := range files {
+		if strings.HasSuffix(file.Name(), segmentSuffix) {
+			segments = append(segments, file.Name())
+		}
+	}
+	sort.Strings(segments)
+	return segments, nil
+}
+
+func (s *SegmentSink) checkpointPath() string {
+	return filepath.Join(s.dir, "segments"+checkpointSuffix)
+}
+
+func (s *SegmentSink) Close() error {
+	if s.file == nil {
+		return nil
+	}
+	return s.file.Close()
+}
+
+func segmentName(height int64) string {
+	return fmt.Sprintf("%020d%s", height, segmentSuffix)
+}
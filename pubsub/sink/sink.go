@@ -0,0 +1,215 @@
+// Package sink writes the events published by every committed block to external storage.
+//
+// Sinks are fed from the journal of the pubsub server rather than from memory: once a block is
+// committed, the events of every height between the checkpoint of a sink and the committed height
+// are read from the journal and written as one batch. A sink that fails or falls behind never
+// blocks the node, and a restarted node resumes every sink from its own checkpoint, which gives an
+// at-least-once delivery of committed events. The journal keeps the events a sink has not written
+// yet, a sink resuming from a checkpoint below the pruned heights of the journal reports the gap.
+package sink
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/pubsub"
+)
+
+const retryInterval = time.Second
+
+// Record is a journaled event as written by sinks.
+type Record struct {
+	Height int64           `json:"height"`
+	Index  int64           `json:"index"`
+	Topic  pubsub.Topic    `json:"topic"`
+	Type   string          `json:"type"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// Sink is a destination of committed events, e.g. a file or a Kafka producer.
+type Sink interface {
+	Name() string
+	// Checkpoint returns the last height written, 0 if nothing is written yet.
+	Checkpoint() (int64, error)
+	// WriteBlock writes the events of the block at height, which can be empty, as a whole.
+	// Checkpoint has to return height once it succeeds.
+	WriteBlock(height int64, records []Record) error
+	Close() error
+}
+
+// Manager feeds sinks with the events of committed blocks.
+type Manager struct {
+	workers []*worker
+}
+
+// NewManager attaches sinks to server, which must have a journal. Sinks are fed in their own
+// goroutines, call Stop to close them.
+func NewManager(server *pubsub.Server, logger log.Logger, sinks ...Sink) (*Manager, error) {
+	if server.Journal() == nil {
+		return nil, pubsub.ErrNoJournal
+	}
+	m := &Manager{}
+	for _, sink := range sinks {
+		checkpoint, err := sink.Checkpoint()
+		if err != nil {
+			return nil, err
+		}
+		w := &worker{
+			sink:       sink,
+			journal:    server.Journal(),
+			logger:     logger.With("sink", sink.Name()),
+			checkpoint: checkpoint,
+			notify:     make(chan struct{}, 1),
+			quit:       make(chan struct{}),
+			done:       make(chan struct{}),
+		}
+		w.journal.Hold(w.holdName(), checkpoint+1)
+		m.workers = append(m.workers, w)
+		go w.loop()
+	}
+	server.AddCommitHook(m.commit)
+	return m, nil
+}
+
+func (m *Manager) commit(height int64) {
+	for _, w := range m.workers {
+		w.commit(height)
+	}
+}
+
+// Checkpoints returns the last height written by every sink.
+func (m *Manager) Checkpoints() map[string]int64 {
+	checkpoints := make(map[string]int64, len(m.workers))
+	for _, w := range m.workers {
+		w.mtx.Lock()
+		checkpoints[w.sink.Name()] = w.checkpoint
+		w.mtx.Unlock()
+	}
+	return checkpoints
+}
+
+// Stop waits for the pending write of every sink and closes them.
+func (m *Manager) Stop() {
+	for _, w := range m.workers {
+		close(w.quit)
+		<-w.done
+		w.journal.Release(w.holdName())
+		if err := w.sink.Close(); err != nil {
+			w.logger.Error("failed to close sink", "err", err)
+		}
+	}
+}
+
+type worker struct {
+	sink    Sink
+	journal *pubsub.Journal
+	logger  log.Logger
+
+	mtx        sync.Mutex
+	checkpoint int64
+	committed  int64
+
+	notify chan struct{}
+	quit   chan struct{}
+	done   chan struct{}
+}
+
+func (w *worker) holdName() string {
+	return "sink/" + w.sink.Name()
+}
+
+func (w *worker) commit(height int64) {
+	w.mtx.Lock()
+	if height > w.committed {
+		w.committed = height
+	}
+	w.mtx.Unlock()
+
+	select {
+	case w.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (w *worker) loop() {
+	defer close(w.done)
+	for {
+		select {
+		case <-w.notify:
+		case <-w.quit:
+			return
+		}
+		for !w.flush() {
+			select {
+			case <-time.After(retryInterval):
+			case <-w.quit:
+				return
+			}
+		}
+	}
+}
+
+// flush writes every committed block after the checkpoint, it returns false if the sink fails.
+// Blocks without any event are skipped, except the committed one so that the checkpoint catches up.
+func (w *worker) flush() bool {
+	w.mtx.Lock()
+	height, committed := w.checkpoint+1, w.committed
+	w.mtx.Unlock()
+
+	for height <= committed {
+		if pruned := w.journal.Pruned(); height < pruned {
+			w.logger.Error("events are pruned from the journal before being written", "from", height, "to", pruned-1)
+			height = pruned
+			continue
+		}
+		blockHeight, records, err := w.readBlock(height, committed)
+		if err != nil {
+			w.logger.Error("failed to read journal", "height", height, "err", err)
+			return false
+		}
+		if err := w.sink.WriteBlock(blockHeight, records); err != nil {
+			w.logger.Error("failed to write block", "height", blockHeight, "err", err)
+			return false
+		}
+		w.mtx.Lock()
+		w.checkpoint = blockHeight
+		w.mtx.Unlock()
+		height = blockHeight + 1
+		w.journal.Hold(w.holdName(), height)
+
+		select {
+		case <-w.quit:
+			return true
+		default:
+		}
+	}
+	return true
+}
+
+// readBlock reads the events of the first block in [from, to] which has any, or returns to without any record.
+func (w *worker) readBlock(from, to int64) (int64, []Record, error) {
+	height := to
+	var records []Record
+	err := w.journal.Iterate(pubsub.Position{Height: from}, func(entry pubsub.Entry) bool {
+		if len(records) == 0 {
+			if entry.Pos.Height > to {
+				return false
+			}
+			height = entry.Pos.Height
+		} else if entry.Pos.Height != height {
+			return false
+		}
+		records = append(records, Record{
+			Height: entry.Pos.Height,
+			Index:  entry.Pos.Index,
+			Topic:  entry.Topic,
+			Type:   entry.Type,
+			Data:   entry.Data,
+		})
+		return true
+	})
+	return height, records, err
+}
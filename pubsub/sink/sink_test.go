@@ -0,0 +1,226 @@
+package sink
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/pubsub"
+)
+
+func readRecords(t *testing.T, path string) []Record {
+	file, err := os.Open(path)
+	require.Nil(t, err)
+	defer file.Close()
+	var records []Record
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record Record
+		require.Nil(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func publishBlock(server *pubsub.Server, height int64, fees ...int64) {
+	server.SetHeight(height)
+	for _, fee := range fees {
+		server.Publish(pubsub.CrossTransferEvent{ChainId: "bsc", RelayerFee: fee})
+	}
+}
+
+func waitCheckpoint(t *testing.T, m *Manager, name string, height int64) {
+	require.Eventually(t, func() bool {
+		return m.Checkpoints()[name] == height
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestFileSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sink")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "events.ndjson")
+
+	db := dbm.NewMemDB()
+	server := pubsub.NewServer(nil, pubsub.WithJournal(pubsub.NewJournal(db, 0)))
+	require.Nil(t, server.Start())
+	fileSink, err := NewFileSink(path)
+	require.Nil(t, err)
+	m, err := NewManager(server, log.NewNopLogger(), fileSink)
+	require.Nil(t, err)
+
+	publishBlock(server, 1, 1, 2)
+	server.Commit(1)
+	publishBlock(server, 2)
+	server.Commit(2)
+	// the events of a block are only written once it is committed
+	publishBlock(server, 3, 3)
+	waitCheckpoint(t, m, fileSink.Name(), 2)
+
+	records := readRecords(t, path)
+	require.Equal(t, 2, len(records))
+	require.Equal(t, int64(1), records[1].Height)
+	require.Equal(t, int64(1), records[1].Index)
+	require.Equal(t, pubsub.CrossTransferTopic, records[1].Topic)
+	require.Equal(t, pubsub.EventName(pubsub.CrossTransferEvent{}), records[1].Type)
+	var event pubsub.CrossTransferEvent
+	require.Nil(t, json.Unmarshal(records[1].Data, &event))
+	require.Equal(t, int64(2), event.RelayerFee)
+
+	m.Stop()
+	require.Nil(t, server.Stop())
+
+	// a block partially written before a crash is dropped when the sink is opened again
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	require.Nil(t, err)
+	_, err = file.WriteString(`{"height":3,"in`)
+	require.Nil(t, err)
+	file.Close()
+
+	server = pubsub.NewServer(nil, pubsub.WithJournal(pubsub.NewJournal(db, 0)))
+	require.Nil(t, server.Start())
+	defer server.Stop()
+	fileSink, err = NewFileSink(path)
+	require.Nil(t, err)
+	m, err = NewManager(server, log.NewNopLogger(), fileSink)
+	require.Nil(t, err)
+	defer m.Stop()
+
+	// the block 3 was committed before the restart, it is written from the journal
+	publishBlock(server, 4, 4)
+	server.Commit(4)
+	waitCheckpoint(t, m, fileSink.Name(), 4)
+	records = readRecords(t, path)
+	require.Equal(t, 4, len(records))
+	require.Equal(t, int64(3), records[2].Height)
+	require.Equal(t, int64(4), records[3].Height)
+}
+
+func TestSegmentSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sink")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	server := pubsub.NewServer(nil, pubsub.WithJournal(pubsub.NewJournal(dbm.NewMemDB(), 0)))
+	require.Nil(t, server.Start())
+	defer server.Stop()
+	segmentSink, err := NewSegmentSink(dir, 1, 2)
+	require.Nil(t, err)
+	m, err := NewManager(server, log.NewNopLogger(), segmentSink)
+	require.Nil(t, err)
+	defer m.Stop()
+
+	for h := int64(1); h <= 4; h++ {
+		publishBlock(server, h, h, h)
+		server.Commit(h)
+	}
+	waitCheckpoint(t, m, segmentSink.Name(), 4)
+
+	segments, err := segmentSink.segments()
+	require.Nil(t, err)
+	require.Equal(t, []string{segmentName(3), segmentName(4)}, segments)
+	records := readRecords(t, filepath.Join(dir, segmentName(4)))
+	require.Equal(t, 2, len(records))
+	require.Equal(t, int64(4), records[0].Height)
+
+	// segments started after the checkpoint are removed when the sink is opened again
+	require.Nil(t, ioutil.WriteFile(filepath.Join(dir, segmentName(5)), []byte("{"), 0644))
+	reopened, err := NewSegmentSink(dir, 1, 2)
+	require.Nil(t, err)
+	defer reopened.Close()
+	segments, err = reopened.segments()
+	require.Nil(t, err)
+	require.Equal(t, []string{segmentName(3), segmentName(4)}, segments)
+	height, err := reopened.Checkpoint()
+	require.Nil(t, err)
+	require.Equal(t, int64(4), height)
+}
+
+func TestManagerRequiresJournal(t *testing.T) {
+	_, err := NewManager(pubsub.NewServer(nil), log.NewNopLogger())
+	require.Equal(t, pubsub.ErrNoJournal, err)
+}
+
+type failingSink struct {
+	mtx        sync.Mutex
+	fail       bool
+	checkpoint int64
+	records    []Record
+}
+
+func (s *failingSink) Name() string { return "failing" }
+
+func (s *failingSink) Checkpoint() (int64, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.checkpoint, nil
+}
+
+func (s *failingSink) WriteBlock(height int64, records []Record) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.fail {
+		return errors.New("sink is down")
+	}
+	s.checkpoint = height
+	s.records = append(s.records, records...)
+	return nil
+}
+
+func (s *failingSink) Close() error { return nil }
+
+func TestManagerHoldsJournal(t *testing.T) {
+	journal := pubsub.NewJournal(dbm.NewMemDB(), 1)
+	server := pubsub.NewServer(nil, pubsub.WithJournal(journal))
+	require.Nil(t, server.Start())
+	defer server.Stop()
+	sink := &failingSink{fail: true}
+	m, err := NewManager(server, log.NewNopLogger(), sink)
+	require.Nil(t, err)
+	defer m.Stop()
+
+	for h := int64(1); h <= 4; h++ {
+		publishBlock(server, h, h)
+		server.Commit(h)
+	}
+	publishBlock(server, 5, 5)
+
+	// the events the sink has not written are not pruned
+	require.Eventually(t, func() bool {
+		return journal.Next() == pubsub.Position{Height: 5, Index: 1}
+	}, 5*time.Second, 10*time.Millisecond)
+	var heights []int64
+	require.Nil(t, journal.Iterate(pubsub.Position{}, func(entry pubsub.Entry) bool {
+		heights = append(heights, entry.Pos.Height)
+		return true
+	}))
+	require.Equal(t, []int64{1, 2, 3, 4, 5}, heights)
+
+	sink.mtx.Lock()
+	sink.fail = false
+	sink.mtx.Unlock()
+	waitCheckpoint(t, m, sink.Name(), 4)
+	sink.mtx.Lock()
+	require.Equal(t, 4, len(sink.records))
+	sink.mtx.Unlock()
+
+	server.Commit(5)
+	waitCheckpoint(t, m, sink.Name(), 5)
+	sink.mtx.Lock()
+	require.Equal(t, 5, len(sink.records))
+	sink.mtx.Unlock()
+	publishBlock(server, 6)
+	require.Eventually(t, func() bool {
+		return journal.Pruned() == 5
+	}, 5*time.Second, 10*time.Millisecond)
+}
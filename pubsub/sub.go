@@ -3,23 +3,116 @@ package pubsub
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"github.com/tendermint/tendermint/libs/log"
 )
 
 type ClientID string
 
+// Policy decides what the server does when the buffer of a subscriber is full.
+type Policy int
+
+const (
+	// PolicyBlock waits for the subscriber, which also blocks the publisher.
+	PolicyBlock Policy = iota
+	// PolicyDrop discards the event for this subscriber.
+	PolicyDrop
+	// PolicySpill leaves the event in the journal, the subscriber reads it from
+	// disk and switches back to the buffer once it has caught up.
+	PolicySpill
+)
+
+const defaultBufferSize = 100
+
+type envelope struct {
+	pos   Position
+	event Event
+	attrs map[string][]string // computed once by the server if any filter needs it
+}
+
+type subscription struct {
+	pattern Topic
+	filter  *Filter
+	handler PositionHandler
+	cursor  Position // next position to deliver
+}
+
+// SubscribeOption configures a single subscription
+type SubscribeOption func(*cmd)
+
+// FromHeight replays the journaled events from height before the new ones.
+func FromHeight(height int64) SubscribeOption {
+	return func(c *cmd) {
+		c.replay = true
+		c.from = Position{Height: height}
+	}
+}
+
+// WithFilter only delivers the events matching filter.
+func WithFilter(filter *Filter) SubscribeOption {
+	return func(c *cmd) {
+		c.filter = filter
+	}
+}
+
+// SubscriberOption configures a Subscriber
+type SubscriberOption func(*Subscriber)
+
+func WithPolicy(policy Policy) SubscriberOption {
+	return func(s *Subscriber) {
+		s.policy = policy
+	}
+}
+
+func WithBufferSize(size int) SubscriberOption {
+	return func(s *Subscriber) {
+		s.bufferSize = size
+	}
+}
+
+// Durable persists the cursors of the subscriber in the journal under its client ID,
+// so that its subscriptions resume where they stopped after a restart.
+func Durable() SubscriberOption {
+	return func(s *Subscriber) {
+		s.durable = true
+	}
+}
+
+// ManualAck only persists the cursors of a durable subscriber when its events are acknowledged by Ack,
+// instead of once they are handled, so that unacknowledged events are delivered again after a restart.
+func ManualAck() SubscriberOption {
+	return func(s *Subscriber) {
+		s.manualAck = true
+	}
+}
+
 type Subscriber struct {
 	clientID ClientID
 	server   *Server
-	handlers map[Topic]Handler
-	out      chan Event
+	out      chan envelope
+	wake     chan struct{}
 	quit     chan struct{}
+	stopOnce sync.Once
 	wg       sync.WaitGroup
 	Logger   log.Logger
+
+	policy     Policy
+	bufferSize int
+	durable    bool
+	manualAck  bool
+	dropped    int64
+
+	mtx sync.Mutex
+	// in the order they were made, an event matching several of them is handled by each in this order
+	subscriptions []*subscription
+	// the subscriber reads from the journal instead of out while lagging
+	lagging    bool
+	replayFrom Position
+	rewound    bool
 }
 
-func (server *Server) NewSubscriber(clientID ClientID, logger log.Logger) (*Subscriber, error) {
+func (server *Server) NewSubscriber(clientID ClientID, logger log.Logger, options ...SubscriberOption) (*Subscriber, error) {
 	server.mtx.Lock()
 	defer server.mtx.Unlock()
 	_, ok := server.subscribers[clientID]
@@ -27,48 +120,320 @@ func (server *Server) NewSubscriber(clientID ClientID, logger log.Logger) (*Subs
 		return nil, ErrDuplicateClientID
 	}
 	sub := &Subscriber{
-		clientID: clientID,
-		server:   server,
-		handlers: make(map[Topic]Handler),
-		out:      make(chan Event, 100),
-		quit:     make(chan struct{}),
-		Logger:   logger,
+		clientID:   clientID,
+		server:     server,
+		wake:       make(chan struct{}, 1),
+		quit:       make(chan struct{}),
+		Logger:     logger,
+		bufferSize: defaultBufferSize,
+	}
+	for _, option := range options {
+		option(sub)
+	}
+	if (sub.durable || sub.policy == PolicySpill) && server.journal == nil {
+		return nil, ErrNoJournal
+	}
+	if sub.manualAck && !sub.durable {
+		return nil, ErrNotDurable
 	}
+	sub.out = make(chan envelope, sub.bufferSize)
 	server.subscribers[clientID] = make(map[Topic]bool)
 
-	go func() {
-		for {
-			select {
-			case event := <-sub.out:
-				sub.eventHandle(event)
-				sub.wg.Done()
-			case <-sub.quit:
-				if sub.Logger != nil {
-					sub.Logger.Info(fmt.Sprintf("Subscriber[%s] removed", sub.clientID))
-				}
-				return
+	go sub.loop()
+	return sub, nil
+}
+
+func (s *Subscriber) loop() {
+	for {
+		select {
+		case env := <-s.out:
+			s.deliver(env)
+			continue
+		case <-s.quit:
+			s.stopped()
+			return
+		default:
+		}
+
+		// only read from the journal once the buffered events are handled
+		if s.isLagging() {
+			s.catchUp()
+			continue
+		}
+
+		select {
+		case env := <-s.out:
+			s.deliver(env)
+		case <-s.wake:
+		case <-s.quit:
+			s.stopped()
+			return
+		}
+	}
+}
+
+func (s *Subscriber) stopped() {
+	if s.Logger != nil {
+		s.Logger.Info(fmt.Sprintf("Subscriber[%s] removed", s.clientID))
+	}
+}
+
+func (s *Subscriber) deliver(env envelope) {
+	defer s.wg.Done()
+	s.dispatch(env.pos, env.event.GetTopic(), func() (Event, map[string][]string, error) {
+		return env.event, env.attrs, nil
+	})
+}
+
+// dispatch hands the event at pos over to every subscription matching it which has not seen pos yet.
+// The event is only loaded when at least one subscription is interested in its topic.
+func (s *Subscriber) dispatch(pos Position, topic Topic, load func() (Event, map[string][]string, error)) {
+	subs := s.accept(pos, topic)
+	if len(subs) == 0 {
+		return
+	}
+	event, attrs, err := load()
+	if err != nil {
+		if s.Logger != nil {
+			s.Logger.Error("failed to load event", "position", pos, "err", err)
+		}
+		return
+	}
+	for _, sub := range subs {
+		if sub.filter != nil {
+			if attrs == nil {
+				attrs = EventAttributes(event)
+			}
+			if !sub.filter.Matches(attrs) {
+				continue
 			}
 		}
-	}()
-	return sub, nil
+		s.eventHandle(sub.handler, pos, event)
+		s.commit(pos, sub.pattern)
+	}
+}
+
+// accept returns the subscriptions matching topic which have not seen pos yet, and moves their cursors past it.
+func (s *Subscriber) accept(pos Position, topic Topic) []*subscription {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	var subs []*subscription
+	for _, sub := range s.subscriptions {
+		if !sub.pattern.Match(topic) || pos.Less(sub.cursor) {
+			continue
+		}
+		sub.cursor = pos.Next()
+		subs = append(subs, sub)
+	}
+	return subs
+}
+
+// wants checks if any subscription is interested in the event, called in the server loop.
+func (s *Subscriber) wants(env *envelope) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	topic := env.event.GetTopic()
+	for _, sub := range s.subscriptions {
+		if !sub.pattern.Match(topic) {
+			continue
+		}
+		if sub.filter == nil {
+			return true
+		}
+		if env.attrs == nil {
+			env.attrs = EventAttributes(env.event)
+		}
+		if sub.filter.Matches(env.attrs) {
+			return true
+		}
+	}
+	return false
 }
 
-func (s *Subscriber) eventHandle(event Event) {
+func (s *Subscriber) commit(pos Position, pattern Topic) {
+	if s.durable && !s.manualAck {
+		s.server.journal.SetCursor(s.clientID, pattern, pos.Next())
+	}
+}
+
+// Ack acknowledges every event of the subscription topic up to pos, only for durable subscribers.
+func (s *Subscriber) Ack(topic Topic, pos Position) error {
+	if !s.durable {
+		return ErrNotDurable
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for _, sub := range s.subscriptions {
+		if sub.pattern != topic {
+			continue
+		}
+		if cursor, ok := s.server.journal.Cursor(s.clientID, topic); !ok || cursor.Less(pos.Next()) {
+			s.server.journal.SetCursor(s.clientID, topic, pos.Next())
+		}
+		return nil
+	}
+	return ErrSubscriptionNotFound
+}
+
+func (s *Subscriber) eventHandle(handler PositionHandler, pos Position, event Event) {
 	defer func() {
 		if err := recover(); err != nil && s.Logger != nil {
 			s.Logger.Error("event handle err: ", err)
 		}
 	}()
-	handler, ok := s.handlers[event.GetTopic()]
-	if ok {
-		handler(event)
+	handler(pos, event)
+}
+
+func (s *Subscriber) isLagging() bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.lagging
+}
+
+// catchUp delivers journaled events from replayFrom, and leaves the lagging mode
+// once there is nothing left in the journal.
+func (s *Subscriber) catchUp() {
+	journal := s.server.journal
+	s.mtx.Lock()
+	from := s.replayFrom
+	s.rewound = false
+	s.mtx.Unlock()
+
+	err := journal.Iterate(from, func(entry Entry) bool {
+		select {
+		case <-s.quit:
+			return false
+		default:
+		}
+		s.dispatch(entry.Pos, entry.Topic, func() (Event, map[string][]string, error) {
+			event, err := entry.Decode()
+			return event, nil, err
+		})
+
+		s.mtx.Lock()
+		defer s.mtx.Unlock()
+		if s.rewound {
+			return false
+		}
+		s.replayFrom = entry.Pos.Next()
+		return true
+	})
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if err != nil {
+		if s.Logger != nil {
+			s.Logger.Error("failed to read journal, skip to its head", "from", s.replayFrom, "err", err)
+		}
+		s.replayFrom = journal.Next()
+	}
+	if s.rewound {
+		return
+	}
+	if !s.replayFrom.Less(journal.Next()) {
+		s.lagging = false
+		s.wg.Done()
+	}
+}
+
+// replay makes the subscriber read the journal from position from, called in the server loop.
+func (s *Subscriber) replay(from Position) {
+	s.mtx.Lock()
+	if !s.lagging {
+		s.lagging = true
+		s.replayFrom = from
+		s.wg.Add(1)
+	} else if from.Less(s.replayFrom) {
+		s.replayFrom = from
+		s.rewound = true
+	}
+	s.mtx.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// attach installs a subscription starting at its cursor, called in the server loop.
+func (s *Subscriber) attach(sub *subscription, replay bool) {
+	s.mtx.Lock()
+	s.subscriptions = append(s.subscriptions, sub)
+	s.mtx.Unlock()
+	if replay {
+		s.replay(sub.cursor)
+	}
+}
+
+// detach removes the subscription of pattern, called in the server loop.
+func (s *Subscriber) detach(pattern Topic) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	for i, sub := range s.subscriptions {
+		if sub.pattern == pattern {
+			s.subscriptions = append(s.subscriptions[:i], s.subscriptions[i+1:]...)
+			return
+		}
 	}
 }
 
-func (s *Subscriber) Subscribe(topic Topic, handler Handler) error {
+// push hands an event over to the subscriber according to its policy, called in the server loop.
+func (s *Subscriber) push(env envelope) {
+	if s.isLagging() {
+		// the event is read from the journal later
+		return
+	}
+
+	s.wg.Add(1)
+	if s.policy == PolicyBlock {
+		s.out <- env
+		return
+	}
+	select {
+	case s.out <- env:
+	default:
+		s.wg.Done()
+		if s.policy == PolicySpill {
+			s.replay(env.pos)
+		} else {
+			atomic.AddInt64(&s.dropped, 1)
+			if s.Logger != nil {
+				s.Logger.Error("subscriber is full, drop event", "client", s.clientID, "position", env.pos)
+			}
+		}
+	}
+}
+
+// Dropped returns the number of events discarded because of PolicyDrop.
+func (s *Subscriber) Dropped() int64 {
+	return atomic.LoadInt64(&s.dropped)
+}
+
+// Subscribe registers handler for the events whose topic matches topic, which can be a pattern like "stake/*".
+func (s *Subscriber) Subscribe(topic Topic, handler Handler, options ...SubscribeOption) error {
 	if handler == nil {
 		return ErrNilHandler
 	}
+	return s.SubscribeWithPosition(topic, func(_ Position, event Event) {
+		handler(event)
+	}, options...)
+}
+
+// SubscribeWithPosition is like Subscribe, but handler also receives the position of the event.
+func (s *Subscriber) SubscribeWithPosition(topic Topic, handler PositionHandler, options ...SubscribeOption) error {
+	c := cmd{op: sub, topic: topic, subscriber: s, clientID: s.clientID, handler: handler}
+	for _, option := range options {
+		option(&c)
+	}
+	if handler == nil {
+		return ErrNilHandler
+	}
+	if err := topic.validate(); err != nil {
+		return err
+	}
+	if c.replay && s.server.journal == nil {
+		return ErrNoJournal
+	}
 	s.server.mtx.RLock()
 	subscribers, ok := s.server.subscribers[s.clientID]
 	if ok {
@@ -79,10 +444,8 @@ func (s *Subscriber) Subscribe(topic Topic, handler Handler) error {
 		return ErrAlreadySubscribed
 	}
 
-	s.handlers[topic] = handler
-
 	select {
-	case s.server.cmds <- cmd{op: sub, topic: topic, subscriber: s, clientID: s.clientID}:
+	case s.server.cmds <- c:
 		s.server.mtx.Lock()
 		if _, ok := s.server.subscribers[s.clientID]; !ok {
 			s.server.subscribers[s.clientID] = make(map[Topic]bool)
@@ -95,6 +458,11 @@ func (s *Subscriber) Subscribe(topic Topic, handler Handler) error {
 	}
 }
 
+// SubscribeFrom subscribes topic and replays the journaled events of it from height before the new ones.
+func (s *Subscriber) SubscribeFrom(topic Topic, handler Handler, height int64) error {
+	return s.Subscribe(topic, handler, FromHeight(height))
+}
+
 func (s *Subscriber) Unsubscribe(topic Topic) error {
 	s.server.mtx.RLock()
 	subscribers, ok := s.server.subscribers[s.clientID]
@@ -109,7 +477,7 @@ func (s *Subscriber) Unsubscribe(topic Topic) error {
 	case s.server.cmds <- cmd{op: unsub, clientID: s.clientID, topic: topic}:
 		s.server.mtx.Lock()
 		delete(s.server.subscribers[s.clientID], topic)
-		close(s.quit)
+		s.stop()
 		s.server.mtx.Unlock()
 		return nil
 	case <-s.server.Quit():
@@ -128,13 +496,20 @@ func (s *Subscriber) UnsubscribeAll() error {
 	case s.server.cmds <- cmd{op: unsub, clientID: s.clientID}:
 		s.server.mtx.Lock()
 		delete(s.server.subscribers, s.clientID)
-		s.server.mtx.RUnlock()
+		s.stop()
+		s.server.mtx.Unlock()
 		return nil
 	case <-s.server.Quit():
 		return nil
 	}
 }
 
+func (s *Subscriber) stop() {
+	s.stopOnce.Do(func() {
+		close(s.quit)
+	})
+}
+
 func (s *Subscriber) Wait() {
 	s.server.wg.Wait()
 	s.wg.Wait()
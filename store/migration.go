@@ -0,0 +1,194 @@
+package store
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MigratableStore is a CommitMultiStore keeping the versions of the data of its stores. The versions are not
+// saved, they are rebuilt from the registered migrations whose upgrades were committed, so a node restored from
+// a snapshot of the state knows them as well as a node which ran the migrations.
+type MigratableStore interface {
+	sdk.StoreMigrator
+
+	// Register a migration of a mounted store, it panics if the migration conflicts with another one.
+	RegisterMigration(migration sdk.StoreMigration)
+
+	// The version of the data of a store, including the migrations not committed yet.
+	GetStoreVersion(name string) int64
+
+	// Run the migrations of an upgrade against a copy of the latest committed state.
+	DryRunMigrations(upgrade string, header abci.Header, logger log.Logger) (CommitID, error)
+}
+
+var _ MigratableStore = (*rootMultiStore)(nil)
+
+// Implements MigratableStore.
+func (rs *rootMultiStore) RegisterMigration(migration sdk.StoreMigration) {
+	if _, ok := rs.keysByName[migration.StoreKey]; !ok {
+		panic(fmt.Sprintf("migration %s of unknown store", migration))
+	}
+	if migration.From < 0 || migration.To <= migration.From {
+		panic(fmt.Sprintf("migration %s should increase the store version", migration))
+	}
+	if migration.Upgrade == "" || migration.Migrate == nil {
+		panic(fmt.Sprintf("migration %s should have an upgrade and a migrate function", migration))
+	}
+	for _, m := range rs.migrations {
+		if m.StoreKey == migration.StoreKey && m.From == migration.From {
+			panic(fmt.Sprintf("migration %s conflicts with %s", migration, m))
+		}
+	}
+	rs.migrations = append(rs.migrations, migration)
+}
+
+// Implements MigratableStore.
+func (rs *rootMultiStore) GetStoreVersion(name string) int64 {
+	if version, ok := rs.pendingVersions[name]; ok {
+		return version
+	}
+	return rs.committedStoreVersion(name)
+}
+
+// committedStoreVersion follows the migrations of a store from version 0 through the upgrades at or below the
+// last committed height, as they ran in the blocks of their upgrades.
+func (rs *rootMultiStore) committedStoreVersion(name string) int64 {
+	var migrations []sdk.StoreMigration
+	for _, m := range rs.migrations {
+		if m.StoreKey == name {
+			migrations = append(migrations, m)
+		}
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].From < migrations[j].From
+	})
+
+	var version int64
+	for _, m := range migrations {
+		upgradeHeight := sdk.UpgradeMgr.GetUpgradeHeight(m.Upgrade)
+		if m.From != version || upgradeHeight == 0 || upgradeHeight > rs.lastCommitID.Version {
+			continue
+		}
+		version = m.To
+	}
+	return version
+}
+
+// Implements sdk.StoreMigrator. The migrations of the upgrades at the current height run in the
+// order of store names and versions, the new versions are saved by the next Commit.
+func (rs *rootMultiStore) RunMigrations(ctx sdk.Context) error {
+	var migrations []sdk.StoreMigration
+	for _, m := range rs.migrations {
+		if sdk.IsUpgradeHeight(m.Upgrade) {
+			migrations = append(migrations, m)
+		}
+	}
+	return rs.runMigrations(ctx, migrations)
+}
+
+// Implements MigratableStore. The copy is committed so that the resulting app hash can be checked,
+// nothing of rs is changed. The whole state is copied into memory, so it's meant for tests and
+// rehearsals of upgrades rather than for running nodes.
+func (rs *rootMultiStore) DryRunMigrations(upgrade string, header abci.Header, logger log.Logger) (CommitID, error) {
+	copied := rs.copy()
+	if err := copied.LoadVersion(rs.lastCommitID.Version); err != nil {
+		return CommitID{}, err
+	}
+
+	var migrations []sdk.StoreMigration
+	for _, m := range copied.migrations {
+		if m.Upgrade == upgrade {
+			migrations = append(migrations, m)
+		}
+	}
+	cache := copied.CacheMultiStore()
+	ctx := sdk.NewContext(cache, header, sdk.RunTxModeDeliver, logger)
+	if err := copied.runMigrations(ctx, migrations); err != nil {
+		return CommitID{}, err
+	}
+	cache.Write()
+	return copied.Commit(), nil
+}
+
+func (rs *rootMultiStore) runMigrations(ctx sdk.Context, migrations []sdk.StoreMigration) error {
+	sort.SliceStable(migrations, func(i, j int) bool {
+		if migrations[i].StoreKey != migrations[j].StoreKey {
+			return migrations[i].StoreKey < migrations[j].StoreKey
+		}
+		return migrations[i].From < migrations[j].From
+	})
+
+	versions := make(map[string]int64)
+	for _, m := range migrations {
+		version, ok := versions[m.StoreKey]
+		if !ok {
+			version = rs.GetStoreVersion(m.StoreKey)
+		}
+		if version != m.From {
+			return fmt.Errorf("can not run migration %s, store is at version %d", m, version)
+		}
+		if err := m.Migrate(ctx); err != nil {
+			return fmt.Errorf("migration %s failed: %v", m, err)
+		}
+		versions[m.StoreKey] = m.To
+		ctx.Logger().Info("migrated store", "migration", m.String())
+	}
+	for name, version := range versions {
+		rs.pendingVersions[name] = version
+	}
+	return nil
+}
+
+// copy returns a store with the same stores and migrations as rs, backed by in-memory copies of its DBs.
+func (rs *rootMultiStore) copy() *rootMultiStore {
+	copied := NewCommitMultiStore(copyDB(rs.db))
+	copied.pruning = rs.pruning
+	dbs := map[dbm.DB]dbm.DB{rs.db: copied.db}
+	for key, params := range rs.storesParams {
+		db := params.db
+		if db != nil {
+			if _, ok := dbs[db]; !ok {
+				dbs[db] = copyDB(db)
+			}
+			db = dbs[db]
+		}
+		copied.MountStoreWithDB(key, params.typ, db)
+	}
+	copied.migrations = append(copied.migrations, rs.migrations...)
+	return copied
+}
+
+func copyDB(db dbm.DB) dbm.DB {
+	copied := dbm.NewMemDB()
+	iterator := db.Iterator(nil, nil)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		copied.Set(iterator.Key(), iterator.Value())
+	}
+	return copied
+}
+
+// VerifyMigrations dry-runs the migrations of upgrade runs times and checks they always result in the
+// same app hash, which catches migrations depending on map iteration order or anything else random.
+func VerifyMigrations(store MigratableStore, upgrade string, header abci.Header, runs int) (CommitID, error) {
+	var expected CommitID
+	for i := 0; i < runs; i++ {
+		commitID, err := store.DryRunMigrations(upgrade, header, log.NewNopLogger())
+		if err != nil {
+			return CommitID{}, err
+		}
+		if i > 0 && (commitID.Version != expected.Version || !bytes.Equal(commitID.Hash, expected.Hash)) {
+			return CommitID{}, fmt.Errorf("migrations of %s are not deterministic, app hash %X != %X",
+				upgrade, commitID.Hash, expected.Hash)
+		}
+		expected = commitID
+	}
+	return expected, nil
+}
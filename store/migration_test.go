@@ -0,0 +1,152 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const migrationUpgrade = "migrationTest"
+
+// renameKeys moves every key of store under prefix
+func renameKeys(key sdk.StoreKey, prefix string) func(ctx sdk.Context) error {
+	return func(ctx sdk.Context) error {
+		store := ctx.KVStore(key)
+		iterator := store.Iterator(nil, nil)
+		var keys, values [][]byte
+		for ; iterator.Valid(); iterator.Next() {
+			keys = append(keys, iterator.Key())
+			values = append(values, iterator.Value())
+		}
+		iterator.Close()
+		for i := range keys {
+			store.Delete(keys[i])
+			store.Set(append([]byte(prefix), keys[i]...), values[i])
+		}
+		return nil
+	}
+}
+
+func newMigrationTestStore(t *testing.T) (*rootMultiStore, sdk.StoreKey) {
+	sdk.UpgradeMgr = sdk.NewUpgradeManager(sdk.UpgradeConfig{})
+	sdk.UpgradeMgr.AddUpgradeHeight(migrationUpgrade, 3)
+
+	store := newMultiStoreWithMounts(dbm.NewMemDB())
+	require.Nil(t, store.LoadLatestVersion())
+	key := store.keysByName["store1"]
+	for i := 0; i < 10; i++ {
+		store.GetKVStore(key).Set([]byte(fmt.Sprintf("key%d", i)), []byte{byte(i)})
+	}
+	sdk.UpgradeMgr.SetHeight(1)
+	store.Commit()
+	sdk.UpgradeMgr.SetStoreMigrator(store)
+	return store, key
+}
+
+func TestRegisterMigration(t *testing.T) {
+	store, key := newMigrationTestStore(t)
+	migrate := renameKeys(key, "a/")
+
+	require.Panics(t, func() {
+		store.RegisterMigration(sdk.StoreMigration{StoreKey: "store77", From: 0, To: 1, Upgrade: migrationUpgrade, Migrate: migrate})
+	})
+	require.Panics(t, func() {
+		store.RegisterMigration(sdk.StoreMigration{StoreKey: "store1", From: 1, To: 1, Upgrade: migrationUpgrade, Migrate: migrate})
+	})
+	require.Panics(t, func() {
+		store.RegisterMigration(sdk.StoreMigration{StoreKey: "store1", From: 0, To: 1, Upgrade: migrationUpgrade})
+	})
+	store.RegisterMigration(sdk.StoreMigration{StoreKey: "store1", From: 0, To: 1, Upgrade: migrationUpgrade, Migrate: migrate})
+	require.Panics(t, func() {
+		store.RegisterMigration(sdk.StoreMigration{StoreKey: "store1", From: 0, To: 2, Upgrade: migrationUpgrade, Migrate: migrate})
+	})
+}
+
+func TestRunMigrations(t *testing.T) {
+	store, key := newMigrationTestStore(t)
+	// registered out of order on purpose
+	store.RegisterMigration(sdk.StoreMigration{StoreKey: "store1", From: 1, To: 3, Upgrade: migrationUpgrade, Migrate: renameKeys(key, "b/")})
+	store.RegisterMigration(sdk.StoreMigration{StoreKey: "store1", From: 0, To: 1, Upgrade: migrationUpgrade, Migrate: renameKeys(key, "a/")})
+
+	for height := int64(2); height <= 4; height++ {
+		sdk.UpgradeMgr.SetHeight(height)
+		cache := store.CacheMultiStore()
+		ctx := sdk.NewContext(cache, abci.Header{Height: height}, sdk.RunTxModeDeliver, log.NewNopLogger())
+		sdk.UpgradeMgr.BeginBlocker(ctx)
+		cache.Write()
+		if height == 3 {
+			require.Equal(t, int64(3), store.GetStoreVersion("store1"))
+			require.Equal(t, int64(0), store.committedStoreVersion("store1"))
+		}
+		store.Commit()
+	}
+
+	require.Equal(t, int64(3), store.GetStoreVersion("store1"))
+	require.Equal(t, int64(0), store.GetStoreVersion("store2"))
+	require.Nil(t, store.GetKVStore(key).Get([]byte("key1")))
+	require.Equal(t, []byte{1}, store.GetKVStore(key).Get([]byte("b/a/key1")))
+
+	// versions are rebuilt from the committed upgrades after a restart or a restore from a snapshot
+	reloaded := newMultiStoreWithMounts(store.db)
+	require.Nil(t, reloaded.LoadLatestVersion())
+	reloaded.migrations = store.migrations
+	require.Equal(t, int64(3), reloaded.GetStoreVersion("store1"))
+	require.Nil(t, reloaded.LoadVersion(2))
+	require.Equal(t, int64(0), reloaded.GetStoreVersion("store1"))
+}
+
+func TestRunMigrationsFailure(t *testing.T) {
+	store, key := newMigrationTestStore(t)
+	store.RegisterMigration(sdk.StoreMigration{StoreKey: "store1", From: 1, To: 2, Upgrade: migrationUpgrade, Migrate: renameKeys(key, "a/")})
+	store.RegisterMigration(sdk.StoreMigration{StoreKey: "store2", From: 0, To: 1, Upgrade: migrationUpgrade, Migrate: func(ctx sdk.Context) error {
+		return errors.New("failure")
+	}})
+
+	sdk.UpgradeMgr.SetHeight(3)
+	ctx := sdk.NewContext(store.CacheMultiStore(), abci.Header{Height: 3}, sdk.RunTxModeDeliver, log.NewNopLogger())
+	require.Panics(t, func() { sdk.UpgradeMgr.BeginBlocker(ctx) })
+	require.NotNil(t, store.RunMigrations(ctx))
+	require.Equal(t, int64(0), store.GetStoreVersion("store1"))
+	require.Equal(t, int64(0), store.GetStoreVersion("store2"))
+}
+
+func TestDryRunMigrations(t *testing.T) {
+	store, key := newMigrationTestStore(t)
+	store.RegisterMigration(sdk.StoreMigration{StoreKey: "store1", From: 0, To: 1, Upgrade: migrationUpgrade, Migrate: renameKeys(key, "a/")})
+	lastCommitID := store.LastCommitID()
+
+	header := abci.Header{Height: 3}
+	dryRun, err := VerifyMigrations(store, migrationUpgrade, header, 3)
+	require.Nil(t, err)
+
+	// nothing is changed by dry runs
+	require.Equal(t, lastCommitID, store.LastCommitID())
+	require.Equal(t, int64(0), store.GetStoreVersion("store1"))
+	require.Equal(t, []byte{1}, store.GetKVStore(key).Get([]byte("key1")))
+
+	// and they end up with the app hash of the real upgrade
+	sdk.UpgradeMgr.SetHeight(3)
+	cache := store.CacheMultiStore()
+	require.Nil(t, store.RunMigrations(sdk.NewContext(cache, header, sdk.RunTxModeDeliver, log.NewNopLogger())))
+	cache.Write()
+	require.Equal(t, dryRun, store.Commit())
+}
+
+func TestVerifyMigrationsNonDeterministic(t *testing.T) {
+	store, key := newMigrationTestStore(t)
+	store.RegisterMigration(sdk.StoreMigration{StoreKey: "store1", From: 0, To: 1, Upgrade: migrationUpgrade, Migrate: func(ctx sdk.Context) error {
+		ctx.KVStore(key).Set([]byte("random"), []byte(fmt.Sprint(rand.Int63())))
+		return nil
+	}})
+
+	_, err := VerifyMigrations(store, migrationUpgrade, abci.Header{Height: 3}, 3)
+	require.NotNil(t, err)
+}
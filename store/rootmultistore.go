@@ -31,6 +31,9 @@ type rootMultiStore struct {
 	stores       map[StoreKey]CommitStore
 	keysByName   map[string]StoreKey
 
+	migrations      []sdk.StoreMigration
+	pendingVersions map[string]int64
+
 	traceWriter  io.Writer
 	traceContext TraceContext
 }
@@ -45,6 +48,8 @@ func NewCommitMultiStore(db dbm.DB) *rootMultiStore {
 		storesParams: make(map[StoreKey]storeParams),
 		stores:       make(map[StoreKey]CommitStore),
 		keysByName:   make(map[string]StoreKey),
+
+		pendingVersions: make(map[string]int64),
 	}
 }
 
@@ -156,6 +161,7 @@ func (rs *rootMultiStore) LoadVersion(ver int64) error {
 	// Success.
 	rs.lastCommitID = cInfo.CommitID()
 	rs.stores = newStores
+	rs.pendingVersions = make(map[string]int64)
 	return nil
 }
 
@@ -216,6 +222,7 @@ func (rs *rootMultiStore) Commit() CommitID {
 	setCommitInfo(batch, version, commitInfo)
 	setLatestVersion(batch, version)
 	batch.Write()
+	rs.pendingVersions = make(map[string]int64)
 
 	// Prepare for next version.
 	commitID := CommitID{
@@ -85,6 +85,13 @@ type CrossChainApplication interface {
 	ExecuteFailAckPackage(ctx Context, payload []byte) ExecuteResult
 }
 
+// CrossChainPackageRecorder is implemented by the cross chain applications which keep a record of the packages they
+// execute. The oracle calls RecordPackage after a package is executed, with the context whose state is kept even if
+// the package fails or crashes and its own state changes are discarded.
+type CrossChainPackageRecorder interface {
+	RecordPackage(ctx Context, packageType CrossChainPackageType, sequence uint64, payload []byte, crash bool, result ExecuteResult)
+}
+
 type ExecuteResult struct {
 	Err     Error
 	Tags    Tags
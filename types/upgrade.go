@@ -1,7 +1,9 @@
 package types
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 )
 
 var UpgradeMgr = NewUpgradeManager(UpgradeConfig{})
@@ -23,8 +25,22 @@ const (
 	BEP171                      = "BEP171" //https://github.com/bnb-chain/BEPs/pull/171
 	BEP173                      = "BEP173" // https://github.com/bnb-chain/BEPs/pull/173
 	FixDoubleSignChainId        = "FixDoubleSignChainId"
-	BEP126                      = "BEP126" //https://github.com/binance-chain/BEPs/pull/126
-	BEP255                      = "BEP255" // https://github.com/bnb-chain/BEPs/pull/255
+	BEP126                      = "BEP126"                  //https://github.com/binance-chain/BEPs/pull/126
+	BEP255                      = "BEP255"                  // https://github.com/bnb-chain/BEPs/pull/255
+	GovUpgradePlan              = "GovUpgradePlan"          // upgrade plans scheduled by software upgrade proposals
+	ProphecyExpiry              = "ProphecyExpiry"          // expiry and garbage collection of oracle prophecies
+	ProphecyClaimStore          = "ProphecyClaimStore"      // ordered storage of the claims of oracle prophecies
+	IBCPackagePruning           = "IBCPackagePruning"       // pruning of the ibc packages acknowledged by destination chains
+	IBCRelayerFeeSchedule       = "IBCRelayerFeeSchedule"   // per channel relayer fees and fee payers of ibc packages
+	LightClientVerification     = "LightClientVerification" // verification of the oracle packages of selected channels by light clients
+	BscDoubleSignEvidences      = "BscDoubleSignEvidences"  // batched bsc double sign evidences verified against the tracked validator sets
+	BscVoteEvidence             = "BscVoteEvidence"         // malicious fast finality vote evidences submitted to beacon chain
+	SlashRecordIndex            = "SlashRecordIndex"        // index slash records by slash height, infraction type and slash time
+	ProgressiveSlashing         = "ProgressiveSlashing"     // slashes escalated for repeat offenders and tombstoning after repeated double signs
+	RewardRestake               = "RewardRestake"           // rewards of opted in delegators delegated back to their validators
+	CommissionChangeNotice      = "CommissionChangeNotice"  // commission raises of validators applied after a notice period of breathe blocks
+	LiquidStaking               = "LiquidStaking"           // side chain delegations represented by transferable receipt tokens
+	CrossStakeRecord            = "CrossStakeRecord"        // records of the cross stake requests and refunds with bounded retries of failed refunds
 )
 
 var MainNetConfig = UpgradeConfig{
@@ -32,15 +48,22 @@ var MainNetConfig = UpgradeConfig{
 }
 
 type UpgradeConfig struct {
-	HeightMap     map[string]int64
-	StoreKeyMap   map[string]int64
-	MsgTypeMap    map[string]int64
-	BeginBlockers map[int64][]func(ctx Context)
+	HeightMap          map[string]int64
+	StoreKeyMap        map[string]int64
+	DeletedStoreKeyMap map[string]int64
+	MsgTypeMap         map[string]int64
+	BeginBlockers      map[int64][]func(ctx Context)
+	Plans              map[string]UpgradePlan
 }
 
+// UpgradeHandler applies the state migration of an upgrade plan.
+type UpgradeHandler func(ctx Context)
+
 type UpgradeManager struct {
-	Config UpgradeConfig
-	Height int64
+	Config   UpgradeConfig
+	Height   int64
+	Handlers map[string]UpgradeHandler
+	Migrator StoreMigrator
 }
 
 func NewUpgradeManager(config UpgradeConfig) *UpgradeManager {
@@ -69,6 +92,31 @@ func (mgr *UpgradeManager) GetHeight() int64 {
 
 // run in every ABCI BeginBlock.
 func (mgr *UpgradeManager) BeginBlocker(ctx Context) {
+	// halt before anything is changed if the binary can't execute a plan of this height
+	var plans []UpgradePlan
+	for _, plan := range mgr.Config.Plans {
+		if plan.Height == mgr.GetHeight() {
+			if _, ok := mgr.Handlers[plan.Name]; !ok {
+				err := ErrUpgradeNeeded{Plan: plan}
+				ctx.Logger().Error(err.Error())
+				panic(err)
+			}
+			plans = append(plans, plan)
+		}
+	}
+	sort.Slice(plans, func(i, j int) bool { return plans[i].Name < plans[j].Name })
+
+	// stores are migrated before anything else of the upgrades is applied
+	if mgr.Migrator != nil {
+		if err := mgr.Migrator.RunMigrations(ctx); err != nil {
+			panic(fmt.Errorf("failed to migrate stores at height %d: %v", mgr.GetHeight(), err))
+		}
+	}
+	for _, plan := range plans {
+		ctx.Logger().Info("apply upgrade plan", "name", plan.Name, "height", plan.Height)
+		mgr.Handlers[plan.Name](ctx)
+	}
+
 	if beginBlockers, ok := mgr.Config.BeginBlockers[mgr.GetHeight()]; ok {
 		for _, beginBlocker := range beginBlockers {
 			beginBlocker(ctx)
@@ -76,6 +124,11 @@ func (mgr *UpgradeManager) BeginBlocker(ctx Context) {
 	}
 }
 
+// SetStoreMigrator sets the migrator running the store migrations of the upgrades in BeginBlocker.
+func (mgr *UpgradeManager) SetStoreMigrator(migrator StoreMigrator) {
+	mgr.Migrator = migrator
+}
+
 func (mgr *UpgradeManager) RegisterBeginBlocker(name string, beginBlocker func(Context)) {
 	height := mgr.GetUpgradeHeight(name)
 	if height == 0 {
@@ -139,6 +192,64 @@ func (mgr *UpgradeManager) RegisterMsgTypes(upgradeName string, msgTypes ...stri
 	}
 }
 
+// RegisterUpgradeHandler registers the handler of the upgrade plan name. Plans are scheduled by
+// governance, so a binary registers the handlers of every plan it is able to execute.
+func (mgr *UpgradeManager) RegisterUpgradeHandler(name string, handler UpgradeHandler) {
+	if mgr.Handlers == nil {
+		mgr.Handlers = make(map[string]UpgradeHandler)
+	}
+	mgr.Handlers[name] = handler
+}
+
+func (mgr *UpgradeManager) HasUpgradeHandler(name string) bool {
+	_, ok := mgr.Handlers[name]
+	return ok
+}
+
+// AddPlan schedules plan, its store keys and msg types take effect at the plan height like the ones
+// of hard-coded upgrades. A name can only be scheduled once.
+func (mgr *UpgradeManager) AddPlan(plan UpgradePlan) error {
+	if err := plan.ValidateBasic(); err != nil {
+		return err
+	}
+	if height := mgr.GetUpgradeHeight(plan.Name); height != 0 {
+		return fmt.Errorf("upgrade %s is already scheduled at height %d", plan.Name, height)
+	}
+	for _, storeKeyName := range plan.DeletedStoreKeys {
+		if height := mgr.GetDeletedStoreKeyHeight(storeKeyName); height != 0 {
+			return fmt.Errorf("store %s is already deleted at height %d", storeKeyName, height)
+		}
+	}
+
+	mgr.AddUpgradeHeight(plan.Name, plan.Height)
+	mgr.RegisterStoreKeys(plan.Name, plan.AddedStoreKeys...)
+	mgr.RegisterMsgTypes(plan.Name, plan.MsgTypes...)
+	if mgr.Config.DeletedStoreKeyMap == nil {
+		mgr.Config.DeletedStoreKeyMap = map[string]int64{}
+	}
+	for _, storeKeyName := range plan.DeletedStoreKeys {
+		mgr.Config.DeletedStoreKeyMap[storeKeyName] = plan.Height
+	}
+	if mgr.Config.Plans == nil {
+		mgr.Config.Plans = map[string]UpgradePlan{}
+	}
+	mgr.Config.Plans[plan.Name] = plan
+	return nil
+}
+
+func (mgr *UpgradeManager) GetPlan(name string) (UpgradePlan, bool) {
+	plan, ok := mgr.Config.Plans[name]
+	return plan, ok
+}
+
+func (mgr *UpgradeManager) GetDeletedStoreKeyHeight(storeKeyName string) int64 {
+	if mgr.Config.DeletedStoreKeyMap == nil {
+		return 0
+	}
+
+	return mgr.Config.DeletedStoreKeyMap[storeKeyName]
+}
+
 func (mgr *UpgradeManager) GetStoreKeyHeight(storeKeyName string) int64 {
 	if mgr.Config.StoreKeyMap == nil {
 		return 0
@@ -183,6 +294,10 @@ func IsUpgradeWithHeight(name string, height int64) bool {
 }
 
 func ShouldCommitStore(storeKeyName string) bool {
+	if deletedHeight := UpgradeMgr.GetDeletedStoreKeyHeight(storeKeyName); deletedHeight != 0 && UpgradeMgr.GetHeight() >= deletedHeight {
+		return false
+	}
+
 	storeKeyHeight := UpgradeMgr.GetStoreKeyHeight(storeKeyName)
 	if storeKeyHeight == 0 {
 		return true
@@ -229,3 +344,75 @@ func Upgrade(name string, before func(), in func(), after func()) {
 		}
 	}
 }
+
+// UpgradePlan is an upgrade scheduled by a software upgrade proposal rather than compiled into the binary.
+// The stores of AddedStoreKeys have to be mounted by the binary, they are committed from Height on,
+// the stores of DeletedStoreKeys are not committed any more from Height on.
+type UpgradePlan struct {
+	Name             string   `json:"name"`
+	Height           int64    `json:"height"`
+	Info             string   `json:"info,omitempty"`
+	AddedStoreKeys   []string `json:"added_store_keys,omitempty"`
+	DeletedStoreKeys []string `json:"deleted_store_keys,omitempty"`
+	MsgTypes         []string `json:"msg_types,omitempty"`
+}
+
+func (plan UpgradePlan) ValidateBasic() error {
+	if len(plan.Name) == 0 {
+		return errors.New("upgrade name should not be empty")
+	}
+	if plan.Height <= 0 {
+		return fmt.Errorf("upgrade height should be positive, got %d", plan.Height)
+	}
+	storeKeys := make(map[string]bool)
+	for _, storeKeyName := range append(append([]string{}, plan.AddedStoreKeys...), plan.DeletedStoreKeys...) {
+		if len(storeKeyName) == 0 {
+			return errors.New("store key name should not be empty")
+		}
+		if storeKeys[storeKeyName] {
+			return fmt.Errorf("duplicated store key %s", storeKeyName)
+		}
+		storeKeys[storeKeyName] = true
+	}
+	for _, msgType := range plan.MsgTypes {
+		if len(msgType) == 0 {
+			return errors.New("msg type should not be empty")
+		}
+	}
+	return nil
+}
+
+func (plan UpgradePlan) String() string {
+	return fmt.Sprintf("UpgradePlan{Name: %s, Height: %d, AddedStoreKeys: %v, DeletedStoreKeys: %v, MsgTypes: %v}",
+		plan.Name, plan.Height, plan.AddedStoreKeys, plan.DeletedStoreKeys, plan.MsgTypes)
+}
+
+// ErrUpgradeNeeded is raised in BeginBlock at the height of a plan the running binary has no handler for,
+// the block is not executed, so the node can resume from it with a binary which has.
+type ErrUpgradeNeeded struct {
+	Plan UpgradePlan
+}
+
+func (err ErrUpgradeNeeded) Error() string {
+	return fmt.Sprintf("UPGRADE %q NEEDED at height %d: %s", err.Plan.Name, err.Plan.Height, err.Plan.Info)
+}
+
+// StoreMigration migrates the data of a store from version From to version To, it runs in the
+// BeginBlocker at the height of Upgrade. Versions of a store start from 0 and every migration of
+// a store has to start from the version the previous one ends with.
+type StoreMigration struct {
+	StoreKey string
+	From     int64
+	To       int64
+	Upgrade  string
+	Migrate  func(ctx Context) error
+}
+
+func (m StoreMigration) String() string {
+	return fmt.Sprintf("%s %d -> %d (%s)", m.StoreKey, m.From, m.To, m.Upgrade)
+}
+
+// StoreMigrator runs the store migrations of the upgrades at the height of ctx.
+type StoreMigrator interface {
+	RunMigrations(ctx Context) error
+}
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
 )
 
 const UpgradeTest = "upgradeTest"
@@ -174,3 +176,50 @@ func TestMsgType(t *testing.T) {
 		require.Equal(t, tc.isSupported, IsMsgTypeSupported(MsgTypeTest))
 	}
 }
+
+func TestUpgradePlan(t *testing.T) {
+	UpgradeMgr = NewUpgradeManager(UpgradeConfig{})
+	ctx := NewContext(nil, abci.Header{}, RunTxModeDeliver, log.NewNopLogger())
+
+	plan := UpgradePlan{
+		Name:             UpgradeTest,
+		Height:           545000,
+		AddedStoreKeys:   []string{StoreKeyNameTest},
+		DeletedStoreKeys: []string{"deletedStoreKeyTest"},
+		MsgTypes:         []string{MsgTypeTest},
+	}
+	require.NotNil(t, UpgradeMgr.AddPlan(UpgradePlan{Name: UpgradeTest}))
+	require.NotNil(t, UpgradeMgr.AddPlan(UpgradePlan{Name: UpgradeTest, Height: 1, AddedStoreKeys: []string{"a"}, DeletedStoreKeys: []string{"a"}}))
+	require.Nil(t, UpgradeMgr.AddPlan(plan))
+	require.NotNil(t, UpgradeMgr.AddPlan(plan))
+
+	applied := 0
+	UpgradeMgr.RegisterUpgradeHandler(UpgradeTest, func(ctx Context) {
+		applied++
+	})
+	for _, height := range []int64{544999, 545000, 545001} {
+		UpgradeMgr.SetHeight(height)
+		UpgradeMgr.BeginBlocker(ctx)
+		require.Equal(t, height >= 545000, IsUpgrade(UpgradeTest))
+		require.Equal(t, height >= 545000, ShouldCommitStore(StoreKeyNameTest))
+		require.Equal(t, height < 545000, ShouldCommitStore("deletedStoreKeyTest"))
+		require.Equal(t, height >= 545000, IsMsgTypeSupported(MsgTypeTest))
+	}
+	require.Equal(t, 1, applied)
+}
+
+func TestUpgradePlanWithoutHandler(t *testing.T) {
+	UpgradeMgr = NewUpgradeManager(UpgradeConfig{})
+	ctx := NewContext(nil, abci.Header{}, RunTxModeDeliver, log.NewNopLogger())
+
+	plan := UpgradePlan{Name: UpgradeTest, Height: 545000}
+	require.Nil(t, UpgradeMgr.AddPlan(plan))
+
+	UpgradeMgr.SetHeight(544999)
+	UpgradeMgr.BeginBlocker(ctx)
+
+	UpgradeMgr.SetHeight(545000)
+	require.PanicsWithError(t, ErrUpgradeNeeded{Plan: plan}.Error(), func() {
+		UpgradeMgr.BeginBlocker(ctx)
+	})
+}
@@ -123,6 +123,7 @@ const (
 	ProposalTypeNil             ProposalKind = 0x00
 	ProposalTypeText            ProposalKind = 0x01
 	ProposalTypeParameterChange ProposalKind = 0x02
+	// ProposalTypeSoftwareUpgrade carries an upgrade plan in its description since GovUpgradePlan, see x/upgrade.
 	ProposalTypeSoftwareUpgrade ProposalKind = 0x03
 	ProposalTypeListTradingPair ProposalKind = 0x04
 	// ProposalTypeFeeChange belongs to ProposalTypeParameterChange. We use this to make it easily to distinguish。
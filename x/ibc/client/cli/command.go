@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/tendermint/go-amino"
+
+	"github.com/cosmos/cosmos-sdk/client"
+)
+
+const (
+	flagSideChainId  = "side-chain-id"
+	flagChannelId    = "channel-id"
+	flagFromSequence = "from-sequence"
+	flagToSequence   = "to-sequence"
+	flagLimit        = "limit"
+	flagSequence     = "sequence"
+)
+
+func AddCommands(cmd *cobra.Command, storeName string, cdc *amino.Codec) {
+	ibcCmd := &cobra.Command{
+		Use:   "ibc",
+		Short: "ibc commands",
+	}
+	ibcCmd.AddCommand(
+		client.GetCommands(
+			ShowPackagesCmd(cdc),
+			ShowPackageCmd(storeName, cdc),
+			ShowSequencesCmd(cdc),
+			ShowRelayerFeesCmd(cdc),
+			ShowPackageFeeCmd(cdc))...)
+	cmd.AddCommand(ibcCmd)
+}
@@ -0,0 +1,206 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/go-amino"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+	ibcclient "github.com/cosmos/cosmos-sdk/x/ibc/client"
+)
+
+func ShowPackagesCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show-packages",
+		Short: "Show the packages of a channel of side chain in a sequence range",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			sideChainId := viper.GetString(flagSideChainId)
+			if sideChainId == "" {
+				return fmt.Errorf("missing side-chain-id")
+			}
+
+			queryData, err := cdc.MarshalJSON(ibc.QueryPackagesParams{
+				SideChainId:  sideChainId,
+				ChannelId:    sdk.ChannelID(viper.GetUint(flagChannelId)),
+				FromSequence: viper.GetUint64(flagFromSequence),
+				ToSequence:   viper.GetUint64(flagToSequence),
+				Limit:        viper.GetInt(flagLimit),
+			})
+			if err != nil {
+				return err
+			}
+
+			bz, err := cliCtx.Query(fmt.Sprintf("custom/ibc/%s", ibc.QueryPackages), queryData)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bz))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagSideChainId, "", "the id of side chain")
+	cmd.Flags().Uint(flagChannelId, 0, "the id of channel")
+	cmd.Flags().Uint64(flagFromSequence, 0, "the first sequence to show")
+	cmd.Flags().Uint64(flagToSequence, 0, "the last sequence to show, 0 for no upper bound")
+	cmd.Flags().Int(flagLimit, 0, "the max number of packages to show, 0 for the max of the node")
+	return cmd
+}
+
+func ShowPackageCmd(storeName string, cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show-package",
+		Short: "Show a package of side chain with the proof of its key, verified unless the node is trusted",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			sideChainId := viper.GetString(flagSideChainId)
+			if sideChainId == "" {
+				return fmt.Errorf("missing side-chain-id")
+			}
+			sequences, err := querySequences(cliCtx, cdc, sideChainId)
+			if err != nil {
+				return err
+			}
+
+			proof, err := ibcclient.QueryPackageProof(cliCtx, storeName, sequences.SrcChainId, sequences.DestChainId,
+				sdk.ChannelID(viper.GetUint(flagChannelId)), viper.GetUint64(flagSequence))
+			if err != nil {
+				return err
+			}
+			if !cliCtx.TrustNode {
+				// the AppHash for height H is in header H+1
+				commit, err := cliCtx.Verify(proof.Height + 1)
+				if err != nil {
+					return err
+				}
+				if err := ibcclient.VerifyPackageProof(proof, commit.Header.AppHash); err != nil {
+					return err
+				}
+			}
+
+			bz, err := codec.MarshalJSONIndent(cdc, proof)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bz))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagSideChainId, "", "the id of side chain")
+	cmd.Flags().Uint(flagChannelId, 0, "the id of channel")
+	cmd.Flags().Uint64(flagSequence, 0, "the sequence of package")
+	return cmd
+}
+
+func ShowSequencesCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show-sequences",
+		Short: "Show the send sequences of the channels of side chain",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			sideChainId := viper.GetString(flagSideChainId)
+			if sideChainId == "" {
+				return fmt.Errorf("missing side-chain-id")
+			}
+
+			sequences, err := querySequences(cliCtx, cdc, sideChainId)
+			if err != nil {
+				return err
+			}
+			bz, err := codec.MarshalJSONIndent(cdc, sequences)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bz))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagSideChainId, "", "the id of side chain")
+	return cmd
+}
+
+func ShowRelayerFeesCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show-relayer-fees",
+		Short: "Show the relayer fee schedule of side chain and the relayer fees owed for each channel",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			sideChainId := viper.GetString(flagSideChainId)
+			if sideChainId == "" {
+				return fmt.Errorf("missing side-chain-id")
+			}
+
+			queryData, err := cdc.MarshalJSON(sideChainId)
+			if err != nil {
+				return err
+			}
+			bz, err := cliCtx.Query(fmt.Sprintf("custom/ibc/%s", ibc.QueryRelayerFees), queryData)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bz))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagSideChainId, "", "the id of side chain")
+	return cmd
+}
+
+func ShowPackageFeeCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show-package-fee",
+		Short: "Show the relayer fee of a package of side chain and the account which paid it",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			sideChainId := viper.GetString(flagSideChainId)
+			if sideChainId == "" {
+				return fmt.Errorf("missing side-chain-id")
+			}
+
+			queryData, err := cdc.MarshalJSON(ibc.QueryPackageFeeParams{
+				SideChainId: sideChainId,
+				ChannelId:   sdk.ChannelID(viper.GetUint(flagChannelId)),
+				Sequence:    viper.GetUint64(flagSequence),
+			})
+			if err != nil {
+				return err
+			}
+			bz, err := cliCtx.Query(fmt.Sprintf("custom/ibc/%s", ibc.QueryPackageFee), queryData)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bz))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagSideChainId, "", "the id of side chain")
+	cmd.Flags().Uint(flagChannelId, 0, "the id of channel")
+	cmd.Flags().Uint64(flagSequence, 0, "the sequence of package")
+	return cmd
+}
+
+func querySequences(cliCtx context.CLIContext, cdc *amino.Codec, sideChainId string) (ibc.SequencesInfo, error) {
+	queryData, err := cdc.MarshalJSON(sideChainId)
+	if err != nil {
+		return ibc.SequencesInfo{}, err
+	}
+	bz, err := cliCtx.Query(fmt.Sprintf("custom/ibc/%s", ibc.QuerySequences), queryData)
+	if err != nil {
+		return ibc.SequencesInfo{}, err
+	}
+	var sequences ibc.SequencesInfo
+	if err := cdc.UnmarshalJSON(bz, &sequences); err != nil {
+		return ibc.SequencesInfo{}, err
+	}
+	return sequences, nil
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/tendermint/tendermint/crypto/merkle"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+)
+
+// PackageProof is a package of the ibc store at Height with the proof of its key, Value is nil if the
+// package does not exist. The proof is an ics23 CommitmentOp of the ibc store chained by the store op of the
+// multistore, its root is the app hash of the header at Height+1.
+type PackageProof struct {
+	StoreName string        `json:"store_name"`
+	Height    int64         `json:"height"`
+	Key       []byte        `json:"key"`
+	Value     []byte        `json:"value"`
+	Proof     *merkle.Proof `json:"proof"`
+}
+
+// QueryPackageProof queries a package with the proof of its key from the node of cliCtx.
+func QueryPackageProof(cliCtx context.CLIContext, storeName string, srcChainID, destChainID sdk.ChainID,
+	channelID sdk.ChannelID, sequence uint64) (PackageProof, error) {
+	node, err := cliCtx.GetNode()
+	if err != nil {
+		return PackageProof{}, err
+	}
+
+	key := ibc.GetIBCPackageKey(srcChainID, destChainID, channelID, sequence)
+	opts := rpcclient.ABCIQueryOptions{
+		Height: cliCtx.Height,
+		Prove:  true,
+	}
+	result, err := node.ABCIQueryWithOptions(fmt.Sprintf("/store/%s/ics23-key", storeName), key, opts)
+	if err != nil {
+		return PackageProof{}, err
+	}
+	resp := result.Response
+	if !resp.IsOK() {
+		return PackageProof{}, errors.Errorf(resp.Log)
+	}
+	if resp.Proof == nil {
+		return PackageProof{}, errors.New("node returned no proof")
+	}
+
+	return PackageProof{
+		StoreName: storeName,
+		Height:    resp.Height,
+		Key:       key,
+		Value:     resp.Value,
+		Proof:     resp.Proof,
+	}, nil
+}
+
+// VerifyPackageProof verifies the package of a proof, or its absence, against a trusted app hash,
+// the app hash of the header at proof.Height+1.
+func VerifyPackageProof(proof PackageProof, appHash []byte) error {
+	if proof.Proof == nil {
+		return errors.New("missing proof")
+	}
+
+	kp := merkle.KeyPath{}
+	kp = kp.AppendKey([]byte(proof.StoreName), merkle.KeyEncodingURL)
+	kp = kp.AppendKey(proof.Key, merkle.KeyEncodingURL)
+
+	prt := store.DefaultProofRuntime()
+	if proof.Value == nil {
+		if err := prt.VerifyAbsence(proof.Proof, appHash, kp.String()); err != nil {
+			return errors.Wrap(err, "failed to prove merkle absence proof")
+		}
+		return nil
+	}
+	if err := prt.VerifyValue(proof.Proof, appHash, kp.String(), proof.Value); err != nil {
+		return errors.Wrap(err, "failed to prove merkle existence proof")
+	}
+	return nil
+}
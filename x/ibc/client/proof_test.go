@@ -0,0 +1,83 @@
+package client
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/sidechain"
+)
+
+func TestVerifyPackageProof(t *testing.T) {
+	// the multistore commits to the hashes of the substores since BEP171
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.BEP171, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() { sdk.UpgradeMgr = sdk.NewUpgradeManager(sdk.UpgradeConfig{}) }()
+
+	keyIBC := sdk.NewKVStoreKey("ibc")
+	keySideChain := sdk.NewKVStoreKey("sc")
+	keyParams := sdk.NewKVStoreKey("params")
+	tkeyParams := sdk.NewTransientStoreKey("transient_params")
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyIBC, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(keySideChain, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, nil)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	scKeeper := sidechain.NewKeeper(keySideChain, pk.Subspace(sidechain.DefaultParamspace), cdc)
+	ibcKeeper := ibc.NewKeeper(keyIBC, pk.Subspace(ibc.DefaultParamspace), ibc.DefaultCodespace, scKeeper)
+
+	srcChainID, destChainID, channelID := sdk.ChainID(0x0001), sdk.ChainID(0x000f), sdk.ChannelID(0x01)
+	scKeeper.SetSrcChainID(srcChainID)
+	require.NoError(t, scKeeper.RegisterDestChain("bsc", destChainID))
+
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "foochainid"}, sdk.RunTxModeDeliver, log.NewNopLogger())
+	scKeeper.SetChannelSendPermission(ctx, destChainID, channelID, sdk.ChannelAllow)
+	_, err := ibcKeeper.CreateRawIBCPackageByIdWithFee(ctx, destChainID, channelID, sdk.SynCrossChainPackageType,
+		[]byte{0x01, 0x02}, *big.NewInt(100))
+	require.NoError(t, err)
+	commitID := ms.Commit()
+
+	// reload the multistore from the db before querying it
+	ms = store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyIBC, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(keySideChain, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, nil)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, nil)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	queryProof := func(sequence uint64) PackageProof {
+		key := ibc.GetIBCPackageKey(srcChainID, destChainID, channelID, sequence)
+		res := ms.Query(abci.RequestQuery{
+			Path: "/ibc/ics23-key", Data: key, Height: commitID.Version, Prove: true})
+		require.True(t, res.IsOK(), res.Log)
+		return PackageProof{StoreName: "ibc", Height: res.Height, Key: key, Value: res.Value, Proof: res.Proof}
+	}
+
+	proof := queryProof(0)
+	require.NotNil(t, proof.Value)
+	require.NoError(t, VerifyPackageProof(proof, commitID.Hash))
+	require.Error(t, VerifyPackageProof(proof, []byte("wrong app hash")))
+
+	proof.Value = append([]byte{}, proof.Value...)
+	proof.Value[len(proof.Value)-1] = 0x03
+	require.Error(t, VerifyPackageProof(proof, commitID.Hash))
+
+	// the absence of a package is proved too
+	proof = queryProof(1)
+	require.Nil(t, proof.Value)
+	require.NoError(t, VerifyPackageProof(proof, commitID.Hash))
+}
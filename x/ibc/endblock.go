@@ -5,6 +5,7 @@ import (
 )
 
 func EndBlocker(ctx sdk.Context, keeper Keeper) {
+	keeper.PrunePackages(ctx)
 	if len(keeper.packageCollector.collectedPackages) == 0 {
 		return
 	}
@@ -8,10 +8,11 @@ import (
 const (
 	DefaultCodespace sdk.CodespaceType = 3
 
-	CodeDuplicatedSequence    sdk.CodeType = 101
-	CodeFeeParamMismatch      sdk.CodeType = 102
-	CodeInvalidChainId        sdk.CodeType = 103
-	CodeWritePackageForbidden sdk.CodeType = 104
+	CodeDuplicatedSequence     sdk.CodeType = 101
+	CodeFeeParamMismatch       sdk.CodeType = 102
+	CodeInvalidChainId         sdk.CodeType = 103
+	CodeWritePackageForbidden  sdk.CodeType = 104
+	CodeInsufficientRelayerFee sdk.CodeType = 105
 )
 
 func ErrDuplicatedSequence(codespace sdk.CodespaceType, msg string) sdk.Error {
@@ -29,3 +30,7 @@ func ErrInvalidChainId(codespace sdk.CodespaceType, msg string) sdk.Error {
 func ErrWritePackageForbidden(codespace sdk.CodespaceType, msg string) sdk.Error {
 	return sdk.NewError(codespace, CodeWritePackageForbidden, msg)
 }
+
+func ErrInsufficientRelayerFee(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInsufficientRelayerFee, msg)
+}
@@ -0,0 +1,213 @@
+package ibc
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/bsc"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RelayerFeeLedger is the sum of the relayer fees of the syn packages sent through a channel since the
+// IBCRelayerFeeSchedule upgrade, they are owed to the relayers delivering the packages. PayerFees is the
+// part debited from the originating accounts, the rest is paid by the system pools of the destination chain.
+type RelayerFeeLedger struct {
+	ChannelId sdk.ChannelID `json:"channel_id"`
+	Packages  uint64        `json:"packages"`
+	Fees      int64         `json:"fees"`
+	PayerFees int64         `json:"payer_fees"`
+}
+
+// PackageFee is the relayer fee of a package and the account it was debited from, Payer is empty if the
+// fee is paid by the system pools.
+type PackageFee struct {
+	Payer sdk.AccAddress `json:"payer"`
+	Fee   int64          `json:"fee"`
+}
+
+// CreateIBCSyncPackageWithFeePayer creates a syn package with the relayer fee of its channel and payload size.
+// The fee is debited from payer, or paid by the system pools of the destination chain if payer is empty.
+// No package is created if payer can not cover the fee. The debit is not reverted if the package can not
+// be created, callers run in a cached context like message handlers.
+func (k *Keeper) CreateIBCSyncPackageWithFeePayer(ctx sdk.Context, destChainName string, channelName string,
+	payer sdk.AccAddress, packageLoad []byte) (uint64, sdk.Error) {
+	destChainID, err := k.sideKeeper.GetDestChainID(destChainName)
+	if err != nil {
+		return 0, ErrInvalidChainId(DefaultCodespace, err.Error())
+	}
+	channelID, err := k.sideKeeper.GetChannelID(channelName)
+	if err != nil {
+		return 0, sdk.ErrInternal(err.Error())
+	}
+	sequence, _, sdkErr := k.createPaidIBCPackage(ctx, destChainName, destChainID, channelID, payer, packageLoad)
+	return sequence, sdkErr
+}
+
+// CreatePaidIBCPackageById creates a syn package of an app whose originating account payer pays the relayer fee,
+// and returns the charged fee. Since the IBCRelayerFeeSchedule upgrade the fee of the channel and payload size is
+// debited from payer like CreateIBCSyncPackageWithFeePayer, before it the app already sent legacyFee to the peg
+// account with the tokens the package transfers.
+func (k *Keeper) CreatePaidIBCPackageById(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID,
+	payer sdk.AccAddress, packageLoad []byte, legacyFee int64) (uint64, int64, sdk.Error) {
+	if !sdk.IsUpgrade(sdk.IBCRelayerFeeSchedule) {
+		sequence, sdkErr := k.CreateRawIBCPackageByIdWithFee(ctx, destChainID, channelID, sdk.SynCrossChainPackageType,
+			packageLoad, *bsc.ConvertBCAmountToBSCAmount(legacyFee))
+		return sequence, legacyFee, sdkErr
+	}
+
+	if payer.Empty() {
+		return 0, 0, sdk.ErrInvalidAddress("the payer of a paid package is missing")
+	}
+	destChainName, err := k.sideKeeper.GetDestChainName(destChainID)
+	if err != nil {
+		return 0, 0, ErrInvalidChainId(DefaultCodespace, err.Error())
+	}
+	return k.createPaidIBCPackage(ctx, destChainName, destChainID, channelID, payer, packageLoad)
+}
+
+// GetRelayerFeeById returns the relayer fee of a syn package of a channel like GetRelayerFee, apps use it to quote
+// the fee before they build their package.
+func (k *Keeper) GetRelayerFeeById(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, payloadSize int) (int64, sdk.Error) {
+	destChainName, err := k.sideKeeper.GetDestChainName(destChainID)
+	if err != nil {
+		return 0, ErrInvalidChainId(DefaultCodespace, err.Error())
+	}
+	fee, err := k.GetRelayerFee(ctx, destChainName, channelID, payloadSize)
+	if err != nil {
+		return 0, ErrFeeParamMismatch(DefaultCodespace, fmt.Sprintf("fail to load relayerFee, %v", err))
+	}
+	return fee, nil
+}
+
+func (k *Keeper) createPaidIBCPackage(ctx sdk.Context, destChainName string, destChainID sdk.ChainID,
+	channelID sdk.ChannelID, payer sdk.AccAddress, packageLoad []byte) (uint64, int64, sdk.Error) {
+	fee, err := k.GetRelayerFee(ctx, destChainName, channelID, len(packageLoad))
+	if err != nil {
+		return 0, 0, ErrFeeParamMismatch(DefaultCodespace, fmt.Sprintf("fail to load relayerFee, %v", err))
+	}
+
+	if !payer.Empty() {
+		if k.bankKeeper == nil {
+			return 0, 0, sdk.ErrInternal("the keeper is not prepared to debit relayer fees")
+		}
+		if balance := k.bankKeeper.GetCoins(ctx, payer).AmountOf(sdk.NativeTokenSymbol); balance < fee {
+			return 0, 0, ErrInsufficientRelayerFee(DefaultCodespace,
+				fmt.Sprintf("%s can not pay the relayer fee %d with %d", payer, fee, balance))
+		}
+		// the relayer fee is paid on the destination chain from the tokens locked by the peg account
+		if _, sdkErr := k.bankKeeper.SendCoins(ctx, payer, sdk.PegAccount,
+			sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, fee)}); sdkErr != nil {
+			return 0, 0, sdkErr
+		}
+	}
+
+	sequence, sdkErr := k.CreateRawIBCPackageByIdWithFee(ctx, destChainID, channelID, sdk.SynCrossChainPackageType,
+		packageLoad, *bsc.ConvertBCAmountToBSCAmount(fee))
+	if sdkErr != nil {
+		return 0, 0, sdkErr
+	}
+	k.recordRelayerFee(ctx, destChainID, channelID, sequence, PackageFee{Payer: payer, Fee: fee})
+	return sequence, fee, nil
+}
+
+// GetRelayerFee returns the relayer fee of a syn package of a channel with a payload of payloadSize bytes.
+// Before the IBCRelayerFeeSchedule upgrade every package pays the relayer fee of the side chain.
+func (k *Keeper) GetRelayerFee(ctx sdk.Context, destChainName string, channelID sdk.ChannelID, payloadSize int) (int64, error) {
+	storePrefix := k.sideKeeper.GetSideChainStorePrefix(ctx, destChainName)
+	if storePrefix == nil {
+		return 0, fmt.Errorf("invalid sideChainId: %s", destChainName)
+	}
+	sideChainCtx := ctx.WithSideChainKeyPrefix(storePrefix)
+	var relayerFee int64
+	k.paramSpace.Get(sideChainCtx, ParamRelayerFee, &relayerFee)
+	if !sdk.IsUpgrade(sdk.IBCRelayerFeeSchedule) {
+		return relayerFee, nil
+	}
+
+	for _, channelFee := range k.getChannelRelayerFees(sideChainCtx) {
+		if channelFee.ChannelId == channelID {
+			return channelFee.Fee + channelFee.FeePerByte*int64(payloadSize), nil
+		}
+	}
+	return relayerFee, nil
+}
+
+// GetChannelRelayerFees returns the relayer fees of the channels of a side chain which do not use the
+// relayer fee of the side chain.
+func (k *Keeper) GetChannelRelayerFees(ctx sdk.Context, destChainName string) []ChannelRelayerFee {
+	storePrefix := k.sideKeeper.GetSideChainStorePrefix(ctx, destChainName)
+	if storePrefix == nil {
+		return nil
+	}
+	return k.getChannelRelayerFees(ctx.WithSideChainKeyPrefix(storePrefix))
+}
+
+func (k *Keeper) getChannelRelayerFees(sideChainCtx sdk.Context) (channelFees []ChannelRelayerFee) {
+	k.paramSpace.GetIfExists(sideChainCtx, ParamChannelRelayerFees, &channelFees)
+	return
+}
+
+// GetRelayerFeeLedger returns the relayer fees of the syn packages sent through a channel.
+func (k *Keeper) GetRelayerFeeLedger(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID) RelayerFeeLedger {
+	bz := ctx.KVStore(k.storeKey).Get(buildRelayerFeeKey(destChainID, channelID))
+	return decodeRelayerFeeLedger(channelID, bz)
+}
+
+// GetRelayerFeeLedgers returns the relayer fee ledgers of the channels of a destination chain ordered by channel.
+func (k *Keeper) GetRelayerFeeLedgers(ctx sdk.Context, destChainID sdk.ChainID) []RelayerFeeLedger {
+	prefix := buildRelayerFeeKeyPrefix(destChainID)
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), prefix)
+	defer iterator.Close()
+
+	ledgers := make([]RelayerFeeLedger, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		channelID := sdk.ChannelID(iterator.Key()[len(prefix)])
+		ledgers = append(ledgers, decodeRelayerFeeLedger(channelID, iterator.Value()))
+	}
+	return ledgers
+}
+
+// GetPackageFee returns the relayer fee of a package recorded in the ledger, the fee record is pruned
+// with the package.
+func (k *Keeper) GetPackageFee(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) (PackageFee, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(buildPackageFeeKey(destChainID, channelID, sequence))
+	if bz == nil {
+		return PackageFee{}, false
+	}
+	return PackageFee{
+		Payer: sdk.AccAddress(bz[sequenceLength:]),
+		Fee:   int64(binary.BigEndian.Uint64(bz[:sequenceLength])),
+	}, true
+}
+
+func (k *Keeper) recordRelayerFee(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID,
+	sequence uint64, packageFee PackageFee) {
+	ledger := k.GetRelayerFeeLedger(ctx, destChainID, channelID)
+	ledger.Packages++
+	ledger.Fees += packageFee.Fee
+	if !packageFee.Payer.Empty() {
+		ledger.PayerFees += packageFee.Fee
+	}
+
+	bz := make([]byte, 3*sequenceLength)
+	binary.BigEndian.PutUint64(bz, ledger.Packages)
+	binary.BigEndian.PutUint64(bz[sequenceLength:], uint64(ledger.Fees))
+	binary.BigEndian.PutUint64(bz[2*sequenceLength:], uint64(ledger.PayerFees))
+	kvStore := ctx.KVStore(k.storeKey)
+	kvStore.Set(buildRelayerFeeKey(destChainID, channelID), bz)
+
+	feeBz := make([]byte, sequenceLength, sequenceLength+len(packageFee.Payer))
+	binary.BigEndian.PutUint64(feeBz, uint64(packageFee.Fee))
+	kvStore.Set(buildPackageFeeKey(destChainID, channelID, sequence), append(feeBz, packageFee.Payer...))
+}
+
+func decodeRelayerFeeLedger(channelID sdk.ChannelID, bz []byte) RelayerFeeLedger {
+	ledger := RelayerFeeLedger{ChannelId: channelID}
+	if bz == nil {
+		return ledger
+	}
+	ledger.Packages = binary.BigEndian.Uint64(bz)
+	ledger.Fees = int64(binary.BigEndian.Uint64(bz[sequenceLength:]))
+	ledger.PayerFees = int64(binary.BigEndian.Uint64(bz[2*sequenceLength:]))
+	return ledger
+}
@@ -4,9 +4,11 @@ import (
 	"encoding/binary"
 	"fmt"
 	"math/big"
+	"sort"
 
 	"github.com/cosmos/cosmos-sdk/bsc"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/ibc/metrics"
 	"github.com/cosmos/cosmos-sdk/x/paramHub/types"
 	param "github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/sidechain"
@@ -21,6 +23,14 @@ type Keeper struct {
 	paramSpace       param.Subspace
 	packageCollector *packageCollector
 	sideKeeper       sidechain.Keeper
+	bankKeeper       BankKeeper
+
+	Metrics *metrics.Metrics
+}
+
+type BankKeeper interface {
+	GetCoins(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins
+	SendCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) (sdk.Tags, sdk.Error)
 }
 
 func ParamTypeTable() param.TypeTable {
@@ -34,10 +44,24 @@ func NewKeeper(storeKey sdk.StoreKey, paramSpace param.Subspace, codespace sdk.C
 		packageCollector: newPackageCollector(),
 		paramSpace:       paramSpace.WithTypeTable(ParamTypeTable()),
 		sideKeeper:       sideKeeper,
+		Metrics:          metrics.NopMetrics(),
 	}
 }
 
+func (k *Keeper) EnablePrometheusMetrics() {
+	k.Metrics = metrics.PrometheusMetrics()
+}
+
+func (k *Keeper) SetBankKeeper(bankKeeper BankKeeper) {
+	k.bankKeeper = bankKeeper
+}
+
+// CreateIBCSyncPackage creates a syn package originated by the chain itself, like the validator set updates and
+// the param changes, no account is debited and its relayer fee is paid by the system pools of the destination chain.
 func (k *Keeper) CreateIBCSyncPackage(ctx sdk.Context, destChainName string, channelName string, packageLoad []byte) (uint64, sdk.Error) {
+	if sdk.IsUpgrade(sdk.IBCRelayerFeeSchedule) {
+		return k.CreateIBCSyncPackageWithFeePayer(ctx, destChainName, channelName, nil, packageLoad)
+	}
 	relayerFee, err := k.GetRelayerFeeParam(ctx, destChainName)
 	if err != nil {
 		return 0, ErrFeeParamMismatch(DefaultCodespace, fmt.Sprintf("fail to load relayerFee, %v", err))
@@ -124,6 +148,54 @@ func (k *Keeper) GetIBCPackageById(ctx sdk.Context, destChainID sdk.ChainID, cha
 	return kvStore.Get(key), nil
 }
 
+// GetPackages returns at most limit packages of a channel from fromSequence to toSequence, without upper bound
+// if toSequence is 0.
+func (k *Keeper) GetPackages(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID,
+	fromSequence, toSequence uint64, limit int) []PackageInfo {
+	srcChainID := k.sideKeeper.GetSrcChainID()
+	prefixKey := buildIBCPackageKeyPrefix(srcChainID, destChainID, channelID)
+	iterator := ctx.KVStore(k.storeKey).Iterator(
+		buildIBCPackageKey(srcChainID, destChainID, channelID, fromSequence), sdk.PrefixEndBytes(prefixKey))
+	defer iterator.Close()
+
+	packages := make([]PackageInfo, 0)
+	for ; iterator.Valid() && len(packages) < limit; iterator.Next() {
+		packageKey := iterator.Key()
+		if len(packageKey) != totalPackageKeyLength {
+			continue
+		}
+		sequence := binary.BigEndian.Uint64(packageKey[totalPackageKeyLength-sequenceLength:])
+		if toSequence > 0 && sequence > toSequence {
+			break
+		}
+		info := PackageInfo{ChannelId: channelID, Sequence: sequence, Package: iterator.Value()}
+		if packageType, relayerFee, err := sTypes.DecodePackageHeader(iterator.Value()); err == nil {
+			info.Type = packageType
+			info.RelayerFee = relayerFee.String()
+		}
+		packages = append(packages, info)
+	}
+	return packages
+}
+
+// GetChannelSequences returns the send sequences of the channels of a destination chain ordered by channel.
+func (k *Keeper) GetChannelSequences(ctx sdk.Context, destChainID sdk.ChainID) []ChannelSequence {
+	permissions := k.sideKeeper.GetChannelSendPermissions(ctx, destChainID)
+	sequences := make([]ChannelSequence, 0, len(permissions))
+	for channelID := range permissions {
+		sequence := ChannelSequence{
+			ChannelId:    channelID,
+			SendSequence: k.sideKeeper.GetSendSequence(ctx, destChainID, channelID),
+		}
+		sequence.AckedSequence, sequence.Acked = k.GetAckedSequence(ctx, destChainID, channelID)
+		sequences = append(sequences, sequence)
+	}
+	sort.Slice(sequences, func(i, j int) bool {
+		return sequences[i].ChannelId < sequences[j].ChannelId
+	})
+	return sequences
+}
+
 func (k *Keeper) CleanupIBCPackage(ctx sdk.Context, destChainName string, channelName string, confirmedSequence uint64) {
 	destChainID, err := k.sideKeeper.GetDestChainID(destChainName)
 	if err != nil {
@@ -133,12 +205,19 @@ func (k *Keeper) CleanupIBCPackage(ctx sdk.Context, destChainName string, channe
 	if err != nil {
 		return
 	}
+	k.cleanupIBCPackageById(ctx, destChainID, channelID, confirmedSequence, 0)
+}
+
+// cleanupIBCPackageById deletes the packages up to confirmedSequence, at most limit of them if limit is positive,
+// and returns the number of deleted packages.
+func (k *Keeper) cleanupIBCPackageById(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID,
+	confirmedSequence uint64, limit int) int {
 	prefixKey := buildIBCPackageKeyPrefix(k.sideKeeper.GetSrcChainID(), destChainID, channelID)
 	kvStore := ctx.KVStore(k.storeKey)
 	iterator := sdk.KVStorePrefixIterator(kvStore, prefixKey)
-	defer iterator.Close()
 
-	for ; iterator.Valid(); iterator.Next() {
+	var packageKeys [][]byte
+	for ; iterator.Valid() && (limit <= 0 || len(packageKeys) < limit); iterator.Next() {
 		packageKey := iterator.Key()
 		if len(packageKey) != totalPackageKeyLength {
 			continue
@@ -147,8 +226,18 @@ func (k *Keeper) CleanupIBCPackage(ctx sdk.Context, destChainName string, channe
 		if sequence > confirmedSequence {
 			break
 		}
+		packageKeys = append(packageKeys, packageKey)
+	}
+	iterator.Close()
+
+	for _, packageKey := range packageKeys {
 		kvStore.Delete(packageKey)
+		if sdk.IsUpgrade(sdk.IBCRelayerFeeSchedule) {
+			sequence := binary.BigEndian.Uint64(packageKey[totalPackageKeyLength-sequenceLength:])
+			kvStore.Delete(buildPackageFeeKey(destChainID, channelID, sequence))
+		}
 	}
+	return len(packageKeys)
 }
 
 func (k Keeper) GetRelayerFeeParam(ctx sdk.Context, destChainName string) (relaterFee *big.Int, err error) {
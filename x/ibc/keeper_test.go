@@ -9,20 +9,26 @@ import (
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
 
+	"github.com/cosmos/cosmos-sdk/bsc"
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/sidechain"
+	sTypes "github.com/cosmos/cosmos-sdk/x/sidechain/types"
 )
 
 func createTestInput(t *testing.T, isCheckTx bool) (sdk.Context, Keeper) {
 	keyIBC := sdk.NewKVStoreKey("ibc")
 	keySideChain := sdk.NewKVStoreKey("sc")
+	keyParams := sdk.NewKVStoreKey("params")
+	tkeyParams := sdk.NewTransientStoreKey("transient_params")
 	db := dbm.NewMemDB()
 	ms := store.NewCommitMultiStore(db)
 	ms.MountStoreWithDB(keyIBC, sdk.StoreTypeIAVL, db)
 	ms.MountStoreWithDB(keySideChain, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
 	err := ms.LoadLatestVersion()
 	require.Nil(t, err)
 
@@ -30,8 +36,6 @@ func createTestInput(t *testing.T, isCheckTx bool) (sdk.Context, Keeper) {
 	if isCheckTx {
 		mode = sdk.RunTxModeCheck
 	}
-	keyParams := sdk.NewKVStoreKey("params")
-	tkeyParams := sdk.NewTransientStoreKey("transient_params")
 
 	cdc := createTestCodec()
 	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
@@ -117,6 +121,238 @@ func TestKeeper(t *testing.T) {
 
 }
 
+func TestPrunePackages(t *testing.T) {
+	destChainName := "bsc"
+	destChainID := sdk.ChainID(0x000f)
+	channelName := "transfer"
+	channelID := sdk.ChannelID(0x01)
+
+	ctx, keeper := createTestInput(t, false)
+	keeper.sideKeeper.SetSrcChainID(sdk.ChainID(0x0001))
+	require.NoError(t, keeper.sideKeeper.RegisterDestChain(destChainName, destChainID))
+	require.NoError(t, keeper.sideKeeper.RegisterChannel(channelName, channelID, nil))
+	keeper.sideKeeper.SetChannelSendPermission(ctx, destChainID, channelID, sdk.ChannelAllow)
+
+	packageTypes := []sdk.CrossChainPackageType{
+		sdk.SynCrossChainPackageType, sdk.AckCrossChainPackageType, sdk.SynCrossChainPackageType,
+		sdk.SynCrossChainPackageType, sdk.SynCrossChainPackageType,
+	}
+	for _, packageType := range packageTypes {
+		_, err := keeper.CreateRawIBCPackage(ctx, destChainName, channelName, packageType, []byte{0x01}, *big.NewInt(100))
+		require.NoError(t, err)
+	}
+
+	keeper.AcknowledgePackage(ctx, destChainID, channelID)
+	_, found := keeper.GetAckedSequence(ctx, destChainID, channelID)
+	require.False(t, found)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.IBCPackagePruning, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() { sdk.UpgradeMgr = sdk.NewUpgradeManager(sdk.UpgradeConfig{}) }()
+
+	// the ack package at sequence 1 is not acknowledged
+	for _, expected := range []uint64{0, 2, 3} {
+		keeper.AcknowledgePackage(ctx, destChainID, channelID)
+		acked, found := keeper.GetAckedSequence(ctx, destChainID, channelID)
+		require.True(t, found)
+		require.Equal(t, expected, acked)
+	}
+
+	// the default retention keeps everything
+	require.Equal(t, DefaultPackageRetention, keeper.GetPackageRetention(ctx, destChainID))
+	keeper.PrunePackages(ctx)
+	ibcPackage, err := keeper.GetIBCPackage(ctx, destChainName, channelName, 0)
+	require.NoError(t, err)
+	require.NotNil(t, ibcPackage)
+
+	storePrefix := []byte{0x99}
+	keeper.sideKeeper.SetSideChainIdAndStorePrefix(ctx, destChainName, storePrefix)
+	keeper.SetParams(ctx.WithSideChainKeyPrefix(storePrefix), Params{RelayerFee: DefaultRelayerFeeParam, PackageRetention: 1})
+	keeper.PrunePackages(ctx)
+	for sequence := uint64(0); sequence < uint64(len(packageTypes)); sequence++ {
+		ibcPackage, err := keeper.GetIBCPackage(ctx, destChainName, channelName, sequence)
+		require.NoError(t, err)
+		require.Equal(t, sequence > 2, ibcPackage != nil)
+	}
+}
+
+func TestQuerier(t *testing.T) {
+	destChainName := "bsc"
+	destChainID := sdk.ChainID(0x000f)
+	channelName := "transfer"
+	channelID := sdk.ChannelID(0x01)
+
+	ctx, keeper := createTestInput(t, false)
+	keeper.sideKeeper.SetSrcChainID(sdk.ChainID(0x0001))
+	require.NoError(t, keeper.sideKeeper.RegisterDestChain(destChainName, destChainID))
+	require.NoError(t, keeper.sideKeeper.RegisterChannel(channelName, channelID, nil))
+	keeper.sideKeeper.SetChannelSendPermission(ctx, destChainID, channelID, sdk.ChannelAllow)
+	keeper.sideKeeper.SetChannelSendPermission(ctx, destChainID, sdk.ChannelID(0x02), sdk.ChannelForbidden)
+
+	for i := 0; i < 5; i++ {
+		_, err := keeper.CreateRawIBCPackage(ctx, destChainName, channelName, sdk.SynCrossChainPackageType, []byte{byte(i)}, *big.NewInt(100))
+		require.NoError(t, err)
+	}
+	keeper.CleanupIBCPackage(ctx, destChainName, channelName, 0)
+
+	cdc := createTestCodec()
+	querier := NewQuerier(keeper, cdc)
+	query := func(path string, params interface{}) []byte {
+		bz, err := querier(ctx, []string{path}, abci.RequestQuery{Data: cdc.MustMarshalJSON(params)})
+		require.NoError(t, err)
+		return bz
+	}
+
+	var packages []PackageInfo
+	cdc.MustUnmarshalJSON(query(QueryPackages, QueryPackagesParams{SideChainId: destChainName, ChannelId: channelID}), &packages)
+	require.Len(t, packages, 4)
+	require.Equal(t, uint64(1), packages[0].Sequence)
+	require.Equal(t, sdk.SynCrossChainPackageType, packages[0].Type)
+	require.Equal(t, "100", packages[0].RelayerFee)
+	ibcPackage, err := keeper.GetIBCPackageById(ctx, destChainID, channelID, 1)
+	require.NoError(t, err)
+	require.Equal(t, ibcPackage, packages[0].Package)
+
+	cdc.MustUnmarshalJSON(query(QueryPackages, QueryPackagesParams{
+		SideChainId: destChainName, ChannelId: channelID, FromSequence: 2, ToSequence: 4, Limit: 2}), &packages)
+	require.Len(t, packages, 2)
+	require.Equal(t, uint64(2), packages[0].Sequence)
+	require.Equal(t, uint64(3), packages[1].Sequence)
+
+	_, sdkErr := querier(ctx, []string{QueryPackages}, abci.RequestQuery{
+		Data: cdc.MustMarshalJSON(QueryPackagesParams{SideChainId: "btc", ChannelId: channelID})})
+	require.Error(t, sdkErr)
+
+	var sequences SequencesInfo
+	cdc.MustUnmarshalJSON(query(QuerySequences, destChainName), &sequences)
+	require.Equal(t, sdk.ChainID(0x0001), sequences.SrcChainId)
+	require.Equal(t, destChainID, sequences.DestChainId)
+	require.Equal(t, []ChannelSequence{
+		{ChannelId: channelID, SendSequence: 5},
+		{ChannelId: sdk.ChannelID(0x02)},
+	}, sequences.Channels)
+}
+
+type mockBankKeeper struct {
+	balances map[string]int64
+}
+
+func (k *mockBankKeeper) GetCoins(ctx sdk.Context, addr sdk.AccAddress) sdk.Coins {
+	return sdk.Coins{sdk.NewCoin(sdk.NativeTokenSymbol, k.balances[addr.String()])}
+}
+
+func (k *mockBankKeeper) SendCoins(ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) (sdk.Tags, sdk.Error) {
+	amount := amt.AmountOf(sdk.NativeTokenSymbol)
+	if k.balances[fromAddr.String()] < amount {
+		return nil, sdk.ErrInsufficientCoins("not enough coins")
+	}
+	k.balances[fromAddr.String()] -= amount
+	k.balances[toAddr.String()] += amount
+	return nil, nil
+}
+
+func TestRelayerFeeSchedule(t *testing.T) {
+	destChainName := "bsc"
+	destChainID := sdk.ChainID(0x000f)
+	channelName := "transfer"
+	channelID := sdk.ChannelID(0x01)
+
+	ctx, keeper := createTestInput(t, false)
+	keeper.sideKeeper.SetSrcChainID(sdk.ChainID(0x0001))
+	require.NoError(t, keeper.sideKeeper.RegisterDestChain(destChainName, destChainID))
+	require.NoError(t, keeper.sideKeeper.RegisterChannel(channelName, channelID, nil))
+	require.NoError(t, keeper.sideKeeper.RegisterChannel("stake", sdk.ChannelID(0x08), nil))
+	keeper.sideKeeper.SetChannelSendPermission(ctx, destChainID, channelID, sdk.ChannelAllow)
+	keeper.sideKeeper.SetChannelSendPermission(ctx, destChainID, sdk.ChannelID(0x08), sdk.ChannelAllow)
+	storePrefix := []byte{0x99}
+	keeper.sideKeeper.SetSideChainIdAndStorePrefix(ctx, destChainName, storePrefix)
+	params := Params{
+		RelayerFee:         DefaultRelayerFeeParam,
+		ChannelRelayerFees: []ChannelRelayerFee{{ChannelId: channelID, Fee: 2e6, FeePerByte: 10}},
+	}
+	require.NoError(t, params.UpdateCheck())
+	keeper.SetParams(ctx.WithSideChainKeyPrefix(storePrefix), params)
+
+	payer := sdk.AccAddress([]byte("payer"))
+	bankKeeper := &mockBankKeeper{balances: map[string]int64{payer.String(): 3e6}}
+	keeper.SetBankKeeper(bankKeeper)
+	payload := []byte{0x01, 0x02, 0x03}
+
+	// every package pays the relayer fee of the side chain before the upgrade
+	fee, err := keeper.GetRelayerFee(ctx, destChainName, channelID, len(payload))
+	require.NoError(t, err)
+	require.Equal(t, DefaultRelayerFeeParam, fee)
+	_, sdkErr := keeper.CreateIBCSyncPackage(ctx, destChainName, channelName, payload)
+	require.NoError(t, sdkErr)
+	require.Empty(t, keeper.GetRelayerFeeLedgers(ctx, destChainID))
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.IBCRelayerFeeSchedule, 1)
+	sdk.UpgradeMgr.SetHeight(1)
+	defer func() { sdk.UpgradeMgr = sdk.NewUpgradeManager(sdk.UpgradeConfig{}) }()
+
+	fee, err = keeper.GetRelayerFee(ctx, destChainName, channelID, len(payload))
+	require.NoError(t, err)
+	require.Equal(t, int64(2e6+30), fee)
+	fee, err = keeper.GetRelayerFee(ctx, destChainName, sdk.ChannelID(0x08), len(payload))
+	require.NoError(t, err)
+	require.Equal(t, DefaultRelayerFeeParam, fee)
+
+	sequence, sdkErr := keeper.CreateIBCSyncPackageWithFeePayer(ctx, destChainName, channelName, payer, payload)
+	require.NoError(t, sdkErr)
+	require.Equal(t, uint64(1), sequence)
+	require.Equal(t, int64(3e6-2e6-30), bankKeeper.balances[payer.String()])
+	require.Equal(t, int64(2e6+30), bankKeeper.balances[sdk.PegAccount.String()])
+	ibcPackage, err := keeper.GetIBCPackageById(ctx, destChainID, channelID, sequence)
+	require.NoError(t, err)
+	_, relayerFee, err := sTypes.DecodePackageHeader(ibcPackage)
+	require.NoError(t, err)
+	require.Equal(t, bsc.ConvertBCAmountToBSCAmount(2e6+30).String(), relayerFee.String())
+
+	// the payer can not afford another package
+	_, sdkErr = keeper.CreateIBCSyncPackageWithFeePayer(ctx, destChainName, channelName, payer, payload)
+	require.Equal(t, CodeInsufficientRelayerFee, sdkErr.Code())
+	require.Equal(t, int64(3e6-2e6-30), bankKeeper.balances[payer.String()])
+	require.Equal(t, uint64(2), keeper.sideKeeper.GetSendSequence(ctx, destChainID, channelID))
+
+	// packages of the system are paid by the system pools
+	sequence, sdkErr = keeper.CreateIBCSyncPackage(ctx, destChainName, channelName, payload)
+	require.NoError(t, sdkErr)
+	packageFee, found := keeper.GetPackageFee(ctx, destChainID, channelID, sequence)
+	require.True(t, found)
+	require.True(t, packageFee.Payer.Empty())
+	require.Equal(t, int64(2e6+30), packageFee.Fee)
+
+	require.Equal(t, []RelayerFeeLedger{
+		{ChannelId: channelID, Packages: 2, Fees: 2 * (2e6 + 30), PayerFees: 2e6 + 30},
+	}, keeper.GetRelayerFeeLedgers(ctx, destChainID))
+
+	packageFee, found = keeper.GetPackageFee(ctx, destChainID, channelID, 1)
+	require.True(t, found)
+	require.Equal(t, PackageFee{Payer: payer, Fee: 2e6 + 30}, packageFee)
+	keeper.CleanupIBCPackage(ctx, destChainName, channelName, 1)
+	_, found = keeper.GetPackageFee(ctx, destChainID, channelID, 1)
+	require.False(t, found)
+
+	// apps debit the relayer fee of the channel from the originating account
+	_, _, sdkErr = keeper.CreatePaidIBCPackageById(ctx, destChainID, sdk.ChannelID(0x08), payer, payload, 0)
+	require.Equal(t, CodeInsufficientRelayerFee, sdkErr.Code())
+	require.Equal(t, int64(3e6-2e6-30), bankKeeper.balances[payer.String()])
+	bankKeeper.balances[payer.String()] += 1e6
+	sequence, fee, sdkErr = keeper.CreatePaidIBCPackageById(ctx, destChainID, sdk.ChannelID(0x08), payer, payload, 0)
+	require.NoError(t, sdkErr)
+	require.Equal(t, DefaultRelayerFeeParam, fee)
+	require.Equal(t, int64(3e6-2e6-30), bankKeeper.balances[payer.String()])
+	require.Equal(t, int64(2e6+30+1e6), bankKeeper.balances[sdk.PegAccount.String()])
+	packageFee, found = keeper.GetPackageFee(ctx, destChainID, sdk.ChannelID(0x08), sequence)
+	require.True(t, found)
+	require.Equal(t, PackageFee{Payer: payer, Fee: 1e6}, packageFee)
+	require.Equal(t, RelayerFeeLedger{ChannelId: sdk.ChannelID(0x08), Packages: 1, Fees: 1e6, PayerFees: 1e6},
+		keeper.GetRelayerFeeLedger(ctx, destChainID, sdk.ChannelID(0x08)))
+
+	params.ChannelRelayerFees = append(params.ChannelRelayerFees, ChannelRelayerFee{ChannelId: channelID, Fee: 1})
+	require.Error(t, params.UpdateCheck())
+}
+
 func createTestCodec() *codec.Codec {
 	cdc := codec.New()
 	sdk.RegisterCodec(cdc)
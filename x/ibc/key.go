@@ -16,8 +16,11 @@ const (
 )
 
 var (
-	PrefixForIbcPackageKey = []byte{0x00}
-	PrefixForSequenceKey   = []byte{0x01}
+	PrefixForIbcPackageKey  = []byte{0x00}
+	PrefixForSequenceKey    = []byte{0x01}
+	PrefixForAckSequenceKey = []byte{0x02}
+	PrefixForRelayerFeeKey  = []byte{0x03}
+	PrefixForPackageFeeKey  = []byte{0x04}
 )
 
 func buildIBCPackageKey(srcChainID, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) []byte {
@@ -32,6 +35,11 @@ func buildIBCPackageKey(srcChainID, destChainID sdk.ChainID, channelID sdk.Chann
 	return key
 }
 
+// GetIBCPackageKey returns the key of a package in the ibc store, relayers query it with a proof.
+func GetIBCPackageKey(srcChainID, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) []byte {
+	return buildIBCPackageKey(srcChainID, destChainID, channelID, sequence)
+}
+
 func buildIBCPackageKeyPrefix(srcChainID, destChainID sdk.ChainID, channelID sdk.ChannelID) []byte {
 	key := make([]byte, totalPackageKeyLength-sequenceLength)
 
@@ -41,4 +49,50 @@ func buildIBCPackageKeyPrefix(srcChainID, destChainID sdk.ChainID, channelID sdk
 	copy(key[prefixLength+srcChainIdLength+destChainIDLength:], []byte{byte(channelID)})
 
 	return key
-}
\ No newline at end of file
+}
+
+func buildAckSequenceKey(destChainID sdk.ChainID, channelID sdk.ChannelID) []byte {
+	key := make([]byte, prefixLength+destChainIDLength+channelIDLength)
+
+	copy(key[:prefixLength], PrefixForAckSequenceKey)
+	binary.BigEndian.PutUint16(key[prefixLength:prefixLength+destChainIDLength], uint16(destChainID))
+	copy(key[prefixLength+destChainIDLength:], []byte{byte(channelID)})
+
+	return key
+}
+
+func parseAckSequenceKey(key []byte) (sdk.ChainID, sdk.ChannelID) {
+	destChainID := sdk.ChainID(binary.BigEndian.Uint16(key[prefixLength : prefixLength+destChainIDLength]))
+	channelID := sdk.ChannelID(key[prefixLength+destChainIDLength])
+	return destChainID, channelID
+}
+
+func buildRelayerFeeKey(destChainID sdk.ChainID, channelID sdk.ChannelID) []byte {
+	key := make([]byte, prefixLength+destChainIDLength+channelIDLength)
+
+	copy(key[:prefixLength], PrefixForRelayerFeeKey)
+	binary.BigEndian.PutUint16(key[prefixLength:prefixLength+destChainIDLength], uint16(destChainID))
+	copy(key[prefixLength+destChainIDLength:], []byte{byte(channelID)})
+
+	return key
+}
+
+func buildRelayerFeeKeyPrefix(destChainID sdk.ChainID) []byte {
+	key := make([]byte, prefixLength+destChainIDLength)
+
+	copy(key[:prefixLength], PrefixForRelayerFeeKey)
+	binary.BigEndian.PutUint16(key[prefixLength:prefixLength+destChainIDLength], uint16(destChainID))
+
+	return key
+}
+
+func buildPackageFeeKey(destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) []byte {
+	key := make([]byte, prefixLength+destChainIDLength+channelIDLength+sequenceLength)
+
+	copy(key[:prefixLength], PrefixForPackageFeeKey)
+	binary.BigEndian.PutUint16(key[prefixLength:prefixLength+destChainIDLength], uint16(destChainID))
+	copy(key[prefixLength+destChainIDLength:], []byte{byte(channelID)})
+	binary.BigEndian.PutUint64(key[prefixLength+destChainIDLength+channelIDLength:], sequence)
+
+	return key
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	metricsPkg "github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics contains Metrics exposed by this package.
+type Metrics struct {
+	AckedSequence    metricsPkg.Gauge
+	PrunedPackages   metricsPkg.Counter
+	RetainedPackages metricsPkg.Gauge
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+func PrometheusMetrics() *Metrics {
+	return &Metrics{
+		AckedSequence: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Subsystem: "ibc",
+			Name:      "acked_sequence",
+			Help:      "The last package acknowledged by the destination chain",
+		}, []string{"chain_id", "channel_id"}),
+		PrunedPackages: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Subsystem: "ibc",
+			Name:      "pruned_packages",
+			Help:      "The numbers of packages pruned from boot",
+		}, []string{"chain_id", "channel_id"}),
+		RetainedPackages: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Subsystem: "ibc",
+			Name:      "retained_packages",
+			Help:      "The numbers of packages left in the store after pruning",
+		}, []string{"chain_id", "channel_id"}),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		AckedSequence:    discard.NewGauge(),
+		PrunedPackages:   discard.NewCounter(),
+		RetainedPackages: discard.NewGauge(),
+	}
+}
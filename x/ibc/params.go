@@ -3,26 +3,45 @@ package ibc
 import (
 	"fmt"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
 const (
 	DefaultRelayerFeeParam int64 = 1e6 // decimal is 8
+	// DefaultPackageRetention is used by side chains which have not set the package retention
+	DefaultPackageRetention int64 = 10000
 	// Default parameter namespace
 	DefaultParamspace = "ibc"
 )
 
 var (
-	ParamRelayerFee = []byte("relayerFee")
+	ParamRelayerFee         = []byte("relayerFee")
+	ParamPackageRetention   = []byte("packageRetention")
+	ParamChannelRelayerFees = []byte("channelRelayerFees")
 )
 
+// ChannelRelayerFee is the relayer fee of the syn packages of a channel, FeePerByte is charged for each byte
+// of the package payload on top of Fee.
+type ChannelRelayerFee struct {
+	ChannelId  sdk.ChannelID `json:"channel_id"`
+	Fee        int64         `json:"fee"`
+	FeePerByte int64         `json:"fee_per_byte"`
+}
+
 type Params struct {
 	RelayerFee int64 `json:"relayer_fee"`
+	// Number of packages kept before the last acknowledged package of a channel, 0 disables the pruning.
+	PackageRetention int64 `json:"package_retention"`
+	// Relayer fees of the channels which do not use RelayerFee.
+	ChannelRelayerFees []ChannelRelayerFee `json:"channel_relayer_fees"`
 }
 
 func (p *Params) KeyValuePairs() params.KeyValuePairs {
 	return params.KeyValuePairs{
 		{ParamRelayerFee, &p.RelayerFee},
+		{ParamPackageRetention, &p.PackageRetention},
+		{ParamChannelRelayerFees, &p.ChannelRelayerFees},
 	}
 }
 
@@ -30,6 +49,22 @@ func (p *Params) UpdateCheck() error {
 	if p.RelayerFee <= 0 {
 		return fmt.Errorf("the syn_package_fee should be greater than 0")
 	}
+	if p.PackageRetention < 0 {
+		return fmt.Errorf("the package_retention should not be negative")
+	}
+	channels := make(map[sdk.ChannelID]bool, len(p.ChannelRelayerFees))
+	for _, channelFee := range p.ChannelRelayerFees {
+		if channels[channelFee.ChannelId] {
+			return fmt.Errorf("duplicated relayer fee of channel %d", channelFee.ChannelId)
+		}
+		channels[channelFee.ChannelId] = true
+		if channelFee.Fee <= 0 {
+			return fmt.Errorf("the relayer fee of channel %d should be greater than 0", channelFee.ChannelId)
+		}
+		if channelFee.FeePerByte < 0 {
+			return fmt.Errorf("the relayer fee per byte of channel %d should not be negative", channelFee.ChannelId)
+		}
+	}
 	return nil
 }
 
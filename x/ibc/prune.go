@@ -0,0 +1,118 @@
+package ibc
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// at most maxPrunedPackagesPerBlock packages of a channel are pruned in a block, so the first
+// blocks after enabling the pruning do not have to delete the whole history
+const maxPrunedPackagesPerBlock = 1000
+
+// GetAckedSequence returns the sequence of the last syn package acknowledged by the destination chain.
+func (k *Keeper) GetAckedSequence(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID) (uint64, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(buildAckSequenceKey(destChainID, channelID))
+	if bz == nil {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(bz), true
+}
+
+func (k *Keeper) setAckedSequence(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID, sequence uint64) {
+	bz := make([]byte, sequenceLength)
+	binary.BigEndian.PutUint64(bz, sequence)
+	ctx.KVStore(k.storeKey).Set(buildAckSequenceKey(destChainID, channelID), bz)
+}
+
+// AcknowledgePackage is called for every ack or fail ack package received from the destination chain.
+// The destination chain handles the packages of a channel in order, so the ack is taken for the first syn
+// package after the last acknowledged one, and all the packages before it have been received. Syn packages
+// which are not acked by the destination chain only make the acknowledged sequence lag behind.
+func (k *Keeper) AcknowledgePackage(ctx sdk.Context, destChainID sdk.ChainID, channelID sdk.ChannelID) {
+	if !sdk.IsUpgrade(sdk.IBCPackagePruning) {
+		return
+	}
+	start := uint64(0)
+	if acked, found := k.GetAckedSequence(ctx, destChainID, channelID); found {
+		start = acked + 1
+	}
+
+	srcChainID := k.sideKeeper.GetSrcChainID()
+	prefixKey := buildIBCPackageKeyPrefix(srcChainID, destChainID, channelID)
+	iterator := ctx.KVStore(k.storeKey).Iterator(
+		buildIBCPackageKey(srcChainID, destChainID, channelID, start), sdk.PrefixEndBytes(prefixKey))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		packageKey := iterator.Key()
+		if len(packageKey) != totalPackageKeyLength || len(iterator.Value()) == 0 {
+			continue
+		}
+		if sdk.CrossChainPackageType(iterator.Value()[0]) == sdk.SynCrossChainPackageType {
+			sequence := binary.BigEndian.Uint64(packageKey[totalPackageKeyLength-sequenceLength:])
+			k.setAckedSequence(ctx, destChainID, channelID, sequence)
+			k.Metrics.AckedSequence.With(metricLabels(destChainID, channelID)...).Set(float64(sequence))
+			return
+		}
+	}
+	ctx.Logger().With("module", "ibc").Debug("no syn package to acknowledge",
+		"chainId", destChainID, "channelId", channelID, "start", start)
+}
+
+// GetPackageRetention returns the number of packages kept before the last acknowledged package.
+func (k *Keeper) GetPackageRetention(ctx sdk.Context, destChainID sdk.ChainID) int64 {
+	retention := DefaultPackageRetention
+	destChainName, err := k.sideKeeper.GetDestChainName(destChainID)
+	if err != nil {
+		return retention
+	}
+	if storePrefix := k.sideKeeper.GetSideChainStorePrefix(ctx, destChainName); storePrefix != nil {
+		k.paramSpace.GetIfExists(ctx.WithSideChainKeyPrefix(storePrefix), ParamPackageRetention, &retention)
+	}
+	return retention
+}
+
+// PrunePackages deletes the packages of every channel which are older than the package retention of
+// their destination chain, counting from the last acknowledged package.
+func (k *Keeper) PrunePackages(ctx sdk.Context) {
+	if !sdk.IsUpgrade(sdk.IBCPackagePruning) {
+		return
+	}
+	type ackedChannel struct {
+		destChainID sdk.ChainID
+		channelID   sdk.ChannelID
+		sequence    uint64
+	}
+	var channels []ackedChannel
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), PrefixForAckSequenceKey)
+	for ; iterator.Valid(); iterator.Next() {
+		destChainID, channelID := parseAckSequenceKey(iterator.Key())
+		channels = append(channels, ackedChannel{destChainID, channelID, binary.BigEndian.Uint64(iterator.Value())})
+	}
+	iterator.Close()
+
+	for _, channel := range channels {
+		retention := k.GetPackageRetention(ctx, channel.destChainID)
+		if retention <= 0 || channel.sequence < uint64(retention) {
+			continue
+		}
+		confirmedSequence := channel.sequence - uint64(retention)
+		pruned := k.cleanupIBCPackageById(ctx, channel.destChainID, channel.channelID, confirmedSequence, maxPrunedPackagesPerBlock)
+		if pruned == 0 {
+			continue
+		}
+		labels := metricLabels(channel.destChainID, channel.channelID)
+		k.Metrics.PrunedPackages.With(labels...).Add(float64(pruned))
+		if pruned < maxPrunedPackagesPerBlock {
+			sendSequence := k.sideKeeper.GetSendSequence(ctx, channel.destChainID, channel.channelID)
+			k.Metrics.RetainedPackages.With(labels...).Set(float64(sendSequence - confirmedSequence - 1))
+		}
+		ctx.Logger().With("module", "ibc").Debug("pruned packages", "chainId", channel.destChainID,
+			"channelId", channel.channelID, "confirmedSequence", confirmedSequence, "count", pruned)
+	}
+}
+
+func metricLabels(destChainID sdk.ChainID, channelID sdk.ChannelID) []string {
+	return []string{"chain_id", fmt.Sprintf("%d", destChainID), "channel_id", fmt.Sprintf("%d", channelID)}
+}
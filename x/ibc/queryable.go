@@ -0,0 +1,174 @@
+package ibc
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/bsc"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	QueryPackages    = "packages"
+	QuerySequences   = "sequences"
+	QueryRelayerFees = "relayer-fees"
+	QueryPackageFee  = "package-fee"
+
+	// at most maxPackagesPerQuery packages are returned by a packages query
+	maxPackagesPerQuery = 100
+)
+
+// QueryPackagesParams selects the packages of a channel from FromSequence to ToSequence, without upper bound
+// if ToSequence is 0.
+type QueryPackagesParams struct {
+	SideChainId  string        `json:"side_chain_id"`
+	ChannelId    sdk.ChannelID `json:"channel_id"`
+	FromSequence uint64        `json:"from_sequence"`
+	ToSequence   uint64        `json:"to_sequence"`
+	Limit        int           `json:"limit"`
+}
+
+// PackageInfo is an outbound package with its decoded header, Package is the value saved in the store.
+type PackageInfo struct {
+	ChannelId  sdk.ChannelID             `json:"channel_id"`
+	Sequence   uint64                    `json:"sequence"`
+	Type       sdk.CrossChainPackageType `json:"type"`
+	RelayerFee string                    `json:"relayer_fee"`
+	Package    []byte                    `json:"package"`
+}
+
+// ChannelSequence is the next send sequence of a channel and its last acknowledged sequence, if any.
+type ChannelSequence struct {
+	ChannelId     sdk.ChannelID `json:"channel_id"`
+	SendSequence  uint64        `json:"send_sequence"`
+	AckedSequence uint64        `json:"acked_sequence"`
+	Acked         bool          `json:"acked"`
+}
+
+// SequencesInfo has the chain ids needed to build the package keys of a side chain with its channel sequences.
+type SequencesInfo struct {
+	SideChainId string            `json:"side_chain_id"`
+	SrcChainId  sdk.ChainID       `json:"src_chain_id"`
+	DestChainId sdk.ChainID       `json:"dest_chain_id"`
+	Channels    []ChannelSequence `json:"channels"`
+}
+
+// RelayerFeesInfo is the relayer fee schedule of a side chain and the relayer fees of its channels.
+type RelayerFeesInfo struct {
+	SideChainId        string              `json:"side_chain_id"`
+	RelayerFee         int64               `json:"relayer_fee"`
+	ChannelRelayerFees []ChannelRelayerFee `json:"channel_relayer_fees"`
+	Ledgers            []RelayerFeeLedger  `json:"ledgers"`
+}
+
+type QueryPackageFeeParams struct {
+	SideChainId string        `json:"side_chain_id"`
+	ChannelId   sdk.ChannelID `json:"channel_id"`
+	Sequence    uint64        `json:"sequence"`
+}
+
+// creates a querier for ibc REST endpoints
+func NewQuerier(k Keeper, cdc *codec.Codec) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		if len(path) == 0 {
+			return nil, sdk.ErrUnknownRequest("unknown ibc query endpoint")
+		}
+		switch path[0] {
+		case QueryPackages:
+			var params QueryPackagesParams
+			if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+			}
+			return queryPackages(ctx, k, cdc, params)
+		case QuerySequences:
+			var sideChainId string
+			if err := cdc.UnmarshalJSON(req.Data, &sideChainId); err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+			}
+			return querySequences(ctx, k, cdc, sideChainId)
+		case QueryRelayerFees:
+			var sideChainId string
+			if err := cdc.UnmarshalJSON(req.Data, &sideChainId); err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+			}
+			return queryRelayerFees(ctx, k, cdc, sideChainId)
+		case QueryPackageFee:
+			var params QueryPackageFeeParams
+			if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+			}
+			return queryPackageFee(ctx, k, cdc, params)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown ibc query endpoint")
+		}
+	}
+}
+
+func queryPackages(ctx sdk.Context, k Keeper, cdc *codec.Codec, params QueryPackagesParams) ([]byte, sdk.Error) {
+	destChainID, err := k.sideKeeper.GetDestChainID(params.SideChainId)
+	if err != nil {
+		return nil, ErrInvalidChainId(DefaultCodespace, err.Error())
+	}
+	if params.ToSequence > 0 && params.ToSequence < params.FromSequence {
+		return nil, sdk.ErrUnknownRequest("to sequence is less than from sequence")
+	}
+	limit := params.Limit
+	if limit <= 0 || limit > maxPackagesPerQuery {
+		limit = maxPackagesPerQuery
+	}
+	return marshalResult(cdc, k.GetPackages(ctx, destChainID, params.ChannelId, params.FromSequence, params.ToSequence, limit))
+}
+
+func querySequences(ctx sdk.Context, k Keeper, cdc *codec.Codec, sideChainId string) ([]byte, sdk.Error) {
+	destChainID, err := k.sideKeeper.GetDestChainID(sideChainId)
+	if err != nil {
+		return nil, ErrInvalidChainId(DefaultCodespace, err.Error())
+	}
+	return marshalResult(cdc, SequencesInfo{
+		SideChainId: sideChainId,
+		SrcChainId:  k.sideKeeper.GetSrcChainID(),
+		DestChainId: destChainID,
+		Channels:    k.GetChannelSequences(ctx, destChainID),
+	})
+}
+
+func queryRelayerFees(ctx sdk.Context, k Keeper, cdc *codec.Codec, sideChainId string) ([]byte, sdk.Error) {
+	destChainID, err := k.sideKeeper.GetDestChainID(sideChainId)
+	if err != nil {
+		return nil, ErrInvalidChainId(DefaultCodespace, err.Error())
+	}
+	relayerFee, err := k.GetRelayerFeeParam(ctx, sideChainId)
+	if err != nil {
+		return nil, ErrFeeParamMismatch(DefaultCodespace, err.Error())
+	}
+	channelFees := k.GetChannelRelayerFees(ctx, sideChainId)
+	if channelFees == nil {
+		channelFees = []ChannelRelayerFee{}
+	}
+	return marshalResult(cdc, RelayerFeesInfo{
+		SideChainId:        sideChainId,
+		RelayerFee:         bsc.ConvertBSCAmountToBCAmount(relayerFee),
+		ChannelRelayerFees: channelFees,
+		Ledgers:            k.GetRelayerFeeLedgers(ctx, destChainID),
+	})
+}
+
+func queryPackageFee(ctx sdk.Context, k Keeper, cdc *codec.Codec, params QueryPackageFeeParams) ([]byte, sdk.Error) {
+	destChainID, err := k.sideKeeper.GetDestChainID(params.SideChainId)
+	if err != nil {
+		return nil, ErrInvalidChainId(DefaultCodespace, err.Error())
+	}
+	packageFee, found := k.GetPackageFee(ctx, destChainID, params.ChannelId, params.Sequence)
+	if !found {
+		return nil, sdk.ErrUnknownRequest("no relayer fee recorded for the package")
+	}
+	return marshalResult(cdc, packageFee)
+}
+
+func marshalResult(cdc *codec.Codec, result interface{}) ([]byte, sdk.Error) {
+	res, err := codec.MarshalJSONIndent(cdc, result)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return res, nil
+}
@@ -0,0 +1,28 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/tendermint/go-amino"
+
+	"github.com/cosmos/cosmos-sdk/client"
+)
+
+const (
+	flagSourceChainId = "source-chain-id"
+	flagFromNumber    = "from-number"
+	flagLimit         = "limit"
+	flagNumber        = "number"
+)
+
+func AddCommands(cmd *cobra.Command, cdc *amino.Codec) {
+	lightClientCmd := &cobra.Command{
+		Use:   "lightclient",
+		Short: "light client commands",
+	}
+	lightClientCmd.AddCommand(
+		client.GetCommands(
+			ShowClientCmd(cdc),
+			ShowHeadersCmd(cdc),
+			ShowValidatorsCmd(cdc))...)
+	cmd.AddCommand(lightClientCmd)
+}
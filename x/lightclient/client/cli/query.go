@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/go-amino"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/lightclient"
+)
+
+func ShowClientCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show-client",
+		Short: "Show the light client state of a source chain",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			queryData, err := cdc.MarshalJSON(sdk.ChainID(viper.GetUint(flagSourceChainId)))
+			if err != nil {
+				return err
+			}
+			return query(cliCtx, lightclient.QueryClientState, queryData)
+		},
+	}
+
+	cmd.Flags().Uint(flagSourceChainId, 0, "the id of source chain")
+	return cmd
+}
+
+func ShowHeadersCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show-headers",
+		Short: "Show the trusted headers of a source chain from a number",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			queryData, err := cdc.MarshalJSON(lightclient.QueryHeadersParams{
+				ChainId:    sdk.ChainID(viper.GetUint(flagSourceChainId)),
+				FromNumber: viper.GetInt64(flagFromNumber),
+				Limit:      viper.GetInt(flagLimit),
+			})
+			if err != nil {
+				return err
+			}
+			return query(cliCtx, lightclient.QueryHeaders, queryData)
+		},
+	}
+
+	cmd.Flags().Uint(flagSourceChainId, 0, "the id of source chain")
+	cmd.Flags().Int64(flagFromNumber, 0, "the number of the first header to show")
+	cmd.Flags().Int(flagLimit, 0, "the max number of headers to show, 0 for the max of the node")
+	return cmd
+}
+
+func ShowValidatorsCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show-validators",
+		Short: "Show the validator set of a source chain signing a header",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			queryData, err := cdc.MarshalJSON(lightclient.QueryValidatorSetParams{
+				ChainId: sdk.ChainID(viper.GetUint(flagSourceChainId)),
+				Number:  viper.GetInt64(flagNumber),
+			})
+			if err != nil {
+				return err
+			}
+			return query(cliCtx, lightclient.QueryValidatorSet, queryData)
+		},
+	}
+
+	cmd.Flags().Uint(flagSourceChainId, 0, "the id of source chain")
+	cmd.Flags().Int64(flagNumber, 0, "the number of the header")
+	return cmd
+}
+
+func query(cliCtx context.CLIContext, path string, queryData []byte) error {
+	bz, err := cliCtx.Query(fmt.Sprintf("custom/%s/%s", lightclient.StoreKey, path), queryData)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(bz))
+	return nil
+}
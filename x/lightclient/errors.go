@@ -0,0 +1,40 @@
+package lightclient
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	DefaultCodespace sdk.CodespaceType = 33
+
+	CodeUnknownClient      sdk.CodeType = 101
+	CodeInvalidCheckpoint  sdk.CodeType = 102
+	CodeInvalidHeader      sdk.CodeType = 103
+	CodeUnknownHeader      sdk.CodeType = 104
+	CodeInvalidProof       sdk.CodeType = 105
+	CodePackageNotVerified sdk.CodeType = 106
+)
+
+func ErrUnknownClient(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeUnknownClient, msg)
+}
+
+func ErrInvalidCheckpoint(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidCheckpoint, msg)
+}
+
+func ErrInvalidHeader(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidHeader, msg)
+}
+
+func ErrUnknownHeader(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeUnknownHeader, msg)
+}
+
+func ErrInvalidProof(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidProof, msg)
+}
+
+func ErrPackageNotVerified(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodePackageNotVerified, msg)
+}
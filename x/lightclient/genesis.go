@@ -0,0 +1,38 @@
+package lightclient
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState is the checkpoints the light clients start from.
+type GenesisState struct {
+	Checkpoints []Checkpoint `json:"checkpoints"`
+}
+
+func DefaultGenesisState() GenesisState {
+	return GenesisState{}
+}
+
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	for _, checkpoint := range data.Checkpoints {
+		if err := keeper.InitClient(ctx, checkpoint); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func ValidateGenesis(data GenesisState) error {
+	chains := make(map[sdk.ChainID]bool)
+	for _, checkpoint := range data.Checkpoints {
+		if chains[checkpoint.ChainId] {
+			return fmt.Errorf("duplicated checkpoint of chain %d", checkpoint.ChainId)
+		}
+		chains[checkpoint.ChainId] = true
+		if err := checkpoint.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package lightclient
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		if !sdk.IsUpgrade(sdk.LightClientVerification) {
+			return sdk.ErrMsgNotSupported("light clients are not enabled yet").Result()
+		}
+		switch msg := msg.(type) {
+		case MsgSubmitHeaders:
+			return handleMsgSubmitHeaders(ctx, k, msg)
+		case MsgSubmitPackageProof:
+			return handleMsgSubmitPackageProof(ctx, k, msg)
+		default:
+			return sdk.ErrUnknownRequest("Unrecognized lightclient msg type").Result()
+		}
+	}
+}
+
+func handleMsgSubmitHeaders(ctx sdk.Context, k Keeper, msg MsgSubmitHeaders) sdk.Result {
+	if err := k.SubmitHeaders(ctx, msg.ChainId, msg.Headers); err != nil {
+		return err.Result()
+	}
+	return sdk.Result{}
+}
+
+func handleMsgSubmitPackageProof(ctx sdk.Context, k Keeper, msg MsgSubmitPackageProof) sdk.Result {
+	packages, err := k.VerifyReceiptProof(ctx, msg.ChainId, msg.Number, msg.TxIndex, msg.Proof)
+	if err != nil {
+		return err.Result()
+	}
+	for _, pack := range packages {
+		k.Logger(ctx).Debug("package proved", "chainId", msg.ChainId, "channel", pack.ChannelId, "sequence", pack.Sequence)
+	}
+	return sdk.Result{}
+}
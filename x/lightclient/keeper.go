@@ -0,0 +1,216 @@
+package lightclient
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/bsc"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/sidechain"
+)
+
+// DefaultWindow is the number of trusted headers kept by a light client, a day of BSC blocks.
+const DefaultWindow = 28800
+
+// Keeper tracks the headers of Parlia source chains from trusted checkpoints, and verifies the receipt
+// proofs of the cross-chain packages sent by their CrossChain contract against the trusted headers.
+type Keeper struct {
+	storeKey  sdk.StoreKey
+	cdc       *codec.Codec
+	codespace sdk.CodespaceType
+
+	sideKeeper sidechain.Keeper
+}
+
+func NewKeeper(cdc *codec.Codec, storeKey sdk.StoreKey, codespace sdk.CodespaceType, sideKeeper sidechain.Keeper) Keeper {
+	return Keeper{
+		storeKey:   storeKey,
+		cdc:        cdc,
+		codespace:  codespace,
+		sideKeeper: sideKeeper,
+	}
+}
+
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", "lightclient")
+}
+
+// InitClient trusts the header of checkpoint and starts the light client of its chain from it, replacing
+// the light client if any.
+func (k Keeper) InitClient(ctx sdk.Context, checkpoint Checkpoint) sdk.Error {
+	if err := checkpoint.Validate(); err != nil {
+		return ErrInvalidCheckpoint(k.codespace, err.Error())
+	}
+	epoch := checkpoint.Epoch
+	if epoch == 0 {
+		epoch = bsc.DefaultEpoch
+	}
+	window := checkpoint.Window
+	if window == 0 {
+		window = DefaultWindow
+	}
+	validators := make([]bsc.Address, len(checkpoint.Validators))
+	copy(validators, checkpoint.Validators)
+	bsc.SortValidators(validators)
+
+	k.deleteClient(ctx, checkpoint.ChainId)
+	hash := checkpoint.Header.Hash()
+	k.setClientState(ctx, ClientState{
+		ChainId:      checkpoint.ChainId,
+		EvmChainId:   checkpoint.EvmChainId,
+		Epoch:        epoch,
+		LatestNumber: checkpoint.Header.Number,
+		LatestHash:   hash,
+		Validators:   validators,
+		Window:       window,
+	})
+	k.setTrustedHeader(ctx, checkpoint.ChainId, TrustedHeader{
+		Number:      checkpoint.Header.Number,
+		Hash:        hash,
+		ParentHash:  checkpoint.Header.ParentHash,
+		ReceiptHash: checkpoint.Header.ReceiptHash,
+	})
+	k.setValidatorSet(ctx, checkpoint.ChainId, ValidatorSet{StartNumber: checkpoint.Header.Number + 1, Validators: validators})
+	k.Logger(ctx).Info("init light client", "chainId", checkpoint.ChainId, "number", checkpoint.Header.Number, "hash", hash.Hex())
+	return nil
+}
+
+// SubmitHeaders extends the trusted headers of a chain with headers, which must follow each other from
+// the latest trusted header. The headers beyond the window of the light client are pruned.
+func (k Keeper) SubmitHeaders(ctx sdk.Context, chainId sdk.ChainID, headers []bsc.Header) sdk.Error {
+	state, found := k.GetClientState(ctx, chainId)
+	if !found {
+		return ErrUnknownClient(k.codespace, fmt.Sprintf("no light client of chain %d", chainId))
+	}
+	for i := range headers {
+		trustedHeader, changed, err := state.update(&headers[i])
+		if err != nil {
+			return ErrInvalidHeader(k.codespace, err.Error())
+		}
+		k.setTrustedHeader(ctx, chainId, trustedHeader)
+		if changed {
+			k.setValidatorSet(ctx, chainId, ValidatorSet{StartNumber: trustedHeader.Number + 1, Validators: state.Validators})
+		}
+		k.pruneHeaders(ctx, chainId, trustedHeader.Number-state.Window+1)
+	}
+	k.setClientState(ctx, state)
+	return nil
+}
+
+// pruneHeaders deletes the trusted header before oldest and the validator sets replaced before it, as headers
+// are trusted one by one the older ones are already pruned.
+func (k Keeper) pruneHeaders(ctx sdk.Context, chainId sdk.ChainID, oldest int64) {
+	if oldest <= 0 {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	key := GetTrustedHeaderKey(chainId, oldest-1)
+	if !store.Has(key) {
+		return
+	}
+	store.Delete(key)
+
+	var stale [][]byte
+	iterator := sdk.KVStorePrefixIterator(store, GetValidatorSetsKey(chainId))
+	for ; iterator.Valid(); iterator.Next() {
+		key := iterator.Key()
+		if startNumber := int64(binary.BigEndian.Uint64(key[len(key)-numberLength:])); startNumber > oldest {
+			break
+		}
+		stale = append(stale, key)
+	}
+	iterator.Close()
+	// the last validator set starting before the oldest header signs it
+	if len(stale) > 1 {
+		for _, key := range stale[:len(stale)-1] {
+			store.Delete(key)
+		}
+	}
+}
+
+func (k Keeper) deleteClient(ctx sdk.Context, chainId sdk.ChainID) {
+	store := ctx.KVStore(k.storeKey)
+	var keys [][]byte
+	for _, prefix := range [][]byte{GetTrustedHeadersKey(chainId), GetValidatorSetsKey(chainId)} {
+		iterator := sdk.KVStorePrefixIterator(store, prefix)
+		for ; iterator.Valid(); iterator.Next() {
+			keys = append(keys, iterator.Key())
+		}
+		iterator.Close()
+	}
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+func (k Keeper) GetClientState(ctx sdk.Context, chainId sdk.ChainID) (ClientState, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(GetClientStateKey(chainId))
+	if bz == nil {
+		return ClientState{}, false
+	}
+	var state ClientState
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &state)
+	return state, true
+}
+
+func (k Keeper) setClientState(ctx sdk.Context, state ClientState) {
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(state)
+	ctx.KVStore(k.storeKey).Set(GetClientStateKey(state.ChainId), bz)
+}
+
+// GetTrustedHeader returns a trusted header of a chain by number.
+func (k Keeper) GetTrustedHeader(ctx sdk.Context, chainId sdk.ChainID, number int64) (TrustedHeader, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(GetTrustedHeaderKey(chainId, number))
+	if bz == nil {
+		return TrustedHeader{}, false
+	}
+	var header TrustedHeader
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &header)
+	return header, true
+}
+
+func (k Keeper) setTrustedHeader(ctx sdk.Context, chainId sdk.ChainID, header TrustedHeader) {
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(header)
+	ctx.KVStore(k.storeKey).Set(GetTrustedHeaderKey(chainId, header.Number), bz)
+}
+
+// GetTrustedHeaders returns the trusted headers of a chain from a number, at most limit of them.
+func (k Keeper) GetTrustedHeaders(ctx sdk.Context, chainId sdk.ChainID, fromNumber int64, limit int) []TrustedHeader {
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(GetTrustedHeaderKey(chainId, fromNumber), sdk.PrefixEndBytes(GetTrustedHeadersKey(chainId)))
+	defer iterator.Close()
+
+	headers := make([]TrustedHeader, 0)
+	for ; iterator.Valid() && len(headers) < limit; iterator.Next() {
+		var header TrustedHeader
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &header)
+		headers = append(headers, header)
+	}
+	return headers
+}
+
+// GetValidatorSet returns the validator set of a chain signing the header of number, if the header is in the
+// window of the light client or follows its latest header.
+func (k Keeper) GetValidatorSet(ctx sdk.Context, chainId sdk.ChainID, number int64) (ValidatorSet, bool) {
+	state, found := k.GetClientState(ctx, chainId)
+	if !found || number > state.LatestNumber+1 {
+		return ValidatorSet{}, false
+	}
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.ReverseIterator(GetValidatorSetsKey(chainId), GetValidatorSetKey(chainId, number+1))
+	defer iterator.Close()
+	if !iterator.Valid() {
+		return ValidatorSet{}, false
+	}
+	var validatorSet ValidatorSet
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &validatorSet)
+	return validatorSet, true
+}
+
+func (k Keeper) setValidatorSet(ctx sdk.Context, chainId sdk.ChainID, validatorSet ValidatorSet) {
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(validatorSet)
+	ctx.KVStore(k.storeKey).Set(GetValidatorSetKey(chainId, validatorSet.StartNumber), bz)
+}
@@ -0,0 +1,412 @@
+package lightclient
+
+import (
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/bsc"
+	"github.com/cosmos/cosmos-sdk/bsc/rlp"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/sidechain"
+)
+
+// a header of BSC mainnet, sealed by 0x72b61c6014342d914470eC7aC2975bE345796c2b
+const mainnetHeaderJson = `{"parentHash":"0xa9c482b74a276389681eabff076b19bef53cae9b5e44f02224e70e3bfc4e9142",
+	"sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347",
+	"miner":"0x72b61c6014342d914470ec7ac2975be345796c2b",
+	"stateRoot":"0xacd5bca0bc33ed07cb35a635fa674e4ce06211ba201500564dd14dcdaf53e5a9",
+	"transactionsRoot":"0xcb374b870584bd587dec2e82af38924a5c0d5765913568434c50448856f97a2c",
+	"receiptsRoot":"0x6f2dc6ade8cf9422d62abd8e8386f20576a6b1f31cc00aa83dba140d183cff16",
+	"logsBloom":"0xfcfef2ce9d78d29fcbfefbff9dfdf3afb23eff54be7efe7ffdb47abcffdff35fff7bf5de80e257fc836eb97fab43eef33dc7b7ffdfb7fcebfb3df6efff7fecfef473d6fe31dcbcebf7ffeff957b6fbbc6d1efb7ebfddbd3bddfeffded78df79ef3ff9ffd4fd67fcfdfdfff3fecdeddefddadf6ef802b5feaf6f4debffbd7ff9b1fcffff73ceb76fddcfd5f73ffff61bed4be7fb59fe77baebb746f4bcefbdebb76df77fdfb8b73bd2ffcf763b33ff7a6cfeefd7e36f6ed275ffa7fff7fbb996ff33bfbdfe76f23bfecf1ffcfceff3fefbd57b5f5dbfd7fde75cffff77ffffa7feefdf7ddef66f7db77fffd47efa6e5bf55f7fef3ebfbbdf3b1fe77f93ffacedf",
+	"difficulty":"0x2",
+	"number":"0x161d4e8",
+	"gasLimit":"0x7355c0c",
+	"gasUsed":"0x2134d60",
+	"timestamp":"0x6378bdd7",
+	"extraData":"0xd883010111846765746888676f312e31392e32856c696e757800000040fc9c67c61ee4a053e5ec524393cb2608e7a1b0de9a91f880095cd7bfc009b8e0ab5de96c2085a484239f38ca437bc805d2df9b88ea2a9a2a1ce9f5ab6005ae3464b17601",
+	"mixHash":"0x0000000000000000000000000000000000000000000000000000000000000000",
+	"nonce":"0x0000000000000000",
+	"hash":"0x8b6eeece6cedbb23038e7e5c2ce647fbdffa04972247d60a7564e81897e8bc30"}`
+
+const (
+	testEvmChainId = 56
+	srcChainID     = sdk.ChainID(0x0001)
+	bscChainID     = sdk.ChainID(0x0038)
+)
+
+func setupKeeper(t *testing.T) (sdk.Context, Keeper) {
+	keyLightClient := sdk.NewKVStoreKey(StoreKey)
+	keySideChain := sdk.NewKVStoreKey("sc")
+	keyParams := sdk.NewKVStoreKey("params")
+	tkeyParams := sdk.NewTransientStoreKey("transient_params")
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyLightClient, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keySideChain, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	cdc := codec.New()
+	pk := params.NewKeeper(cdc, keyParams, tkeyParams)
+	scKeeper := sidechain.NewKeeper(keySideChain, pk.Subspace(sidechain.DefaultParamspace), cdc)
+	scKeeper.SetSrcChainID(srcChainID)
+
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "foochainid"}, sdk.RunTxModeDeliver, log.NewNopLogger())
+	return ctx, NewKeeper(cdc, keyLightClient, DefaultCodespace, scKeeper)
+}
+
+type testValidator struct {
+	priv *btcec.PrivateKey
+	addr bsc.Address
+}
+
+func newTestValidators(n int) []testValidator {
+	validators := make([]testValidator, n)
+	for i := range validators {
+		seed := make([]byte, 32)
+		seed[31] = byte(i + 1)
+		priv, pub := btcec.PrivKeyFromBytes(seed)
+		validators[i].priv = priv
+		copy(validators[i].addr[:], bsc.Keccak256(pub.SerializeUncompressed()[1:])[12:])
+	}
+	return validators
+}
+
+func addresses(validators []testValidator) []bsc.Address {
+	addrs := make([]bsc.Address, len(validators))
+	for i, validator := range validators {
+		addrs[i] = validator.addr
+	}
+	return addrs
+}
+
+// newHeader returns the header after parent sealed by signer with the difficulty of its turn among the active
+// validators, epoch headers carry the validators.
+func newHeader(t *testing.T, parent *bsc.Header, signer testValidator, active, validators []bsc.Address, receiptHash bsc.Hash) *bsc.Header {
+	extra := make([]byte, 32)
+	for _, validator := range validators {
+		extra = append(extra, validator.Bytes()...)
+	}
+	header := &bsc.Header{
+		ParentHash:  parent.Hash(),
+		Coinbase:    signer.addr,
+		ReceiptHash: receiptHash,
+		Difficulty:  bsc.DiffNoTurn,
+		Number:      parent.Number + 1,
+		GasLimit:    parent.GasLimit,
+		Time:        parent.Time + 3,
+		Extra:       append(extra, make([]byte, 65)...),
+	}
+	sorted := make([]bsc.Address, len(active))
+	copy(sorted, active)
+	bsc.SortValidators(sorted)
+	if bsc.InTurnValidator(sorted, header.Number) == signer.addr {
+		header.Difficulty = bsc.DiffInTurn
+	}
+	sealHeader(t, header, signer)
+	return header
+}
+
+func sealHeader(t *testing.T, header *bsc.Header, signer testValidator) {
+	sig, err := ecdsa.SignCompact(signer.priv, bsc.SealHash(header, big.NewInt(testEvmChainId)).Bytes(), false)
+	require.NoError(t, err)
+	// the signature is sealed as R || S || V
+	copy(header.Extra[len(header.Extra)-65:], sig[1:])
+	header.Extra[len(header.Extra)-1] = sig[0] - 27
+}
+
+func sortedAddresses(validators []testValidator) []bsc.Address {
+	addrs := addresses(validators)
+	bsc.SortValidators(addrs)
+	return addrs
+}
+
+func TestInitClientFromMainnetHeader(t *testing.T) {
+	ctx, keeper := setupKeeper(t)
+	var header bsc.Header
+	require.NoError(t, header.UnmarshalJSON([]byte(mainnetHeaderJson)))
+
+	checkpoint := Checkpoint{ChainId: bscChainID, EvmChainId: testEvmChainId, Header: header}
+	require.NotNil(t, keeper.InitClient(ctx, checkpoint))
+
+	checkpoint.Validators = []bsc.Address{header.Coinbase}
+	require.Nil(t, keeper.InitClient(ctx, checkpoint))
+	state, found := keeper.GetClientState(ctx, bscChainID)
+	require.True(t, found)
+	require.EqualValues(t, bsc.DefaultEpoch, state.Epoch)
+	require.EqualValues(t, 0x161d4e8, state.LatestNumber)
+	require.Equal(t, "0x8b6eeece6cedbb23038e7e5c2ce647fbdffa04972247d60a7564e81897e8bc30", state.LatestHash.Hex())
+
+	trusted, found := keeper.GetTrustedHeader(ctx, bscChainID, 0x161d4e8)
+	require.True(t, found)
+	require.Equal(t, header.ReceiptHash, trusted.ReceiptHash)
+	_, found = keeper.GetClientState(ctx, srcChainID)
+	require.False(t, found)
+}
+
+func TestSubmitHeaders(t *testing.T) {
+	ctx, keeper := setupKeeper(t)
+	validators := newTestValidators(5)
+	oldSet, newSet := addresses(validators[:3]), addresses(validators[3:])
+	checkpointHeader := &bsc.Header{Number: 198, GasLimit: 30000000, Extra: make([]byte, 97)}
+	require.Nil(t, keeper.InitClient(ctx, Checkpoint{
+		ChainId:    bscChainID,
+		EvmChainId: testEvmChainId,
+		Epoch:      200,
+		Header:     *checkpointHeader,
+		Validators: oldSet,
+	}))
+
+	// the epoch header 200 hands over to validators 3 and 4 after header 201
+	h199 := newHeader(t, checkpointHeader, validators[1], oldSet, nil, bsc.Hash{})
+	h200 := newHeader(t, h199, validators[2], oldSet, newSet, bsc.Hash{})
+	h201 := newHeader(t, h200, validators[0], oldSet, nil, bsc.Hash{})
+	require.Nil(t, keeper.SubmitHeaders(ctx, bscChainID, []bsc.Header{*h199, *h200}))
+
+	state, _ := keeper.GetClientState(ctx, bscChainID)
+	require.EqualValues(t, 200, state.LatestNumber)
+	require.Equal(t, h200.Hash(), state.LatestHash)
+	require.Equal(t, sortedAddresses(validators[:3]), state.Validators)
+	require.Equal(t, sortedAddresses(validators[3:]), state.PendingValidators)
+	require.EqualValues(t, 201, state.PendingNumber)
+	require.Equal(t, []bsc.Address{validators[2].addr}, state.RecentSigners)
+
+	// headers must follow the latest header and be sealed by its validators
+	require.NotNil(t, keeper.SubmitHeaders(ctx, bscChainID, []bsc.Header{*newHeader(t, h201, validators[0], oldSet, nil, bsc.Hash{})}))
+	require.NotNil(t, keeper.SubmitHeaders(ctx, bscChainID, []bsc.Header{*newHeader(t, h199, validators[0], oldSet, nil, bsc.Hash{})}))
+	require.NotNil(t, keeper.SubmitHeaders(ctx, bscChainID, []bsc.Header{*newHeader(t, h200, validators[3], oldSet, nil, bsc.Hash{})}))
+	require.NotNil(t, keeper.SubmitHeaders(ctx, srcChainID, []bsc.Header{*h201}))
+	// a validator can not seal a header shortly after its last one
+	require.NotNil(t, keeper.SubmitHeaders(ctx, bscChainID, []bsc.Header{*newHeader(t, h200, validators[2], oldSet, nil, bsc.Hash{})}))
+
+	require.Nil(t, keeper.SubmitHeaders(ctx, bscChainID, []bsc.Header{*h201}))
+	state, _ = keeper.GetClientState(ctx, bscChainID)
+	require.Equal(t, sortedAddresses(validators[3:]), state.Validators)
+	require.Nil(t, state.PendingValidators)
+
+	require.NotNil(t, keeper.SubmitHeaders(ctx, bscChainID, []bsc.Header{*newHeader(t, h201, validators[0], newSet, nil, bsc.Hash{})}))
+	h202 := newHeader(t, h201, validators[4], newSet, nil, bsc.Hash{})
+	require.Nil(t, keeper.SubmitHeaders(ctx, bscChainID, []bsc.Header{*h202}))
+
+	trusted, found := keeper.GetTrustedHeader(ctx, bscChainID, 202)
+	require.True(t, found)
+	require.Equal(t, h202.Hash(), trusted.Hash)
+	require.Equal(t, h201.Hash(), trusted.ParentHash)
+	require.Equal(t, validators[4].addr, trusted.Signer)
+
+	// the validator sets are kept by the headers they sign
+	_, found = keeper.GetValidatorSet(ctx, bscChainID, 198)
+	require.False(t, found)
+	validatorSet, found := keeper.GetValidatorSet(ctx, bscChainID, 201)
+	require.True(t, found)
+	require.Equal(t, ValidatorSet{StartNumber: 199, Validators: sortedAddresses(validators[:3])}, validatorSet)
+	validatorSet, found = keeper.GetValidatorSet(ctx, bscChainID, 203)
+	require.True(t, found)
+	require.Equal(t, ValidatorSet{StartNumber: 202, Validators: sortedAddresses(validators[3:])}, validatorSet)
+	_, found = keeper.GetValidatorSet(ctx, bscChainID, 204)
+	require.False(t, found)
+}
+
+func TestSubmitHeadersParliaRules(t *testing.T) {
+	ctx, keeper := setupKeeper(t)
+	validators := newTestValidators(3)
+	active := addresses(validators)
+	checkpointHeader := &bsc.Header{Number: 10, Extra: make([]byte, 97)}
+	require.Nil(t, keeper.InitClient(ctx, Checkpoint{
+		ChainId:    bscChainID,
+		EvmChainId: testEvmChainId,
+		Header:     *checkpointHeader,
+		Validators: active,
+	}))
+
+	// the difficulty must tell the turn of the signer
+	header := newHeader(t, checkpointHeader, validators[0], active, nil, bsc.Hash{})
+	header.Difficulty = bsc.DiffInTurn + bsc.DiffNoTurn - header.Difficulty
+	sealHeader(t, header, validators[0])
+	require.Equal(t, CodeInvalidHeader, keeper.SubmitHeaders(ctx, bscChainID, []bsc.Header{*header}).Code())
+
+	// the signer must be the coinbase
+	header = newHeader(t, checkpointHeader, validators[0], active, nil, bsc.Hash{})
+	header.Coinbase = validators[1].addr
+	sealHeader(t, header, validators[0])
+	require.Equal(t, CodeInvalidHeader, keeper.SubmitHeaders(ctx, bscChainID, []bsc.Header{*header}).Code())
+
+	// the in-turn validator and the others can seal
+	inTurn := bsc.InTurnValidator(sortedAddresses(validators), 11)
+	for _, validator := range validators {
+		header = newHeader(t, checkpointHeader, validator, active, nil, bsc.Hash{})
+		if validator.addr == inTurn {
+			require.EqualValues(t, bsc.DiffInTurn, header.Difficulty)
+		} else {
+			require.EqualValues(t, bsc.DiffNoTurn, header.Difficulty)
+		}
+	}
+	require.Nil(t, keeper.SubmitHeaders(ctx, bscChainID, []bsc.Header{*header}))
+}
+
+func TestPruneHeaders(t *testing.T) {
+	ctx, keeper := setupKeeper(t)
+	validators := newTestValidators(2)
+	checkpointHeader := &bsc.Header{Number: 10, Extra: make([]byte, 97)}
+	require.Nil(t, keeper.InitClient(ctx, Checkpoint{
+		ChainId:    bscChainID,
+		EvmChainId: testEvmChainId,
+		Epoch:      4,
+		Window:     3,
+		Header:     *checkpointHeader,
+		Validators: addresses(validators[:1]),
+	}))
+
+	// the single validator of the epoch header 12 takes over after it
+	oldSet, newSet := addresses(validators[:1]), addresses(validators[1:])
+	h11 := newHeader(t, checkpointHeader, validators[0], oldSet, nil, bsc.Hash{})
+	h12 := newHeader(t, h11, validators[0], oldSet, newSet, bsc.Hash{})
+	h13 := newHeader(t, h12, validators[1], newSet, nil, bsc.Hash{})
+	h14 := newHeader(t, h13, validators[1], newSet, nil, bsc.Hash{})
+	h15 := newHeader(t, h14, validators[1], newSet, nil, bsc.Hash{})
+	require.Nil(t, keeper.SubmitHeaders(ctx, bscChainID, []bsc.Header{*h11, *h12, *h13}))
+	headers := keeper.GetTrustedHeaders(ctx, bscChainID, 0, 10)
+	require.Len(t, headers, 3)
+	require.EqualValues(t, 11, headers[0].Number)
+	validatorSet, found := keeper.GetValidatorSet(ctx, bscChainID, 12)
+	require.True(t, found)
+	require.Equal(t, oldSet, validatorSet.Validators)
+
+	require.Nil(t, keeper.SubmitHeaders(ctx, bscChainID, []bsc.Header{*h14, *h15}))
+	headers = keeper.GetTrustedHeaders(ctx, bscChainID, 0, 10)
+	require.Len(t, headers, 3)
+	require.Equal(t, []int64{13, 14, 15}, []int64{headers[0].Number, headers[1].Number, headers[2].Number})
+	require.Equal(t, h15.Hash(), headers[2].Hash)
+	require.Len(t, keeper.GetTrustedHeaders(ctx, bscChainID, 14, 1), 1)
+
+	// the validator set of the pruned headers is pruned as well
+	_, found = keeper.GetValidatorSet(ctx, bscChainID, 12)
+	require.False(t, found)
+	validatorSet, found = keeper.GetValidatorSet(ctx, bscChainID, 13)
+	require.True(t, found)
+	require.Equal(t, ValidatorSet{StartNumber: 13, Validators: newSet}, validatorSet)
+}
+
+func crossChainPackageLog(chainId sdk.ChainID, channelId sdk.ChannelID, sequence uint64, payload []byte) *bsc.Log {
+	var sequenceTopic, channelTopic bsc.Hash
+	binary.BigEndian.PutUint64(sequenceTopic[24:], sequence)
+	channelTopic[31] = byte(channelId)
+
+	data := make([]byte, 96, 96+len(payload)+32)
+	binary.BigEndian.PutUint16(data[30:], uint16(chainId))
+	data[63] = 64
+	binary.BigEndian.PutUint64(data[88:], uint64(len(payload)))
+	data = append(data, payload...)
+	data = append(data, make([]byte, 32-len(payload)%32)...)
+	return &bsc.Log{
+		Address: CrossChainContractAddr,
+		Topics:  []bsc.Hash{CrossChainPackageEventTopic, {}, sequenceTopic, channelTopic},
+		Data:    data,
+	}
+}
+
+func TestVerifyReceiptProof(t *testing.T) {
+	ctx, keeper := setupKeeper(t)
+	validators := newTestValidators(1)
+	checkpointHeader := &bsc.Header{Number: 10, Extra: make([]byte, 97)}
+	require.Nil(t, keeper.InitClient(ctx, Checkpoint{
+		ChainId:    bscChainID,
+		EvmChainId: testEvmChainId,
+		Header:     *checkpointHeader,
+		Validators: addresses(validators),
+	}))
+
+	payload := []byte("cross chain package payload")
+	otherLog := crossChainPackageLog(srcChainID, 3, 1, payload)
+	otherLog.Address = bsc.Address{0x01}
+	receipt, err := bsc.EncodeReceipt(&bsc.Receipt{
+		PostStateOrStatus: []byte{0x01},
+		CumulativeGasUsed: 50000,
+		Logs: []*bsc.Log{
+			crossChainPackageLog(srcChainID, 2, 5, payload),
+			crossChainPackageLog(sdk.ChainID(0x0002), 2, 6, payload),
+			otherLog,
+		},
+	})
+	require.NoError(t, err)
+
+	// the receipt of the only transaction of the block is the root of the receipts trie, at key 0x80
+	leaf, err := rlp.EncodeToBytes([]interface{}{[]byte{0x20, 0x80}, receipt})
+	require.NoError(t, err)
+	header := newHeader(t, checkpointHeader, validators[0], addresses(validators), nil, bsc.BytesToHash(bsc.Keccak256(leaf)))
+	require.Nil(t, keeper.SubmitHeaders(ctx, bscChainID, []bsc.Header{*header}))
+
+	_, sdkErr := keeper.VerifyReceiptProof(ctx, bscChainID, 12, 0, [][]byte{leaf})
+	require.Equal(t, CodeUnknownHeader, sdkErr.Code())
+	_, sdkErr = keeper.VerifyReceiptProof(ctx, bscChainID, 11, 1, [][]byte{leaf})
+	require.Equal(t, CodeInvalidProof, sdkErr.Code())
+
+	// only the package sent to this chain by the CrossChain contract is proved
+	require.NotNil(t, keeper.VerifyPackage(ctx, bscChainID, 2, 5, payload))
+	packages, sdkErr := keeper.VerifyReceiptProof(ctx, bscChainID, 11, 0, [][]byte{leaf})
+	require.Nil(t, sdkErr)
+	require.Equal(t, []CrossChainPackage{{ChainId: srcChainID, ChannelId: 2, Sequence: 5, Payload: payload}}, packages)
+
+	require.NotNil(t, keeper.VerifyPackage(ctx, bscChainID, 2, 6, payload))
+	require.NotNil(t, keeper.VerifyPackage(ctx, bscChainID, 3, 1, payload))
+	require.NotNil(t, keeper.VerifyPackage(ctx, bscChainID, 2, 5, []byte("forged payload")))
+	require.Nil(t, keeper.VerifyPackage(ctx, bscChainID, 2, 5, payload))
+	// a package is verified once
+	require.NotNil(t, keeper.VerifyPackage(ctx, bscChainID, 2, 5, payload))
+}
+
+func TestQuerier(t *testing.T) {
+	ctx, keeper := setupKeeper(t)
+	validators := newTestValidators(1)
+	active := addresses(validators)
+	checkpointHeader := &bsc.Header{Number: 10, Extra: make([]byte, 97)}
+	require.Nil(t, keeper.InitClient(ctx, Checkpoint{
+		ChainId:    bscChainID,
+		EvmChainId: testEvmChainId,
+		Header:     *checkpointHeader,
+		Validators: active,
+	}))
+	h11 := newHeader(t, checkpointHeader, validators[0], active, nil, bsc.Hash{})
+	h12 := newHeader(t, h11, validators[0], active, nil, bsc.Hash{})
+	require.Nil(t, keeper.SubmitHeaders(ctx, bscChainID, []bsc.Header{*h11, *h12}))
+
+	cdc := codec.New()
+	querier := NewQuerier(keeper, cdc)
+	query := func(path string, params interface{}) []byte {
+		bz, err := querier(ctx, []string{path}, abci.RequestQuery{Data: cdc.MustMarshalJSON(params)})
+		require.Nil(t, err)
+		return bz
+	}
+
+	var state ClientState
+	cdc.MustUnmarshalJSON(query(QueryClientState, bscChainID), &state)
+	require.EqualValues(t, 12, state.LatestNumber)
+	require.Equal(t, h12.Hash(), state.LatestHash)
+
+	var headers []TrustedHeader
+	cdc.MustUnmarshalJSON(query(QueryHeaders, QueryHeadersParams{ChainId: bscChainID, FromNumber: 11, Limit: 1}), &headers)
+	require.Len(t, headers, 1)
+	require.Equal(t, h11.Hash(), headers[0].Hash)
+
+	var validatorSet ValidatorSet
+	cdc.MustUnmarshalJSON(query(QueryValidatorSet, QueryValidatorSetParams{ChainId: bscChainID, Number: 12}), &validatorSet)
+	require.Equal(t, active, validatorSet.Validators)
+
+	_, err := querier(ctx, []string{QueryClientState}, abci.RequestQuery{Data: cdc.MustMarshalJSON(srcChainID)})
+	require.NotNil(t, err)
+	_, err = querier(ctx, []string{QueryValidatorSet}, abci.RequestQuery{
+		Data: cdc.MustMarshalJSON(QueryValidatorSetParams{ChainId: bscChainID, Number: 14})})
+	require.NotNil(t, err)
+}
@@ -0,0 +1,68 @@
+package lightclient
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	StoreKey = "lightclient"
+
+	chainIDLength  = 2
+	numberLength   = 8
+	sequenceLength = 8
+)
+
+var (
+	ClientStateKeyPrefix     = []byte{0x01} // prefix for the client states, by source chain
+	TrustedHeaderKeyPrefix   = []byte{0x02} // prefix for the trusted headers, by source chain and number
+	VerifiedPackageKeyPrefix = []byte{0x03} // prefix for the hashes of the verified packages, by source chain, channel and sequence
+	ValidatorSetKeyPrefix    = []byte{0x04} // prefix for the validator sets, by source chain and first signed header
+)
+
+// GetClientStateKey returns the key of the client state of a source chain: 0x01 | chainId
+func GetClientStateKey(chainId sdk.ChainID) []byte {
+	key := make([]byte, len(ClientStateKeyPrefix)+chainIDLength)
+	copy(key, ClientStateKeyPrefix)
+	binary.BigEndian.PutUint16(key[len(ClientStateKeyPrefix):], uint16(chainId))
+	return key
+}
+
+// GetTrustedHeaderKey returns the key of a trusted header: 0x02 | chainId | number
+func GetTrustedHeaderKey(chainId sdk.ChainID, number int64) []byte {
+	key := make([]byte, len(TrustedHeaderKeyPrefix)+chainIDLength+numberLength)
+	copy(key, TrustedHeaderKeyPrefix)
+	binary.BigEndian.PutUint16(key[len(TrustedHeaderKeyPrefix):], uint16(chainId))
+	binary.BigEndian.PutUint64(key[len(TrustedHeaderKeyPrefix)+chainIDLength:], uint64(number))
+	return key
+}
+
+// GetTrustedHeadersKey returns the prefix of the trusted headers of a source chain: 0x02 | chainId
+func GetTrustedHeadersKey(chainId sdk.ChainID) []byte {
+	return GetTrustedHeaderKey(chainId, 0)[:len(TrustedHeaderKeyPrefix)+chainIDLength]
+}
+
+// GetValidatorSetKey returns the key of a validator set signing from a header: 0x04 | chainId | number
+func GetValidatorSetKey(chainId sdk.ChainID, startNumber int64) []byte {
+	key := make([]byte, len(ValidatorSetKeyPrefix)+chainIDLength+numberLength)
+	copy(key, ValidatorSetKeyPrefix)
+	binary.BigEndian.PutUint16(key[len(ValidatorSetKeyPrefix):], uint16(chainId))
+	binary.BigEndian.PutUint64(key[len(ValidatorSetKeyPrefix)+chainIDLength:], uint64(startNumber))
+	return key
+}
+
+// GetValidatorSetsKey returns the prefix of the validator sets of a source chain: 0x04 | chainId
+func GetValidatorSetsKey(chainId sdk.ChainID) []byte {
+	return GetValidatorSetKey(chainId, 0)[:len(ValidatorSetKeyPrefix)+chainIDLength]
+}
+
+// GetVerifiedPackageKey returns the key of the hash of a verified package: 0x03 | chainId | channelId | sequence
+func GetVerifiedPackageKey(chainId sdk.ChainID, channelId sdk.ChannelID, sequence uint64) []byte {
+	key := make([]byte, len(VerifiedPackageKeyPrefix)+chainIDLength+1+sequenceLength)
+	copy(key, VerifiedPackageKeyPrefix)
+	binary.BigEndian.PutUint16(key[len(VerifiedPackageKeyPrefix):], uint16(chainId))
+	key[len(VerifiedPackageKeyPrefix)+chainIDLength] = byte(channelId)
+	binary.BigEndian.PutUint64(key[len(VerifiedPackageKeyPrefix)+chainIDLength+1:], sequence)
+	return key
+}
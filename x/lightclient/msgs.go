@@ -0,0 +1,125 @@
+package lightclient
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/bsc"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	RouteLightClient = "lightclient"
+
+	TypeMsgSubmitHeaders      = "submit_headers"
+	TypeMsgSubmitPackageProof = "submit_package_proof"
+
+	// maxHeadersPerMsg is the max number of headers submitted in a message
+	maxHeadersPerMsg = 100
+)
+
+var _ sdk.Msg = MsgSubmitHeaders{}
+
+// MsgSubmitHeaders extends the trusted headers of a source chain, anyone can relay the headers as they are
+// verified against the validator set of the chain.
+type MsgSubmitHeaders struct {
+	Submitter sdk.AccAddress `json:"submitter"`
+	ChainId   sdk.ChainID    `json:"chain_id"`
+	Headers   []bsc.Header   `json:"headers"`
+}
+
+func NewMsgSubmitHeaders(submitter sdk.AccAddress, chainId sdk.ChainID, headers []bsc.Header) MsgSubmitHeaders {
+	return MsgSubmitHeaders{
+		Submitter: submitter,
+		ChainId:   chainId,
+		Headers:   headers,
+	}
+}
+
+// nolint
+func (msg MsgSubmitHeaders) Route() string { return RouteLightClient }
+func (msg MsgSubmitHeaders) Type() string  { return TypeMsgSubmitHeaders }
+func (msg MsgSubmitHeaders) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Submitter}
+}
+
+func (msg MsgSubmitHeaders) GetSignBytes() []byte {
+	b, err := MsgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+func (msg MsgSubmitHeaders) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
+
+func (msg MsgSubmitHeaders) ValidateBasic() sdk.Error {
+	if len(msg.Submitter) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected submitter address length is %d, actual length is %d", sdk.AddrLen, len(msg.Submitter)))
+	}
+	if len(msg.Headers) == 0 || len(msg.Headers) > maxHeadersPerMsg {
+		return ErrInvalidHeader(DefaultCodespace, fmt.Sprintf("the number of headers should be between 1 and %d", maxHeadersPerMsg))
+	}
+	for i := range msg.Headers {
+		if _, err := msg.Headers[i].GetSignature(); err != nil {
+			return ErrInvalidHeader(DefaultCodespace, err.Error())
+		}
+	}
+	return nil
+}
+
+var _ sdk.Msg = MsgSubmitPackageProof{}
+
+// MsgSubmitPackageProof proves the packages sent by a transaction of a source chain with the merkle proof of
+// its receipt in a trusted header.
+type MsgSubmitPackageProof struct {
+	Submitter sdk.AccAddress `json:"submitter"`
+	ChainId   sdk.ChainID    `json:"chain_id"`
+	Number    int64          `json:"number"`
+	TxIndex   uint64         `json:"tx_index"`
+	Proof     [][]byte       `json:"proof"`
+}
+
+func NewMsgSubmitPackageProof(submitter sdk.AccAddress, chainId sdk.ChainID, number int64, txIndex uint64,
+	proof [][]byte) MsgSubmitPackageProof {
+	return MsgSubmitPackageProof{
+		Submitter: submitter,
+		ChainId:   chainId,
+		Number:    number,
+		TxIndex:   txIndex,
+		Proof:     proof,
+	}
+}
+
+// nolint
+func (msg MsgSubmitPackageProof) Route() string { return RouteLightClient }
+func (msg MsgSubmitPackageProof) Type() string  { return TypeMsgSubmitPackageProof }
+func (msg MsgSubmitPackageProof) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Submitter}
+}
+
+func (msg MsgSubmitPackageProof) GetSignBytes() []byte {
+	b, err := MsgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+func (msg MsgSubmitPackageProof) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
+
+func (msg MsgSubmitPackageProof) ValidateBasic() sdk.Error {
+	if len(msg.Submitter) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected submitter address length is %d, actual length is %d", sdk.AddrLen, len(msg.Submitter)))
+	}
+	if msg.Number <= 0 {
+		return ErrInvalidProof(DefaultCodespace, "the number of header should be positive")
+	}
+	if len(msg.Proof) == 0 {
+		return ErrInvalidProof(DefaultCodespace, "the proof should not be empty")
+	}
+	return nil
+}
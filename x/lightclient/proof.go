@@ -0,0 +1,123 @@
+package lightclient
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/cosmos/cosmos-sdk/bsc"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// VerifierName is the name of the package verifier of the light clients, for the channels of the oracle
+// which require the packages to be proved.
+const VerifierName = "lightclient"
+
+var (
+	// CrossChainContractAddr is the address of the system contract of BSC sending the cross-chain packages,
+	// 0x0000000000000000000000000000000000002000
+	CrossChainContractAddr = bsc.Address{18: 0x20}
+
+	// CrossChainPackageEventTopic is the topic of the crossChainPackage event emitted for each package:
+	// crossChainPackage(uint16 chainId, uint64 indexed oracleSequence, uint64 indexed packageSequence,
+	// uint8 indexed channelId, bytes payload)
+	CrossChainPackageEventTopic = bsc.BytesToHash(bsc.Keccak256([]byte("crossChainPackage(uint16,uint64,uint64,uint8,bytes)")))
+)
+
+// CrossChainPackage is a package sent to the chain by the CrossChain contract of a source chain.
+type CrossChainPackage struct {
+	ChainId   sdk.ChainID   `json:"chain_id"`
+	ChannelId sdk.ChannelID `json:"channel_id"`
+	Sequence  uint64        `json:"sequence"`
+	Payload   []byte        `json:"payload"`
+}
+
+// VerifyReceiptProof verifies the proof of the receipt of the transaction at txIndex in a trusted header of
+// a chain, and records the hashes of the cross-chain packages sent by the transaction as verified.
+func (k Keeper) VerifyReceiptProof(ctx sdk.Context, chainId sdk.ChainID, number int64, txIndex uint64,
+	proof [][]byte) ([]CrossChainPackage, sdk.Error) {
+	header, found := k.GetTrustedHeader(ctx, chainId, number)
+	if !found {
+		return nil, ErrUnknownHeader(k.codespace, fmt.Sprintf("header %d of chain %d is not trusted", number, chainId))
+	}
+	value, err := bsc.VerifyProof(header.ReceiptHash, bsc.ReceiptKey(txIndex), proof)
+	if err != nil {
+		return nil, ErrInvalidProof(k.codespace, err.Error())
+	}
+	if value == nil {
+		return nil, ErrInvalidProof(k.codespace, fmt.Sprintf("no receipt of transaction %d in header %d", txIndex, number))
+	}
+	receipt, err := bsc.DecodeReceipt(value)
+	if err != nil {
+		return nil, ErrInvalidProof(k.codespace, fmt.Sprintf("invalid receipt, %v", err))
+	}
+	if !receipt.Successful() {
+		return nil, ErrInvalidProof(k.codespace, fmt.Sprintf("transaction %d of header %d failed", txIndex, number))
+	}
+
+	packages := make([]CrossChainPackage, 0)
+	srcChainId := k.sideKeeper.GetSrcChainID()
+	for _, log := range receipt.Logs {
+		pack, ok, err := decodeCrossChainPackage(log)
+		if err != nil {
+			return nil, ErrInvalidProof(k.codespace, err.Error())
+		}
+		// only the packages sent to this chain are recorded
+		if !ok || pack.ChainId != srcChainId {
+			continue
+		}
+		ctx.KVStore(k.storeKey).Set(GetVerifiedPackageKey(chainId, pack.ChannelId, pack.Sequence), bsc.Keccak256(pack.Payload))
+		packages = append(packages, pack)
+	}
+	if len(packages) == 0 {
+		return nil, ErrInvalidProof(k.codespace, fmt.Sprintf("no package in transaction %d of header %d", txIndex, number))
+	}
+	return packages, nil
+}
+
+// VerifyPackage checks that a package received from a chain was proved to be sent by its CrossChain contract,
+// the record of the proof is deleted as a package is received once.
+func (k Keeper) VerifyPackage(ctx sdk.Context, chainId sdk.ChainID, channelId sdk.ChannelID, sequence uint64, payload []byte) sdk.Error {
+	store := ctx.KVStore(k.storeKey)
+	key := GetVerifiedPackageKey(chainId, channelId, sequence)
+	hash := store.Get(key)
+	if hash == nil {
+		return ErrPackageNotVerified(k.codespace, fmt.Sprintf("package %d of channel %d is not proved", sequence, channelId))
+	}
+	if !bytes.Equal(hash, bsc.Keccak256(payload)) {
+		return ErrPackageNotVerified(k.codespace, fmt.Sprintf("package %d of channel %d does not match its proof", sequence, channelId))
+	}
+	store.Delete(key)
+	return nil
+}
+
+// decodeCrossChainPackage decodes the package of a crossChainPackage event of the CrossChain contract, it
+// returns false for the other logs.
+func decodeCrossChainPackage(log *bsc.Log) (CrossChainPackage, bool, error) {
+	if log.Address != CrossChainContractAddr || len(log.Topics) == 0 || log.Topics[0] != CrossChainPackageEventTopic {
+		return CrossChainPackage{}, false, nil
+	}
+	if len(log.Topics) != 4 {
+		return CrossChainPackage{}, false, fmt.Errorf("invalid topics of crossChainPackage event")
+	}
+	// the data is the abi encoding of (uint16 chainId, bytes payload)
+	data := log.Data
+	if len(data) < 3*32 {
+		return CrossChainPackage{}, false, fmt.Errorf("invalid data of crossChainPackage event")
+	}
+	offset := new(big.Int).SetBytes(data[32:64])
+	if !offset.IsUint64() || offset.Uint64() != 64 {
+		return CrossChainPackage{}, false, fmt.Errorf("invalid payload offset of crossChainPackage event")
+	}
+	length := new(big.Int).SetBytes(data[64:96])
+	if !length.IsUint64() || length.Uint64() > uint64(len(data)-96) {
+		return CrossChainPackage{}, false, fmt.Errorf("invalid payload length of crossChainPackage event")
+	}
+	return CrossChainPackage{
+		ChainId:   sdk.ChainID(binary.BigEndian.Uint16(data[30:32])),
+		ChannelId: sdk.ChannelID(log.Topics[3][31]),
+		Sequence:  binary.BigEndian.Uint64(log.Topics[2][24:]),
+		Payload:   data[96 : 96+length.Uint64()],
+	}, true, nil
+}
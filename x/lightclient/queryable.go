@@ -0,0 +1,96 @@
+package lightclient
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	QueryClientState  = "client-state"
+	QueryHeaders      = "headers"
+	QueryValidatorSet = "validator-set"
+
+	// at most maxHeadersPerQuery headers are returned by a headers query
+	maxHeadersPerQuery = 100
+)
+
+// QueryHeadersParams selects the trusted headers of a chain from FromNumber.
+type QueryHeadersParams struct {
+	ChainId    sdk.ChainID `json:"chain_id"`
+	FromNumber int64       `json:"from_number"`
+	Limit      int         `json:"limit"`
+}
+
+// QueryValidatorSetParams selects the validator set of a chain signing the header of Number.
+type QueryValidatorSetParams struct {
+	ChainId sdk.ChainID `json:"chain_id"`
+	Number  int64       `json:"number"`
+}
+
+// creates a querier for lightclient REST endpoints
+func NewQuerier(k Keeper, cdc *codec.Codec) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		if len(path) == 0 {
+			return nil, sdk.ErrUnknownRequest("unknown lightclient query endpoint")
+		}
+		switch path[0] {
+		case QueryClientState:
+			var chainId sdk.ChainID
+			if err := cdc.UnmarshalJSON(req.Data, &chainId); err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+			}
+			return queryClientState(ctx, k, cdc, chainId)
+		case QueryHeaders:
+			var params QueryHeadersParams
+			if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+			}
+			return queryHeaders(ctx, k, cdc, params)
+		case QueryValidatorSet:
+			var params QueryValidatorSetParams
+			if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+			}
+			return queryValidatorSet(ctx, k, cdc, params)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown lightclient query endpoint")
+		}
+	}
+}
+
+func queryClientState(ctx sdk.Context, k Keeper, cdc *codec.Codec, chainId sdk.ChainID) ([]byte, sdk.Error) {
+	state, found := k.GetClientState(ctx, chainId)
+	if !found {
+		return nil, ErrUnknownClient(k.codespace, "no light client of the chain")
+	}
+	return marshalResult(cdc, state)
+}
+
+func queryHeaders(ctx sdk.Context, k Keeper, cdc *codec.Codec, params QueryHeadersParams) ([]byte, sdk.Error) {
+	if _, found := k.GetClientState(ctx, params.ChainId); !found {
+		return nil, ErrUnknownClient(k.codespace, "no light client of the chain")
+	}
+	limit := params.Limit
+	if limit <= 0 || limit > maxHeadersPerQuery {
+		limit = maxHeadersPerQuery
+	}
+	return marshalResult(cdc, k.GetTrustedHeaders(ctx, params.ChainId, params.FromNumber, limit))
+}
+
+func queryValidatorSet(ctx sdk.Context, k Keeper, cdc *codec.Codec, params QueryValidatorSetParams) ([]byte, sdk.Error) {
+	validatorSet, found := k.GetValidatorSet(ctx, params.ChainId, params.Number)
+	if !found {
+		return nil, ErrUnknownHeader(k.codespace, "the validator set of the header is unknown")
+	}
+	return marshalResult(cdc, validatorSet)
+}
+
+func marshalResult(cdc *codec.Codec, result interface{}) ([]byte, sdk.Error) {
+	res, err := codec.MarshalJSONIndent(cdc, result)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return res, nil
+}
@@ -0,0 +1,143 @@
+package lightclient
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/cosmos/cosmos-sdk/bsc"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ClientState is the trusted state of the light client of a Parlia source chain. Validators sign the
+// header following the latest one, PendingValidators is the validator set of the last epoch header
+// which replaces them once the header PendingNumber is trusted. RecentSigners are the signers of the
+// latest headers, which can not sign the next one. The latest Window headers are kept.
+type ClientState struct {
+	ChainId           sdk.ChainID   `json:"chain_id"`
+	EvmChainId        int64         `json:"evm_chain_id"`
+	Epoch             int64         `json:"epoch"`
+	LatestNumber      int64         `json:"latest_number"`
+	LatestHash        bsc.Hash      `json:"latest_hash"`
+	Validators        []bsc.Address `json:"validators"`
+	PendingValidators []bsc.Address `json:"pending_validators"`
+	PendingNumber     int64         `json:"pending_number"`
+	RecentSigners     []bsc.Address `json:"recent_signers"`
+	Window            int64         `json:"window"`
+}
+
+// TrustedHeader is the part of a trusted header needed to verify the proofs against it.
+type TrustedHeader struct {
+	Number      int64       `json:"number"`
+	Hash        bsc.Hash    `json:"hash"`
+	ParentHash  bsc.Hash    `json:"parent_hash"`
+	ReceiptHash bsc.Hash    `json:"receipt_hash"`
+	Signer      bsc.Address `json:"signer"`
+}
+
+// ValidatorSet is the validator set of a chain which signs the headers from StartNumber.
+type ValidatorSet struct {
+	StartNumber int64         `json:"start_number"`
+	Validators  []bsc.Address `json:"validators"`
+}
+
+// Checkpoint is the header a light client starts from with the validator set signing the header after it.
+// Epoch and Window are set to their defaults if 0.
+type Checkpoint struct {
+	ChainId    sdk.ChainID   `json:"chain_id"`
+	EvmChainId int64         `json:"evm_chain_id"`
+	Epoch      int64         `json:"epoch"`
+	Window     int64         `json:"window"`
+	Header     bsc.Header    `json:"header"`
+	Validators []bsc.Address `json:"validators"`
+}
+
+func (c Checkpoint) Validate() error {
+	if c.EvmChainId <= 0 {
+		return fmt.Errorf("the evm chain id should be positive")
+	}
+	if c.Epoch < 0 {
+		return fmt.Errorf("the epoch should not be negative")
+	}
+	if c.Window < 0 {
+		return fmt.Errorf("the window should not be negative")
+	}
+	if len(c.Validators) == 0 {
+		return fmt.Errorf("the validator set should not be empty")
+	}
+	return nil
+}
+
+// update verifies that header follows the latest trusted header and is sealed by a validator as Parlia
+// rules, and makes it the latest trusted header. It tells if the validator set changes after the header.
+func (s *ClientState) update(header *bsc.Header) (TrustedHeader, bool, error) {
+	if header.Number != s.LatestNumber+1 {
+		return TrustedHeader{}, false, fmt.Errorf("header %d does not follow the latest header %d", header.Number, s.LatestNumber)
+	}
+	if header.ParentHash != s.LatestHash {
+		return TrustedHeader{}, false, fmt.Errorf("the parent hash of header %d is not the hash of the latest header", header.Number)
+	}
+	signer, err := header.ExtractSignerFromHeader(big.NewInt(s.EvmChainId))
+	if err != nil {
+		return TrustedHeader{}, false, fmt.Errorf("failed to extract the signer of header %d, %v", header.Number, err)
+	}
+	if signer != header.Coinbase {
+		return TrustedHeader{}, false, fmt.Errorf("the signer %s of header %d is not its coinbase", signer.String(), header.Number)
+	}
+	if !s.isValidator(signer) {
+		return TrustedHeader{}, false, fmt.Errorf("the signer %s of header %d is not a validator", signer.String(), header.Number)
+	}
+	for _, recent := range s.RecentSigners {
+		if recent == signer {
+			return TrustedHeader{}, false, fmt.Errorf("the signer %s of header %d signed recently", signer.String(), header.Number)
+		}
+	}
+	difficulty := int64(bsc.DiffNoTurn)
+	if bsc.InTurnValidator(s.Validators, header.Number) == signer {
+		difficulty = bsc.DiffInTurn
+	}
+	if header.Difficulty != difficulty {
+		return TrustedHeader{}, false, fmt.Errorf("the difficulty of header %d is %d, expected %d", header.Number, header.Difficulty, difficulty)
+	}
+
+	// the validator set of an epoch header takes over after half of the current validators sealed a block,
+	// as Parlia does
+	if header.Number%s.Epoch == 0 {
+		validators, err := header.ParseValidators()
+		if err != nil {
+			return TrustedHeader{}, false, fmt.Errorf("invalid epoch header %d, %v", header.Number, err)
+		}
+		bsc.SortValidators(validators)
+		s.PendingValidators = validators
+		s.PendingNumber = header.Number + int64(len(s.Validators)/2)
+	}
+	s.RecentSigners = append(s.RecentSigners, signer)
+	changed := false
+	if len(s.PendingValidators) != 0 && header.Number == s.PendingNumber {
+		s.Validators, s.PendingValidators, s.PendingNumber = s.PendingValidators, nil, 0
+		changed = true
+	}
+	// a validator signs at most one of any len(Validators)/2+1 consecutive headers
+	if limit := len(s.Validators) / 2; len(s.RecentSigners) > limit {
+		s.RecentSigners = s.RecentSigners[len(s.RecentSigners)-limit:]
+	}
+
+	hash := header.Hash()
+	s.LatestNumber = header.Number
+	s.LatestHash = hash
+	return TrustedHeader{
+		Number:      header.Number,
+		Hash:        hash,
+		ParentHash:  header.ParentHash,
+		ReceiptHash: header.ReceiptHash,
+		Signer:      signer,
+	}, changed, nil
+}
+
+func (s *ClientState) isValidator(addr bsc.Address) bool {
+	for _, validator := range s.Validators {
+		if validator == addr {
+			return true
+		}
+	}
+	return false
+}
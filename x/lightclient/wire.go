@@ -0,0 +1,20 @@
+package lightclient
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// Register concrete types on codec codec
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgSubmitHeaders{}, "lightclient/MsgSubmitHeaders", nil)
+	cdc.RegisterConcrete(MsgSubmitPackageProof{}, "lightclient/MsgSubmitPackageProof", nil)
+}
+
+// generic sealed codec to be used throughout sdk
+var MsgCdc *codec.Codec
+
+func init() {
+	cdc := codec.New()
+	RegisterCodec(cdc)
+	MsgCdc = cdc.Seal()
+}
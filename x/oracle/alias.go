@@ -10,6 +10,10 @@ const (
 	SuccessStatusText = types.SuccessStatusText
 	FailedStatusText  = types.FailedStatusText
 	DefaultParamSpace = keeper.DefaultParamSpace
+	QueryProphecies   = types.QueryProphecies
+	QueryClaim        = types.QueryClaim
+	QuerySequence     = types.QuerySequence
+	QueryRelayers     = types.QueryRelayers
 )
 
 var (
@@ -26,17 +30,20 @@ var (
 	ErrInvalidClaim                  = types.ErrInvalidClaim
 	ErrInvalidValidator              = types.ErrInvalidValidator
 	ErrInternalDB                    = types.ErrInternalDB
+	ErrInvalidSideChainId            = types.ErrInvalidSideChainId
 
-	NewProphecy = types.NewProphecy
-	NewStatus   = types.NewStatus
+	NewProphecy       = types.NewProphecy
+	NewProphecyRecord = types.NewProphecyRecord
+	NewStatus         = types.NewStatus
 
 	// variable aliases
 	StatusTextToString = types.StatusTextToString
 	StringToStatusText = types.StringToStatusText
 
-	NewClaimMsg = types.NewClaimMsg
-	RouteOracle = types.RouteOracle
-	GetClaimId  = types.GetClaimId
+	NewClaimMsg  = types.NewClaimMsg
+	RouteOracle  = types.RouteOracle
+	GetClaimId   = types.GetClaimId
+	ParseClaimId = types.ParseClaimId
 )
 
 type (
@@ -47,5 +54,18 @@ type (
 	Status     = types.Status
 	StatusText = types.StatusText
 
+	ProphecyInfo   = types.ProphecyInfo
+	ProphecyRecord = types.ProphecyRecord
+	ValidatorClaim = types.ValidatorClaim
+	ClaimTally     = types.ClaimTally
+
+	QuerySequenceParams = types.QuerySequenceParams
+	ClaimStatus         = types.ClaimStatus
+	PayloadTally        = types.PayloadTally
+	SequenceInfo        = types.SequenceInfo
+
 	ClaimMsg = types.ClaimMsg
+
+	ChannelVerifier = types.ChannelVerifier
+	PackageVerifier = types.PackageVerifier
 )
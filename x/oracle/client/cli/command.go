@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/tendermint/go-amino"
+
+	"github.com/cosmos/cosmos-sdk/client"
+)
+
+const (
+	flagSideChainId = "side-chain-id"
+	flagChannelId   = "channel-id"
+)
+
+func AddCommands(cmd *cobra.Command, cdc *amino.Codec) {
+	oracleCmd := &cobra.Command{
+		Use:   "oracle",
+		Short: "oracle commands",
+	}
+	oracleCmd.AddCommand(
+		client.GetCommands(
+			ShowPropheciesCmd(cdc),
+			ShowClaimCmd(cdc),
+			ShowSequenceCmd(cdc),
+			ShowRelayersCmd(cdc))...)
+	cmd.AddCommand(oracleCmd)
+}
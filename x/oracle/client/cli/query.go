@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/tendermint/go-amino"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+func ShowPropheciesCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show-prophecies",
+		Short: "Show pending and stale prophecies of side chain",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			sideChainId := viper.GetString(flagSideChainId)
+			if sideChainId == "" {
+				return fmt.Errorf("missing side-chain-id")
+			}
+
+			queryData, err := cdc.MarshalJSON(sideChainId)
+			if err != nil {
+				return err
+			}
+
+			bz, err := cliCtx.Query(fmt.Sprintf("custom/oracle/%s", types.QueryProphecies), queryData)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bz))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagSideChainId, "", "the id of side chain")
+	return cmd
+}
+
+func ShowClaimCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show-claim [claim-id]",
+		Short: "Show the claims of a prophecy and the power behind each payload",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			queryData, err := cdc.MarshalJSON(args[0])
+			if err != nil {
+				return err
+			}
+
+			bz, err := cliCtx.Query(fmt.Sprintf("custom/oracle/%s", types.QueryClaim), queryData)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bz))
+			return nil
+		},
+	}
+	return cmd
+}
+
+func ShowSequenceCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show-sequence",
+		Short: "Show the next sequence expected by a channel of side chain",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			sideChainId := viper.GetString(flagSideChainId)
+			if sideChainId == "" {
+				return fmt.Errorf("missing side-chain-id")
+			}
+
+			queryData, err := cdc.MarshalJSON(types.QuerySequenceParams{
+				SideChainId: sideChainId,
+				ChannelId:   sdk.ChannelID(viper.GetUint(flagChannelId)),
+			})
+			if err != nil {
+				return err
+			}
+
+			bz, err := cliCtx.Query(fmt.Sprintf("custom/oracle/%s", types.QuerySequence), queryData)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bz))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagSideChainId, "", "the id of side chain")
+	cmd.Flags().Uint(flagChannelId, uint(types.RelayPackagesChannelId), "the id of channel")
+	return cmd
+}
+
+func ShowRelayersCmd(cdc *amino.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show-relayers",
+		Short: "Show the relayers allowed to claim and their power",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bz, err := cliCtx.Query(fmt.Sprintf("custom/oracle/%s", types.QueryRelayers), nil)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bz))
+			return nil
+		},
+	}
+	return cmd
+}
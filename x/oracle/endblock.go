@@ -0,0 +1,9 @@
+package oracle
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func EndBlocker(ctx sdk.Context, keeper Keeper) {
+	keeper.SweepExpiredProphecies(ctx)
+}
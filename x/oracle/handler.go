@@ -92,6 +92,12 @@ func handlePackage(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, pa
 		return sdk.Event{}, types.ErrInvalidSequence(fmt.Sprintf("current sequence of channel %d is %d", pack.ChannelId, sequence))
 	}
 
+	if sdk.IsUpgrade(sdk.LightClientVerification) {
+		if sdkErr := oracleKeeper.VerifyPackage(ctx, chainId, pack); sdkErr != nil {
+			return sdk.Event{}, sdkErr
+		}
+	}
+
 	packageType, relayFee, err := sTypes.DecodePackageHeader(pack.Payload)
 	if err != nil {
 		return sdk.Event{}, types.ErrInvalidPayloadHeader(err.Error())
@@ -140,6 +146,14 @@ func handlePackage(ctx sdk.Context, oracleKeeper Keeper, chainId sdk.ChainID, pa
 		}
 	}
 
+	if recorder, ok := crossChainApp.(sdk.CrossChainPackageRecorder); ok {
+		recorder.RecordPackage(ctx, packageType, pack.Sequence, pack.Payload[sTypes.PackageHeaderLength:], crash, result)
+	}
+
+	if packageType == sdk.AckCrossChainPackageType || packageType == sdk.FailAckCrossChainPackageType {
+		oracleKeeper.IbcKeeper.AcknowledgePackage(ctx, chainId, pack.ChannelId)
+	}
+
 	// write ack package
 	var sendSequence int64 = -1
 	if packageType == sdk.SynCrossChainPackageType {
@@ -0,0 +1,237 @@
+package keeper
+
+import (
+	"bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+// StoreMigrations returns the migrations of the oracle store, the app registers them to the multistore.
+func (k Keeper) StoreMigrations() []sdk.StoreMigration {
+	return []sdk.StoreMigration{
+		{StoreKey: k.storeKey.Name(), From: 0, To: 1, Upgrade: sdk.ProphecyClaimStore, Migrate: k.MigrateProphecies},
+	}
+}
+
+// MigrateProphecies moves the prophecies saved as DBProphecy under their claim ids to a record and one claim
+// entry per validator under the prefix of the prophecy, tallies the claims with the current power of the
+// relayers and deletes the DBProphecy entries.
+func (k Keeper) MigrateProphecies(ctx sdk.Context) error {
+	store := ctx.KVStore(k.storeKey)
+	var keys, values [][]byte
+	iterator := store.Iterator(nil, nil)
+	for ; iterator.Valid(); iterator.Next() {
+		if types.IsLegacyProphecyKey(iterator.Key()) {
+			keys = append(keys, iterator.Key())
+			values = append(values, iterator.Value())
+		}
+	}
+	iterator.Close()
+
+	powers := k.stakeKeeper.GetOracleRelayersPower(ctx)
+	for i, key := range keys {
+		var dbProphecy types.DBProphecy
+		if err := k.cdc.UnmarshalBinaryBare(values[i], &dbProphecy); err != nil {
+			return err
+		}
+		prophecy, err := dbProphecy.DeserializeFromDB()
+		if err != nil {
+			return err
+		}
+
+		for validator, payload := range prophecy.ValidatorClaims {
+			valAddr, err := sdk.ValAddressFromBech32(validator)
+			if err != nil {
+				return err
+			}
+			k.setValidatorClaim(ctx, prophecy.ID, valAddr, types.ValidatorClaim{Payload: payload})
+		}
+		record := types.NewProphecyRecord(prophecy.ID)
+		record.Status = prophecy.Status
+		k.weighClaimTallies(ctx, &record, powers)
+		k.setProphecyRecord(ctx, record)
+		store.Delete(key)
+	}
+	ctx.Logger().With("module", "oracle").Info("migrated prophecies", "count", len(keys))
+	return nil
+}
+
+// processOrderedClaim adds a claim to the ordered claim storage. The returned prophecy only has the id and
+// the status, GetProphecy loads the claims.
+func (k Keeper) processOrderedClaim(ctx sdk.Context, claim types.Claim) (types.Prophecy, bool, sdk.Error) {
+	record, found := k.getProphecyRecord(ctx, claim.ID)
+	if !found {
+		record = types.NewProphecyRecord(claim.ID)
+	}
+	if record.Status.Text != types.PendingStatusText {
+		return types.Prophecy{}, found, types.ErrProphecyFinalized()
+	}
+
+	powers := k.stakeKeeper.GetOracleRelayersPower(ctx)
+	if !bytes.Equal(record.RelayersHash, types.RelayersPowerHash(powers)) {
+		// the power of the relayers changed since the tallies were weighed
+		k.weighClaimTallies(ctx, &record, powers)
+	}
+
+	power := powers[claim.ValidatorAddress.String()]
+	if previous, ok := k.getValidatorClaim(ctx, claim.ID, claim.ValidatorAddress); ok {
+		k.addClaimTally(ctx, &record, previous.Payload, -power, -1)
+	}
+	k.setValidatorClaim(ctx, claim.ID, claim.ValidatorAddress, types.ValidatorClaim{Payload: claim.Payload})
+	k.addClaimTally(ctx, &record, claim.Payload, power, 1)
+
+	totalPower := types.TotalRelayersPower(ctx, k.stakeKeeper, powers)
+	record.Status = k.completionStatus(ctx, record.Status, record.HighestClaim, record.HighestClaimPower, record.TotalClaimsPower, totalPower)
+	k.setProphecyRecord(ctx, record)
+
+	return types.Prophecy{ID: record.ID, Status: record.Status}, found, nil
+}
+
+// weighClaimTallies replaces the tallies of a prophecy with the tallies of its claims weighed with powers,
+// the claims of validators which are not relayers anymore count without power like in FindHighestClaim.
+func (k Keeper) weighClaimTallies(ctx sdk.Context, record *types.ProphecyRecord, powers map[string]int64) {
+	store := ctx.KVStore(k.storeKey)
+	var keys [][]byte
+	iterator := sdk.KVStorePrefixIterator(store, types.GetClaimTalliesKey(record.ID))
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+	for _, key := range keys {
+		store.Delete(key)
+	}
+
+	prophecy := k.loadValidatorClaims(ctx, types.NewProphecy(record.ID))
+	record.TotalClaimsPower = 0
+	for payload, power := range prophecy.ClaimPowers(powers) {
+		tally := types.ClaimTally{Payload: payload, Power: power, Validators: int64(len(prophecy.ClaimValidators[payload]))}
+		k.setClaimTally(ctx, record.ID, tally)
+		record.TotalClaimsPower += power
+	}
+	record.HighestClaim, record.HighestClaimPower = k.findHighestClaimTally(ctx, record.ID)
+	record.RelayersHash = types.RelayersPowerHash(powers)
+}
+
+// addClaimTally adds (sign 1) or removes (sign -1) the claim of a validator with its power to the tally of
+// its payload and keeps the highest claim of the record up to date. Tallies are only scanned when the highest
+// one decreases.
+func (k Keeper) addClaimTally(ctx sdk.Context, record *types.ProphecyRecord, payload string, power int64, sign int64) {
+	tally, _ := k.getClaimTally(ctx, record.ID, payload)
+	tally.Payload = payload
+	tally.Power += power
+	tally.Validators += sign
+	if tally.Validators > 0 {
+		k.setClaimTally(ctx, record.ID, tally)
+	} else {
+		ctx.KVStore(k.storeKey).Delete(types.GetClaimTallyKey(record.ID, payload))
+	}
+	record.TotalClaimsPower += power
+
+	switch {
+	case record.HighestClaim == payload && sign < 0:
+		record.HighestClaim, record.HighestClaimPower = k.findHighestClaimTally(ctx, record.ID)
+	case record.HighestClaim == payload:
+		record.HighestClaimPower = tally.Power
+	case record.HighestClaim == "" || tally.Power > record.HighestClaimPower:
+		record.HighestClaim, record.HighestClaimPower = tally.Payload, tally.Power
+	}
+}
+
+// findHighestClaimTally returns the payload with the most power, the first one in the order of the tally keys
+// if several payloads have the same power.
+func (k Keeper) findHighestClaimTally(ctx sdk.Context, id string) (string, int64) {
+	highestClaim, highestClaimPower := "", int64(0)
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.GetClaimTalliesKey(id))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var tally types.ClaimTally
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &tally)
+		if highestClaim == "" || tally.Power > highestClaimPower {
+			highestClaim, highestClaimPower = tally.Payload, tally.Power
+		}
+	}
+	return highestClaim, highestClaimPower
+}
+
+// getProphecyFromClaims loads a prophecy of the ordered claim storage with its claims ordered by validator.
+func (k Keeper) getProphecyFromClaims(ctx sdk.Context, id string) (types.Prophecy, bool) {
+	record, found := k.getProphecyRecord(ctx, id)
+	if !found {
+		return types.Prophecy{}, false
+	}
+
+	prophecy := types.NewProphecy(id)
+	prophecy.Status = record.Status
+	return k.loadValidatorClaims(ctx, prophecy), true
+}
+
+// loadValidatorClaims adds the claims of the ordered claim storage to a prophecy in the order of the validators.
+func (k Keeper) loadValidatorClaims(ctx sdk.Context, prophecy types.Prophecy) types.Prophecy {
+	prefix := types.GetValidatorClaimsKey(prophecy.ID)
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), prefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var claim types.ValidatorClaim
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &claim)
+		valAddr := sdk.ValAddress(iterator.Key()[len(prefix):])
+		prophecy.ValidatorClaims[valAddr.String()] = claim.Payload
+		prophecy.ClaimValidators[claim.Payload] = append(prophecy.ClaimValidators[claim.Payload], valAddr)
+	}
+	return prophecy
+}
+
+func (k Keeper) deleteProphecyEntries(ctx sdk.Context, id string) {
+	store := ctx.KVStore(k.storeKey)
+	var keys [][]byte
+	iterator := sdk.KVStorePrefixIterator(store, types.GetProphecyKey(id))
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+	for _, key := range keys {
+		store.Delete(key)
+	}
+}
+
+func (k Keeper) getProphecyRecord(ctx sdk.Context, id string) (types.ProphecyRecord, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetProphecyRecordKey(id))
+	if bz == nil {
+		return types.ProphecyRecord{}, false
+	}
+	var record types.ProphecyRecord
+	k.cdc.MustUnmarshalBinaryBare(bz, &record)
+	return record, true
+}
+
+func (k Keeper) setProphecyRecord(ctx sdk.Context, record types.ProphecyRecord) {
+	ctx.KVStore(k.storeKey).Set(types.GetProphecyRecordKey(record.ID), k.cdc.MustMarshalBinaryBare(record))
+}
+
+func (k Keeper) getValidatorClaim(ctx sdk.Context, id string, validator sdk.ValAddress) (types.ValidatorClaim, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetValidatorClaimKey(id, validator))
+	if bz == nil {
+		return types.ValidatorClaim{}, false
+	}
+	var claim types.ValidatorClaim
+	k.cdc.MustUnmarshalBinaryBare(bz, &claim)
+	return claim, true
+}
+
+func (k Keeper) setValidatorClaim(ctx sdk.Context, id string, validator sdk.ValAddress, claim types.ValidatorClaim) {
+	ctx.KVStore(k.storeKey).Set(types.GetValidatorClaimKey(id, validator), k.cdc.MustMarshalBinaryBare(claim))
+}
+
+func (k Keeper) getClaimTally(ctx sdk.Context, id string, payload string) (types.ClaimTally, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetClaimTallyKey(id, payload))
+	if bz == nil {
+		return types.ClaimTally{}, false
+	}
+	var tally types.ClaimTally
+	k.cdc.MustUnmarshalBinaryBare(bz, &tally)
+	return tally, true
+}
+
+func (k Keeper) setClaimTally(ctx sdk.Context, id string, tally types.ClaimTally) {
+	ctx.KVStore(k.storeKey).Set(types.GetClaimTallyKey(id, tally.Payload), k.cdc.MustMarshalBinaryBare(tally))
+}
@@ -0,0 +1,198 @@
+package keeper
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+const (
+	expiredByHeight   = "height"
+	expiredBySequence = "sequence"
+)
+
+func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
+	k.paramSpace.GetParamSet(ctx, &params)
+	return
+}
+
+// GetProphecyExpiryBlocks returns the number of blocks after which a pending prophecy expires, 0 if disabled.
+func (k Keeper) GetProphecyExpiryBlocks(ctx sdk.Context) (expiryBlocks int64) {
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyProphecyExpiryBlocks, &expiryBlocks)
+	return
+}
+
+// GetProphecyExpirySequenceDistance returns the number of sequences a pending prophecy can be behind
+// the receive sequence of its channel, 0 if disabled.
+func (k Keeper) GetProphecyExpirySequenceDistance(ctx sdk.Context) (distance int64) {
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyProphecyExpirySequenceDistance, &distance)
+	return
+}
+
+// IndexProphecies indexes the prophecies created before the ProphecyExpiry upgrade as if they were created
+// at the current height, so they expire like the new ones.
+func (k Keeper) IndexProphecies(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	var ids []string
+	iterator := store.Iterator(nil, nil)
+	for ; iterator.Valid(); iterator.Next() {
+		key := iterator.Key()
+		if types.IsLegacyProphecyKey(key) {
+			ids = append(ids, string(key))
+		} else if key[0] == types.ProphecyKeyPrefix[0] {
+			if id, suffix := types.SplitProphecyKey(key); bytes.Equal(suffix, types.ProphecyRecordKey) {
+				ids = append(ids, id)
+			}
+		}
+	}
+	iterator.Close()
+
+	count := 0
+	for _, id := range ids {
+		if _, found := k.getProphecyCreationHeight(ctx, id); !found {
+			k.setProphecyIndex(ctx, id, ctx.BlockHeight())
+			count++
+		}
+	}
+	ctx.Logger().With("module", "oracle").Info("indexed prophecies", "count", count)
+}
+
+// SweepExpiredProphecies deletes the pending prophecies which expired by height or by sequence distance
+// and returns their ids.
+func (k Keeper) SweepExpiredProphecies(ctx sdk.Context) []string {
+	if !sdk.IsUpgrade(sdk.ProphecyExpiry) {
+		return nil
+	}
+	store := ctx.KVStore(k.storeKey)
+	var expired []string
+	expiredIds := make(map[string]bool)
+
+	if expiryBlocks := k.GetProphecyExpiryBlocks(ctx); expiryBlocks > 0 && ctx.BlockHeight() >= expiryBlocks {
+		end := types.GetProphecyHeightKey(ctx.BlockHeight()-expiryBlocks+1, "")
+		iterator := store.Iterator(types.ProphecyHeightKeyPrefix, end)
+		for ; iterator.Valid(); iterator.Next() {
+			id := string(iterator.Value())
+			expired = append(expired, id)
+			expiredIds[id] = true
+		}
+		iterator.Close()
+		k.Metrics.ExpiredProphecies.With("reason", expiredByHeight).Add(float64(len(expired)))
+	}
+
+	if distance := k.GetProphecyExpirySequenceDistance(ctx); distance > 0 {
+		receiveSequence := k.receiveSequenceGetter(ctx)
+		count := 0
+		iterator := sdk.KVStorePrefixIterator(store, types.ProphecyIndexKeyPrefix)
+		for ; iterator.Valid(); iterator.Next() {
+			id := string(iterator.Key()[len(types.ProphecyIndexKeyPrefix):])
+			if expiredIds[id] {
+				continue
+			}
+			chainId, channelId, sequence, err := types.ParseClaimId(id)
+			if err != nil {
+				continue
+			}
+			current := receiveSequence(chainId, channelId)
+			if current > sequence && current-sequence >= uint64(distance) {
+				expired = append(expired, id)
+				count++
+			}
+		}
+		iterator.Close()
+		k.Metrics.ExpiredProphecies.With("reason", expiredBySequence).Add(float64(count))
+	}
+
+	for _, id := range expired {
+		k.DeleteProphecy(ctx, id)
+	}
+	if len(expired) > 0 {
+		ctx.Logger().With("module", "oracle").Info("deleted expired prophecies", "count", len(expired))
+	}
+	return expired
+}
+
+// GetProphecyInfos returns the indexed pending prophecies of a chain ordered by channel and sequence.
+func (k Keeper) GetProphecyInfos(ctx sdk.Context, chainId sdk.ChainID) []types.ProphecyInfo {
+	store := ctx.KVStore(k.storeKey)
+	expiryBlocks := k.GetProphecyExpiryBlocks(ctx)
+	receiveSequence := k.receiveSequenceGetter(ctx)
+
+	var infos []types.ProphecyInfo
+	iterator := sdk.KVStorePrefixIterator(store, types.GetProphecyChainIndexKey(chainId))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		id := string(iterator.Key()[len(types.ProphecyIndexKeyPrefix):])
+		_, channelId, sequence, err := types.ParseClaimId(id)
+		if err != nil {
+			continue
+		}
+		prophecy, found := k.GetProphecy(ctx, id)
+		if !found {
+			continue
+		}
+		info := types.ProphecyInfo{
+			ID:             id,
+			ChannelId:      channelId,
+			Sequence:       sequence,
+			Status:         prophecy.Status,
+			Claims:         len(prophecy.ValidatorClaims),
+			CreationHeight: int64(binary.BigEndian.Uint64(iterator.Value())),
+			Stale:          receiveSequence(chainId, channelId) > sequence,
+		}
+		if expiryBlocks > 0 {
+			info.ExpireHeight = info.CreationHeight + expiryBlocks
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].ChannelId != infos[j].ChannelId {
+			return infos[i].ChannelId < infos[j].ChannelId
+		}
+		return infos[i].Sequence < infos[j].Sequence
+	})
+	return infos
+}
+
+// receiveSequenceGetter returns a getter of receive sequences caching them for the current block.
+func (k Keeper) receiveSequenceGetter(ctx sdk.Context) func(sdk.ChainID, sdk.ChannelID) uint64 {
+	sequences := make(map[string]uint64)
+	return func(chainId sdk.ChainID, channelId sdk.ChannelID) uint64 {
+		key := fmt.Sprintf("%d:%d", chainId, channelId)
+		sequence, ok := sequences[key]
+		if !ok {
+			sequence = k.ScKeeper.GetReceiveSequence(ctx, chainId, channelId)
+			sequences[key] = sequence
+		}
+		return sequence
+	}
+}
+
+func (k Keeper) getProphecyCreationHeight(ctx sdk.Context, id string) (int64, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(types.GetProphecyIndexKey(id))
+	if bz == nil {
+		return 0, false
+	}
+	return int64(binary.BigEndian.Uint64(bz)), true
+}
+
+func (k Keeper) setProphecyIndex(ctx sdk.Context, id string, height int64) {
+	store := ctx.KVStore(k.storeKey)
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(height))
+	store.Set(types.GetProphecyIndexKey(id), bz)
+	store.Set(types.GetProphecyHeightKey(height, id), []byte(id))
+}
+
+func (k Keeper) deleteProphecyIndex(ctx sdk.Context, id string) {
+	height, found := k.getProphecyCreationHeight(ctx, id)
+	if !found {
+		return
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(types.GetProphecyIndexKey(id))
+	store.Delete(types.GetProphecyHeightKey(height, id))
+}
@@ -1,6 +1,8 @@
 package keeper
 
 import (
+	"fmt"
+
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/pubsub"
@@ -32,6 +34,8 @@ type Keeper struct {
 
 	Metrics   *metrics.Metrics
 	pubServer *pubsub.Server
+
+	packageVerifiers map[string]types.PackageVerifier
 }
 
 // Parameter store
@@ -57,6 +61,8 @@ func NewKeeper(cdc *codec.Codec, storeKey sdk.StoreKey, paramSpace param.Subspac
 		BkKeeper:    bkKeeper,
 		Metrics:     metrics.NopMetrics(),
 		Pool:        pool,
+
+		packageVerifiers: make(map[string]types.PackageVerifier),
 	}
 }
 
@@ -77,8 +83,19 @@ func (k *Keeper) SetPbsbServer(p *pubsub.Server) {
 	k.pubServer = p
 }
 
+// RegisterPackageVerifier registers a package verifier which channels can select by name.
+func (k *Keeper) RegisterPackageVerifier(name string, verifier types.PackageVerifier) {
+	if _, ok := k.packageVerifiers[name]; ok {
+		panic(fmt.Sprintf("package verifier %s is already registered", name))
+	}
+	k.packageVerifiers[name] = verifier
+}
+
 // GetProphecy gets the entire prophecy data struct for a given id
 func (k Keeper) GetProphecy(ctx sdk.Context, id string) (types.Prophecy, bool) {
+	if sdk.IsUpgrade(sdk.ProphecyClaimStore) {
+		return k.getProphecyFromClaims(ctx, id)
+	}
 	store := ctx.KVStore(k.storeKey)
 	bz := store.Get([]byte(id))
 	if bz == nil {
@@ -98,8 +115,13 @@ func (k Keeper) GetProphecy(ctx sdk.Context, id string) (types.Prophecy, bool) {
 
 // DeleteProphecy delete prophecy for a given id
 func (k Keeper) DeleteProphecy(ctx sdk.Context, id string) {
-	store := ctx.KVStore(k.storeKey)
-	store.Delete([]byte(id))
+	if sdk.IsUpgrade(sdk.ProphecyClaimStore) {
+		k.deleteProphecyEntries(ctx, id)
+	} else {
+		store := ctx.KVStore(k.storeKey)
+		store.Delete([]byte(id))
+	}
+	k.deleteProphecyIndex(ctx, id)
 }
 
 // setProphecy saves a prophecy with an initial claim
@@ -128,22 +150,35 @@ func (k Keeper) ProcessClaim(ctx sdk.Context, claim types.Claim) (types.Prophecy
 		return types.Prophecy{}, types.ErrInvalidClaim()
 	}
 
-	prophecy, found := k.GetProphecy(ctx, claim.ID)
-	if !found {
-		prophecy = types.NewProphecy(claim.ID)
-	}
+	var prophecy types.Prophecy
+	var found bool
+	if sdk.IsUpgrade(sdk.ProphecyClaimStore) {
+		var err sdk.Error
+		prophecy, found, err = k.processOrderedClaim(ctx, claim)
+		if err != nil {
+			return types.Prophecy{}, err
+		}
+	} else {
+		prophecy, found = k.GetProphecy(ctx, claim.ID)
+		if !found {
+			prophecy = types.NewProphecy(claim.ID)
+		}
 
-	switch prophecy.Status.Text {
-	case types.PendingStatusText:
-		// continue processing
-	default:
-		return types.Prophecy{}, types.ErrProphecyFinalized()
-	}
+		switch prophecy.Status.Text {
+		case types.PendingStatusText:
+			// continue processing
+		default:
+			return types.Prophecy{}, types.ErrProphecyFinalized()
+		}
 
-	prophecy.AddClaim(claim.ValidatorAddress, claim.Payload)
-	prophecy = k.processCompletion(ctx, prophecy)
+		prophecy.AddClaim(claim.ValidatorAddress, claim.Payload)
+		prophecy = k.processCompletion(ctx, prophecy)
 
-	k.setProphecy(ctx, prophecy)
+		k.setProphecy(ctx, prophecy)
+	}
+	if !found && sdk.IsUpgrade(sdk.ProphecyExpiry) {
+		k.setProphecyIndex(ctx, prophecy.ID, ctx.BlockHeight())
+	}
 	return prophecy, nil
 }
 
@@ -154,7 +189,12 @@ func (k Keeper) ProcessClaim(ctx sdk.Context, claim types.Claim) (types.Prophecy
 // left to push it over the threshold required for consensus.
 func (k Keeper) processCompletion(ctx sdk.Context, prophecy types.Prophecy) types.Prophecy {
 	highestClaim, highestClaimPower, totalClaimsPower, totalPower := prophecy.FindHighestClaim(ctx, k.stakeKeeper)
+	prophecy.Status = k.completionStatus(ctx, prophecy.Status, highestClaim, highestClaimPower, totalClaimsPower, totalPower)
+	return prophecy
+}
 
+func (k Keeper) completionStatus(ctx sdk.Context, status types.Status, highestClaim string,
+	highestClaimPower, totalClaimsPower, totalPower int64) types.Status {
 	highestConsensusRatio := sdk.NewDec(highestClaimPower).Quo(sdk.NewDec(totalPower))
 	remainingPossibleClaimPower := totalPower - totalClaimsPower
 	highestPossibleClaimPower := highestClaimPower + remainingPossibleClaimPower
@@ -164,12 +204,12 @@ func (k Keeper) processCompletion(ctx sdk.Context, prophecy types.Prophecy) type
 	consensusNeeded := k.GetConsensusNeeded(ctx)
 
 	if highestConsensusRatio.GTE(consensusNeeded) {
-		prophecy.Status.Text = types.SuccessStatusText
-		prophecy.Status.FinalClaim = highestClaim
+		status.Text = types.SuccessStatusText
+		status.FinalClaim = highestClaim
 	} else if highestPossibleConsensusRatio.LT(consensusNeeded) {
-		prophecy.Status.Text = types.FailedStatusText
+		status.Text = types.FailedStatusText
 	}
-	return prophecy
+	return status
 }
 
 func (k *Keeper) SubscribeParamChange(hub pTypes.ParamChangePublisher) {
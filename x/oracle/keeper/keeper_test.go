@@ -278,3 +278,291 @@ func TestNonValidator(t *testing.T) {
 	require.Error(t, err)
 	require.True(t, strings.Contains(err.Error(), "claim must be made by actively bonded validator"))
 }
+
+func TestSweepExpiredProphecies(t *testing.T) {
+	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 3)
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{Height: 10})
+	stakeHandler := stake.NewStakeHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs))
+	for i, addr := range addrs {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5, 5, 5})
+	stake.EndBlocker(ctx, sk)
+
+	keeper.SetParams(ctx, types.Params{
+		ConsensusNeeded:                sdk.NewDecWithPrec(6, 1),
+		ProphecyExpiryBlocks:           100,
+		ProphecyExpirySequenceDistance: 3,
+	})
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ProphecyExpiry, 1)
+	defer func() { sdk.UpgradeMgr = sdk.NewUpgradeManager(sdk.UpgradeConfig{}) }()
+	sdk.UpgradeMgr.SetHeight(ctx.BlockHeight())
+
+	chainId := sdk.ChainID(1)
+	for _, id := range []string{
+		types.GetClaimId(chainId, types.RelayPackagesChannelId, 0),
+		types.GetClaimId(chainId, types.RelayPackagesChannelId, 1),
+		types.GetClaimId(chainId, types.RelayPackagesChannelId, 3),
+		TestID,
+	} {
+		_, err := keeper.ProcessClaim(ctx, types.NewClaim(id, valAddrs[0], TestString))
+		require.NoError(t, err)
+	}
+	otherChainClaim := types.GetClaimId(sdk.ChainID(2), types.RelayPackagesChannelId, 0)
+	_, err := keeper.ProcessClaim(ctx.WithBlockHeight(50), types.NewClaim(otherChainClaim, valAddrs[0], TestString))
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		keeper.ScKeeper.IncrReceiveSequence(ctx, chainId, types.RelayPackagesChannelId)
+	}
+
+	infos := keeper.GetProphecyInfos(ctx, chainId)
+	require.Len(t, infos, 3)
+	require.Equal(t, types.ProphecyInfo{
+		ID:             types.GetClaimId(chainId, types.RelayPackagesChannelId, 1),
+		ChannelId:      types.RelayPackagesChannelId,
+		Sequence:       1,
+		Status:         types.NewStatus(types.PendingStatusText, ""),
+		Claims:         1,
+		CreationHeight: 10,
+		ExpireHeight:   110,
+		Stale:          true,
+	}, infos[1])
+	require.True(t, infos[0].Stale)
+	require.False(t, infos[2].Stale)
+
+	// sequence 0 is 3 sequences behind
+	expired := keeper.SweepExpiredProphecies(ctx)
+	require.Equal(t, []string{types.GetClaimId(chainId, types.RelayPackagesChannelId, 0)}, expired)
+	_, found := keeper.GetProphecy(ctx, expired[0])
+	require.False(t, found)
+	require.Len(t, keeper.GetProphecyInfos(ctx, chainId), 2)
+
+	// the prophecies created at height 10 expire at height 110
+	expired = keeper.SweepExpiredProphecies(ctx.WithBlockHeight(110))
+	require.Equal(t, []string{
+		types.GetClaimId(chainId, types.RelayPackagesChannelId, 1),
+		types.GetClaimId(chainId, types.RelayPackagesChannelId, 3),
+		TestID,
+	}, expired)
+	require.Len(t, keeper.GetProphecyInfos(ctx, chainId), 0)
+	require.Len(t, keeper.GetProphecyInfos(ctx, sdk.ChainID(2)), 1)
+	_, found = keeper.GetProphecy(ctx, TestID)
+	require.False(t, found)
+}
+
+func createClaimStoreTestInput(t *testing.T) (sdk.Context, Keeper, stake.Keeper, []sdk.ValAddress) {
+	mapp, _, keeper, sk, addrs, _, _ := getMockApp(t, 3)
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{Height: 10})
+	stakeHandler := stake.NewStakeHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs))
+	for i, addr := range addrs {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5, 5, 5})
+	stake.EndBlocker(ctx, sk)
+
+	keeper.SetParams(ctx, types.Params{ConsensusNeeded: sdk.NewDecWithPrec(6, 1)})
+	sdk.UpgradeMgr.SetHeight(ctx.BlockHeight())
+	return ctx, keeper, sk, valAddrs
+}
+
+func TestOrderedClaimStore(t *testing.T) {
+	ctx, keeper, _, valAddrs := createClaimStoreTestInput(t)
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ProphecyClaimStore, 1)
+	defer func() { sdk.UpgradeMgr = sdk.NewUpgradeManager(sdk.UpgradeConfig{}) }()
+
+	prophecy, err := keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[0], TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.PendingStatusText, prophecy.Status.Text)
+	prophecy, err = keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[1], AlternateTestString))
+	require.NoError(t, err)
+	require.Equal(t, types.PendingStatusText, prophecy.Status.Text)
+
+	// the second validator changes its mind
+	prophecy, err = keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[1], TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.NewStatus(types.SuccessStatusText, TestString), prophecy.Status)
+	_, found := keeper.getClaimTally(ctx, TestID, AlternateTestString)
+	require.False(t, found)
+
+	prophecy, found = keeper.GetProphecy(ctx, TestID)
+	require.True(t, found)
+	require.Equal(t, map[string]string{valAddrs[0].String(): TestString, valAddrs[1].String(): TestString}, prophecy.ValidatorClaims)
+	require.Len(t, prophecy.ClaimValidators[TestString], 2)
+
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[2], TestString))
+	require.Error(t, err)
+
+	// three different claims can not reach consensus
+	for i, payload := range []string{TestString, AlternateTestString, AnotherAlternateTestString} {
+		prophecy, err = keeper.ProcessClaim(ctx, types.NewClaim(AlternateTestID, valAddrs[i], payload))
+		require.NoError(t, err)
+	}
+	require.Equal(t, types.FailedStatusText, prophecy.Status.Text)
+
+	keeper.DeleteProphecy(ctx, AlternateTestID)
+	_, found = keeper.GetProphecy(ctx, AlternateTestID)
+	require.False(t, found)
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(keeper.storeKey), types.GetProphecyKey(AlternateTestID))
+	require.False(t, iterator.Valid())
+	iterator.Close()
+}
+
+func TestOrderedClaimStoreRelayersPowerChange(t *testing.T) {
+	ctx, keeper, sk, valAddrs := createClaimStoreTestInput(t)
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ProphecyClaimStore, 1)
+	defer func() { sdk.UpgradeMgr = sdk.NewUpgradeManager(sdk.UpgradeConfig{}) }()
+
+	_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[0], TestString))
+	require.NoError(t, err)
+
+	// the first validator is not a relayer anymore, its claim does not count
+	sk.Jail(ctx, sdk.ConsAddress(pubkeys[0].Address()))
+	stake.EndBlocker(ctx, sk)
+
+	prophecy, err := keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[1], TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.PendingStatusText, prophecy.Status.Text)
+	record, found := keeper.getProphecyRecord(ctx, TestID)
+	require.True(t, found)
+	require.Equal(t, int64(5), record.TotalClaimsPower)
+	require.Equal(t, int64(5), record.HighestClaimPower)
+	tally, found := keeper.getClaimTally(ctx, TestID, TestString)
+	require.True(t, found)
+	require.Equal(t, types.ClaimTally{Payload: TestString, Power: 5, Validators: 2}, tally)
+
+	prophecy, err = keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[2], TestString))
+	require.NoError(t, err)
+	require.Equal(t, types.NewStatus(types.SuccessStatusText, TestString), prophecy.Status)
+}
+
+func TestMigrateProphecies(t *testing.T) {
+	ctx, keeper, _, valAddrs := createClaimStoreTestInput(t)
+	defer func() { sdk.UpgradeMgr = sdk.NewUpgradeManager(sdk.UpgradeConfig{}) }()
+
+	_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[0], TestString))
+	require.NoError(t, err)
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[1], AlternateTestString))
+	require.NoError(t, err)
+	legacy, found := keeper.GetProphecy(ctx, TestID)
+	require.True(t, found)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ProphecyClaimStore, ctx.BlockHeight())
+	require.NoError(t, keeper.MigrateProphecies(ctx))
+	require.Nil(t, ctx.KVStore(keeper.storeKey).Get([]byte(TestID)))
+
+	migrated, found := keeper.GetProphecy(ctx, TestID)
+	require.True(t, found)
+	require.Equal(t, legacy.Status, migrated.Status)
+	require.Equal(t, legacy.ValidatorClaims, migrated.ValidatorClaims)
+
+	record, found := keeper.getProphecyRecord(ctx, TestID)
+	require.True(t, found)
+	require.Equal(t, int64(10), record.TotalClaimsPower)
+	require.Equal(t, int64(5), record.HighestClaimPower)
+
+	prophecy, err := keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[2], AlternateTestString))
+	require.NoError(t, err)
+	require.Equal(t, types.NewStatus(types.SuccessStatusText, AlternateTestString), prophecy.Status)
+}
+
+func TestGetClaimStatus(t *testing.T) {
+	ctx, keeper, sk, valAddrs := createClaimStoreTestInput(t)
+
+	_, found := keeper.GetClaimStatus(ctx, TestID)
+	require.False(t, found)
+
+	_, err := keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[1], AlternateTestString))
+	require.NoError(t, err)
+	_, err = keeper.ProcessClaim(ctx, types.NewClaim(TestID, valAddrs[0], TestString))
+	require.NoError(t, err)
+
+	status, found := keeper.GetClaimStatus(ctx, TestID)
+	require.True(t, found)
+	require.Equal(t, types.ClaimStatus{
+		ID:               TestID,
+		Status:           types.NewStatus(types.PendingStatusText, ""),
+		ConsensusNeeded:  sdk.NewDecWithPrec(6, 1),
+		TotalPower:       15,
+		TotalClaimsPower: 10,
+		MissingPower:     4,
+		Tallies: []types.PayloadTally{
+			{Payload: TestString, Power: 5, Validators: []sdk.ValAddress{valAddrs[0]}},
+			{Payload: AlternateTestString, Power: 5, Validators: []sdk.ValAddress{valAddrs[1]}},
+		},
+	}, status)
+
+	relayers := keeper.GetOracleRelayers(ctx)
+	require.Len(t, relayers, 3)
+	for _, relayer := range relayers {
+		require.Equal(t, int64(5), relayer.Power)
+	}
+
+	// the claim of a validator which is not a relayer anymore counts without power
+	sk.Jail(ctx, sdk.ConsAddress(pubkeys[1].Address()))
+	stake.EndBlocker(ctx, sk)
+	status, found = keeper.GetClaimStatus(ctx, TestID)
+	require.True(t, found)
+	require.Equal(t, int64(10), status.TotalPower)
+	require.Equal(t, int64(5), status.TotalClaimsPower)
+	require.Equal(t, int64(1), status.MissingPower)
+	require.Equal(t, []types.PayloadTally{
+		{Payload: TestString, Power: 5, Validators: []sdk.ValAddress{valAddrs[0]}},
+		{Payload: AlternateTestString, Power: 0, Validators: []sdk.ValAddress{valAddrs[1]}},
+	}, status.Tallies)
+}
+
+type mockPackageVerifier struct {
+	verified map[uint64]bool
+}
+
+func (v mockPackageVerifier) VerifyPackage(ctx sdk.Context, chainId sdk.ChainID, channelId sdk.ChannelID, sequence uint64, payload []byte) sdk.Error {
+	if !v.verified[sequence] {
+		return sdk.ErrUnauthorized("package is not verified")
+	}
+	return nil
+}
+
+func TestVerifyPackage(t *testing.T) {
+	mapp, _, keeper, _, _, _, _ := getMockApp(t, 1)
+
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(sdk.RunTxModeDeliver, abci.Header{Height: 10})
+	keeper.RegisterPackageVerifier("mock", mockPackageVerifier{verified: map[uint64]bool{1: true}})
+	require.Panics(t, func() { keeper.RegisterPackageVerifier("mock", mockPackageVerifier{}) })
+
+	keeper.SetParams(ctx, types.Params{
+		ConsensusNeeded: sdk.NewDecWithPrec(6, 1),
+		ChannelVerifiers: []types.ChannelVerifier{
+			{ChannelId: 2, Verifier: "mock"},
+			{ChannelId: 3, Verifier: "unknown"},
+		},
+	})
+	require.Len(t, keeper.GetChannelVerifiers(ctx), 2)
+
+	// the packages of the channels without verifier are not proved
+	require.Nil(t, keeper.VerifyPackage(ctx, 1, &types.Package{ChannelId: 1, Sequence: 0}))
+
+	require.Nil(t, keeper.VerifyPackage(ctx, 1, &types.Package{ChannelId: 2, Sequence: 1}))
+	require.NotNil(t, keeper.VerifyPackage(ctx, 1, &types.Package{ChannelId: 2, Sequence: 2}))
+
+	err := keeper.VerifyPackage(ctx, 1, &types.Package{ChannelId: 3, Sequence: 1})
+	require.NotNil(t, err)
+	require.Equal(t, types.CodeUnknownPackageVerifier, err.Code())
+
+	params := types.Params{
+		ConsensusNeeded:  sdk.NewDecWithPrec(6, 1),
+		ChannelVerifiers: []types.ChannelVerifier{{ChannelId: 2, Verifier: "mock"}, {ChannelId: 2, Verifier: "other"}},
+	}
+	require.Error(t, params.UpdateCheck())
+	params.ChannelVerifiers = []types.ChannelVerifier{{ChannelId: 2}}
+	require.Error(t, params.UpdateCheck())
+}
@@ -0,0 +1,76 @@
+package keeper
+
+import (
+	"bytes"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+// GetClaimStatus returns the tallies of the claims of a prophecy weighed with the current power of the relayers
+// like when the prophecy is evaluated, claims of validators which are not relayers anymore count without power.
+func (k Keeper) GetClaimStatus(ctx sdk.Context, id string) (types.ClaimStatus, bool) {
+	prophecy, found := k.GetProphecy(ctx, id)
+	if !found {
+		return types.ClaimStatus{}, false
+	}
+
+	powers := k.stakeKeeper.GetOracleRelayersPower(ctx)
+	status := types.ClaimStatus{
+		ID:              id,
+		Status:          prophecy.Status,
+		ConsensusNeeded: k.GetConsensusNeeded(ctx),
+		TotalPower:      types.TotalRelayersPower(ctx, k.stakeKeeper, powers),
+		Tallies:         make([]types.PayloadTally, 0, len(prophecy.ClaimValidators)),
+	}
+	for payload, power := range prophecy.ClaimPowers(powers) {
+		tally := types.PayloadTally{Payload: payload, Power: power, Validators: prophecy.ClaimValidators[payload]}
+		sort.Slice(tally.Validators, func(i, j int) bool {
+			return bytes.Compare(tally.Validators[i], tally.Validators[j]) < 0
+		})
+		status.TotalClaimsPower += tally.Power
+		status.Tallies = append(status.Tallies, tally)
+	}
+	sort.Slice(status.Tallies, func(i, j int) bool {
+		if status.Tallies[i].Power != status.Tallies[j].Power {
+			return status.Tallies[i].Power > status.Tallies[j].Power
+		}
+		return status.Tallies[i].Payload < status.Tallies[j].Payload
+	})
+
+	neededPower := status.ConsensusNeeded.MulInt(status.TotalPower)
+	missingPower := neededPower.TruncateInt64()
+	if !neededPower.IsInteger() {
+		missingPower++
+	}
+	if len(status.Tallies) > 0 {
+		missingPower -= status.Tallies[0].Power
+	}
+	if missingPower > 0 && prophecy.Status.Text == types.PendingStatusText {
+		status.MissingPower = missingPower
+	}
+	return status, true
+}
+
+// GetOracleRelayers returns the relayers allowed to claim, the whitelisted relayers since BEP159Phase2
+// and the bonded validators before.
+func (k Keeper) GetOracleRelayers(ctx sdk.Context) []stake.OracleRelayer {
+	if sdk.IsUpgrade(sdk.BEP159Phase2) {
+		return k.stakeKeeper.GetWhiteLabelOracleRelayer(ctx)
+	}
+	powers := k.stakeKeeper.GetOracleRelayersPower(ctx)
+	relayers := make([]stake.OracleRelayer, 0, len(powers))
+	for address, power := range powers {
+		valAddr, err := sdk.ValAddressFromBech32(address)
+		if err != nil {
+			panic(err)
+		}
+		relayers = append(relayers, stake.OracleRelayer{Address: valAddr, Power: power})
+	}
+	sort.Slice(relayers, func(i, j int) bool {
+		return bytes.Compare(relayers[i].Address, relayers[j].Address) < 0
+	})
+	return relayers
+}
@@ -41,7 +41,7 @@ func getMockApp(t *testing.T, numGenAccs int) (*mock.App, bank.BaseKeeper, Keepe
 
 	mapp.SetInitChainer(getInitChainer(mapp, sk))
 
-	require.NoError(t, mapp.CompleteSetup(keyStake, tkeyStake, keyOracle, keyGlobalParams, tkeyGlobalParams))
+	require.NoError(t, mapp.CompleteSetup(keyStake, tkeyStake, keyOracle, keySideChain, keyGlobalParams, tkeyGlobalParams))
 	genAccs, addrs, pubKeys, privKeys := mock.CreateGenAccounts(numGenAccs, sdk.Coins{sdk.NewCoin(gov.DefaultDepositDenom, 5000e8)})
 
 	mock.SetGenesis(mapp, genAccs)
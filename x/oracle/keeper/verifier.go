@@ -0,0 +1,30 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+// GetChannelVerifiers returns the package verifiers selected by channels.
+func (k Keeper) GetChannelVerifiers(ctx sdk.Context) (channelVerifiers []types.ChannelVerifier) {
+	k.paramSpace.GetIfExists(ctx, types.ParamStoreKeyChannelVerifiers, &channelVerifiers)
+	return
+}
+
+// VerifyPackage proves a package with the verifier selected by its channel, the packages of the other
+// channels are accepted on the consensus of the claims only.
+func (k Keeper) VerifyPackage(ctx sdk.Context, chainId sdk.ChainID, pack *types.Package) sdk.Error {
+	for _, channelVerifier := range k.GetChannelVerifiers(ctx) {
+		if channelVerifier.ChannelId != pack.ChannelId {
+			continue
+		}
+		verifier, ok := k.packageVerifiers[channelVerifier.Verifier]
+		if !ok {
+			return types.ErrUnknownPackageVerifier(fmt.Sprintf("unknown verifier %s of channel %d", channelVerifier.Verifier, pack.ChannelId))
+		}
+		return verifier.VerifyPackage(ctx, chainId, pack.ChannelId, pack.Sequence, pack.Payload)
+	}
+	return nil
+}
@@ -9,7 +9,8 @@ import (
 
 // Metrics contains Metrics exposed by this package.
 type Metrics struct {
-	ErrNumOfChannels metricsPkg.Counter
+	ErrNumOfChannels  metricsPkg.Counter
+	ExpiredProphecies metricsPkg.Counter
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -20,12 +21,18 @@ func PrometheusMetrics() *Metrics {
 			Name:      "err_num_of_channels",
 			Help:      "The error numbers of channel happened from boot",
 		}, []string{"channel_id"}),
+		ExpiredProphecies: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Subsystem: "oracle",
+			Name:      "expired_prophecies",
+			Help:      "The numbers of pending prophecies deleted by expiry from boot",
+		}, []string{"reason"}),
 	}
 }
 
 // NopMetrics returns no-op Metrics.
 func NopMetrics() *Metrics {
 	return &Metrics{
-		ErrNumOfChannels: discard.NewCounter(),
+		ErrNumOfChannels:  discard.NewCounter(),
+		ExpiredProphecies: discard.NewCounter(),
 	}
 }
@@ -1,6 +1,7 @@
 package oracle
 
 import (
+	"github.com/cosmos/cosmos-sdk/baseapp"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/oracle/types"
 )
@@ -9,9 +10,23 @@ func RegisterUpgradeBeginBlocker(keeper Keeper) {
 	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.LaunchBscUpgrade, func(ctx sdk.Context) {
 		keeper.SetParams(ctx, types.Params{ConsensusNeeded: types.DefaultConsensusNeeded})
 	})
+	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.ProphecyExpiry, func(ctx sdk.Context) {
+		params := keeper.GetParams(ctx)
+		params.ProphecyExpiryBlocks = types.DefaultProphecyExpiryBlocks
+		params.ProphecyExpirySequenceDistance = types.DefaultProphecyExpirySequenceDistance
+		keeper.SetParams(ctx, params)
+		keeper.IndexProphecies(ctx)
+	})
 
 	err := keeper.ScKeeper.RegisterChannel(types.RelayPackagesChannelName, types.RelayPackagesChannelId, nil)
 	if err != nil {
 		panic("register relay packages channel error")
 	}
 }
+
+// RegisterStoreMigrations registers the migrations of the oracle store, they run at the heights of their upgrades.
+func RegisterStoreMigrations(app *baseapp.BaseApp, keeper Keeper) {
+	for _, migration := range keeper.StoreMigrations() {
+		app.RegisterStoreMigration(migration)
+	}
+}
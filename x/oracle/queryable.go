@@ -0,0 +1,86 @@
+package oracle
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/oracle/types"
+)
+
+// creates a querier for oracle REST endpoints
+func NewQuerier(k Keeper, cdc *codec.Codec) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		if len(path) == 0 {
+			return nil, sdk.ErrUnknownRequest("unknown oracle query endpoint")
+		}
+		switch path[0] {
+		case types.QueryProphecies:
+			var sideChainId string
+			if err := cdc.UnmarshalJSON(req.Data, &sideChainId); err != nil {
+				return nil, types.ErrInvalidSideChainId(err.Error())
+			}
+			if len(sideChainId) == 0 {
+				return nil, types.ErrInvalidSideChainId("SideChainId is missing")
+			}
+			return queryProphecies(ctx, k, cdc, sideChainId)
+		case types.QueryClaim:
+			var claimId string
+			if err := cdc.UnmarshalJSON(req.Data, &claimId); err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+			}
+			return queryClaim(ctx, k, cdc, claimId)
+		case types.QuerySequence:
+			var params types.QuerySequenceParams
+			if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+			}
+			return querySequence(ctx, k, cdc, params)
+		case types.QueryRelayers:
+			return marshalResult(cdc, k.GetOracleRelayers(ctx))
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown oracle query endpoint")
+		}
+	}
+}
+
+func queryProphecies(ctx sdk.Context, k Keeper, cdc *codec.Codec, sideChainId string) ([]byte, sdk.Error) {
+	chainId, err := k.ScKeeper.GetDestChainID(sideChainId)
+	if err != nil {
+		return nil, types.ErrInvalidSideChainId(err.Error())
+	}
+	prophecies := k.GetProphecyInfos(ctx, chainId)
+	if prophecies == nil {
+		prophecies = []types.ProphecyInfo{}
+	}
+	return marshalResult(cdc, prophecies)
+}
+
+func queryClaim(ctx sdk.Context, k Keeper, cdc *codec.Codec, claimId string) ([]byte, sdk.Error) {
+	status, found := k.GetClaimStatus(ctx, claimId)
+	if !found {
+		return nil, types.ErrProphecyNotFound()
+	}
+	return marshalResult(cdc, status)
+}
+
+func querySequence(ctx sdk.Context, k Keeper, cdc *codec.Codec, params types.QuerySequenceParams) ([]byte, sdk.Error) {
+	chainId, err := k.ScKeeper.GetDestChainID(params.SideChainId)
+	if err != nil {
+		return nil, types.ErrInvalidSideChainId(err.Error())
+	}
+	return marshalResult(cdc, types.SequenceInfo{
+		SideChainId: params.SideChainId,
+		ChainId:     chainId,
+		ChannelId:   params.ChannelId,
+		Sequence:    k.ScKeeper.GetReceiveSequence(ctx, chainId, params.ChannelId),
+	})
+}
+
+func marshalResult(cdc *codec.Codec, result interface{}) ([]byte, sdk.Error) {
+	res, err := codec.MarshalJSONIndent(cdc, result)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return res, nil
+}
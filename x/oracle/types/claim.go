@@ -2,6 +2,8 @@ package types
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
@@ -16,6 +18,27 @@ func GetClaimId(chainId sdk.ChainID, channelId sdk.ChannelID, sequence uint64) s
 	return fmt.Sprintf("%d:%d:%d", chainId, channelId, sequence)
 }
 
+// ParseClaimId is the reverse of GetClaimId, claims of tests and legacy ids may not be parsed.
+func ParseClaimId(id string) (sdk.ChainID, sdk.ChannelID, uint64, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 3 {
+		return 0, 0, 0, fmt.Errorf("invalid claim id %s", id)
+	}
+	chainId, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid chain id of claim id %s: %v", id, err)
+	}
+	channelId, err := strconv.ParseUint(parts[1], 10, 8)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid channel id of claim id %s: %v", id, err)
+	}
+	sequence, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("invalid sequence of claim id %s: %v", id, err)
+	}
+	return sdk.ChainID(chainId), sdk.ChannelID(channelId), sequence, nil
+}
+
 // Claim contains an arbitrary claim with arbitrary content made by a given validator
 type Claim struct {
 	ID               string         `json:"id"`
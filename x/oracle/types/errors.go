@@ -24,6 +24,8 @@ const (
 	CodeInvalidLengthOfPayload        sdk.CodeType = 1011
 	CodeFeeOverflow                   sdk.CodeType = 1012
 	CodeInvalidPayload                sdk.CodeType = 1013
+	CodeInvalidSideChainId            sdk.CodeType = 1014
+	CodeUnknownPackageVerifier        sdk.CodeType = 1015
 )
 
 func ErrProphecyNotFound() sdk.Error {
@@ -85,3 +87,11 @@ func ErrFeeOverflow(msg string) sdk.Error {
 func ErrInvalidPayload(msg string) sdk.Error {
 	return sdk.NewError(DefaultCodespace, CodeInvalidPayload, msg)
 }
+
+func ErrInvalidSideChainId(msg string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeInvalidSideChainId, msg)
+}
+
+func ErrUnknownPackageVerifier(msg string) sdk.Error {
+	return sdk.NewError(DefaultCodespace, CodeUnknownPackageVerifier, msg)
+}
@@ -13,4 +13,5 @@ type StakingKeeper interface {
 	GetBondedValidatorsByPower(ctx sdk.Context) []stake.Validator
 	GetOracleRelayersPower(ctx sdk.Context) map[string]int64
 	CheckIsValidOracleRelayer(ctx sdk.Context, validatorAddress sdk.ValAddress) bool
+	GetWhiteLabelOracleRelayer(ctx sdk.Context) []stake.OracleRelayer
 }
@@ -1,5 +1,13 @@
 package types
 
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
 const (
 	EventTypeClaim = "claim"
 
@@ -11,3 +19,82 @@ const (
 	ClaimCrash           = "ClaimCrash"
 	ClaimPackageType     = "ClaimPackageType"
 )
+
+// Before the ProphecyClaimStore upgrade prophecies are stored under their claim ids, which are printable and
+// never start with the prefixes below.
+var (
+	ProphecyHeightKeyPrefix = []byte{0x01} // prefix for the prophecies indexed by creation height
+	ProphecyIndexKeyPrefix  = []byte{0x02} // prefix for the creation heights of prophecies
+	ProphecyKeyPrefix       = []byte{0x03} // prefix for the entries of prophecies
+
+	// suffixes of the entries of a prophecy
+	ProphecyRecordKey       = []byte{0x00}
+	ValidatorClaimKeyPrefix = []byte{0x01}
+	ClaimTallyKeyPrefix     = []byte{0x02}
+)
+
+// IsLegacyProphecyKey tells if key is the claim id of a prophecy saved before the ProphecyClaimStore upgrade.
+func IsLegacyProphecyKey(key []byte) bool {
+	return len(key) > 0 && key[0] >= 0x20
+}
+
+// GetProphecyKey returns the prefix of the entries of a prophecy: 0x03 | len(id) | id
+func GetProphecyKey(id string) []byte {
+	key := make([]byte, len(ProphecyKeyPrefix)+2+len(id))
+	copy(key, ProphecyKeyPrefix)
+	binary.BigEndian.PutUint16(key[len(ProphecyKeyPrefix):], uint16(len(id)))
+	copy(key[len(ProphecyKeyPrefix)+2:], id)
+	return key
+}
+
+// SplitProphecyKey returns the id of the prophecy and the suffix of an entry key of a prophecy.
+func SplitProphecyKey(key []byte) (string, []byte) {
+	key = key[len(ProphecyKeyPrefix):]
+	length := int(binary.BigEndian.Uint16(key))
+	return string(key[2 : 2+length]), key[2+length:]
+}
+
+// GetProphecyRecordKey returns the key of the record of a prophecy: 0x03 | len(id) | id | 0x00
+func GetProphecyRecordKey(id string) []byte {
+	return append(GetProphecyKey(id), ProphecyRecordKey...)
+}
+
+// GetValidatorClaimsKey returns the prefix of the validator claims of a prophecy: 0x03 | len(id) | id | 0x01
+func GetValidatorClaimsKey(id string) []byte {
+	return append(GetProphecyKey(id), ValidatorClaimKeyPrefix...)
+}
+
+// GetValidatorClaimKey returns the key of the claim of a validator: 0x03 | len(id) | id | 0x01 | validator
+func GetValidatorClaimKey(id string, validator sdk.ValAddress) []byte {
+	return append(GetValidatorClaimsKey(id), validator.Bytes()...)
+}
+
+// GetClaimTalliesKey returns the prefix of the claim tallies of a prophecy: 0x03 | len(id) | id | 0x02
+func GetClaimTalliesKey(id string) []byte {
+	return append(GetProphecyKey(id), ClaimTallyKeyPrefix...)
+}
+
+// GetClaimTallyKey returns the key of the tally of a payload: 0x03 | len(id) | id | 0x02 | sha256(payload)
+func GetClaimTallyKey(id string, payload string) []byte {
+	hash := sha256.Sum256([]byte(payload))
+	return append(GetClaimTalliesKey(id), hash[:]...)
+}
+
+// GetProphecyHeightKey returns the key of a prophecy created at height: 0x01 | height | id
+func GetProphecyHeightKey(height int64, id string) []byte {
+	key := make([]byte, len(ProphecyHeightKeyPrefix)+8+len(id))
+	copy(key, ProphecyHeightKeyPrefix)
+	binary.BigEndian.PutUint64(key[len(ProphecyHeightKeyPrefix):], uint64(height))
+	copy(key[len(ProphecyHeightKeyPrefix)+8:], id)
+	return key
+}
+
+// GetProphecyIndexKey returns the key of the creation height of a prophecy: 0x02 | id
+func GetProphecyIndexKey(id string) []byte {
+	return append(ProphecyIndexKeyPrefix, []byte(id)...)
+}
+
+// GetProphecyChainIndexKey returns the prefix of the creation heights of the prophecies of a chain.
+func GetProphecyChainIndexKey(chainId sdk.ChainID) []byte {
+	return GetProphecyIndexKey(fmt.Sprintf("%d:", chainId))
+}
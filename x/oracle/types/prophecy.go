@@ -1,8 +1,11 @@
 package types
 
 import (
+	"crypto/sha256"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/params"
@@ -13,16 +16,53 @@ var (
 	// prophecy to be finalized
 	DefaultConsensusNeeded      sdk.Dec = sdk.NewDecWithPrec(7, 1)
 	ParamStoreKeyProphecyParams         = []byte("prophecyParams")
+
+	// DefaultProphecyExpiryBlocks and DefaultProphecyExpirySequenceDistance are set at the height of
+	// the ProphecyExpiry upgrade, a value of 0 disables the expiry.
+	DefaultProphecyExpiryBlocks                 int64 = 200000
+	DefaultProphecyExpirySequenceDistance       int64 = 100
+	ParamStoreKeyProphecyExpiryBlocks                 = []byte("prophecyExpiryBlocks")
+	ParamStoreKeyProphecyExpirySequenceDistance       = []byte("prophecyExpirySequenceDistance")
+
+	ParamStoreKeyChannelVerifiers = []byte("channelVerifiers")
 )
 
+// ChannelVerifier selects the package verifier which proves the packages of a channel before they are executed.
+type ChannelVerifier struct {
+	ChannelId sdk.ChannelID `json:"channel_id"`
+	Verifier  string        `json:"verifier"`
+}
+
 type Params struct {
 	ConsensusNeeded sdk.Dec `json:"ConsensusNeeded"` //  Minimum deposit for a proposal to enter voting period.
+	// Number of blocks after which a pending prophecy is deleted.
+	ProphecyExpiryBlocks int64 `json:"ProphecyExpiryBlocks"`
+	// Number of sequences a pending prophecy can be behind the receive sequence of its channel before being deleted.
+	ProphecyExpirySequenceDistance int64 `json:"ProphecyExpirySequenceDistance"`
+	// Package verifiers of the channels whose packages are proved, since the LightClientVerification upgrade.
+	ChannelVerifiers []ChannelVerifier `json:"ChannelVerifiers"`
 }
 
 func (p *Params) UpdateCheck() error {
 	if p.ConsensusNeeded.IsNil() || p.ConsensusNeeded.GT(sdk.OneDec()) || p.ConsensusNeeded.LT(sdk.NewDecWithPrec(5, 1)) {
 		return fmt.Errorf("the value should be in range 0.5 to 1")
 	}
+	if p.ProphecyExpiryBlocks < 0 {
+		return fmt.Errorf("the prophecy expiry blocks should not be negative")
+	}
+	if p.ProphecyExpirySequenceDistance < 0 {
+		return fmt.Errorf("the prophecy expiry sequence distance should not be negative")
+	}
+	channels := make(map[sdk.ChannelID]bool)
+	for _, channelVerifier := range p.ChannelVerifiers {
+		if channels[channelVerifier.ChannelId] {
+			return fmt.Errorf("duplicated verifier of channel %d", channelVerifier.ChannelId)
+		}
+		channels[channelVerifier.ChannelId] = true
+		if channelVerifier.Verifier == "" {
+			return fmt.Errorf("the verifier of channel %d should not be empty", channelVerifier.ChannelId)
+		}
+	}
 	return nil
 }
 
@@ -33,6 +73,9 @@ func (p *Params) GetParamAttribute() (string, bool) {
 func (p *Params) KeyValuePairs() params.KeyValuePairs {
 	return params.KeyValuePairs{
 		{ParamStoreKeyProphecyParams, &p.ConsensusNeeded},
+		{ParamStoreKeyProphecyExpiryBlocks, &p.ProphecyExpiryBlocks},
+		{ParamStoreKeyProphecyExpirySequenceDistance, &p.ProphecyExpirySequenceDistance},
+		{ParamStoreKeyChannelVerifiers, &p.ChannelVerifiers},
 	}
 }
 
@@ -55,7 +98,7 @@ type Prophecy struct {
 	ValidatorClaims map[string]string `json:"validator_claims"`
 }
 
-// DBProphecy is what the prophecy becomes when being saved to the database.
+// DBProphecy is what the prophecy becomes when being saved to the database before the ProphecyClaimStore upgrade.
 //  Tendermint/Amino does not support maps so we must serialize those variables into bytes.
 type DBProphecy struct {
 	ID              string `json:"id"`
@@ -108,6 +151,21 @@ func (prophecy Prophecy) FindHighestClaim(ctx sdk.Context, stakeKeeper StakingKe
 	totalClaimsPower := int64(0)
 	highestClaimPower := int64(-1)
 	highestClaim := ""
+	for claim, claimPower := range prophecy.ClaimPowers(validatorsPowerMap) {
+		totalClaimsPower += claimPower
+		if claimPower > highestClaimPower {
+			highestClaimPower = claimPower
+			highestClaim = claim
+		}
+	}
+	totalPower := TotalRelayersPower(ctx, stakeKeeper, validatorsPowerMap)
+	return highestClaim, highestClaimPower, totalClaimsPower, totalPower
+}
+
+// ClaimPowers returns the power behind every claimed payload, validatorsPowerMap is the result of
+// GetOracleRelayersPower.
+func (prophecy Prophecy) ClaimPowers(validatorsPowerMap map[string]int64) map[string]int64 {
+	claimPowers := make(map[string]int64, len(prophecy.ClaimValidators))
 	for claim, validatorAddrs := range prophecy.ClaimValidators {
 		claimPower := int64(0)
 		for _, validatorAddr := range validatorAddrs {
@@ -118,12 +176,33 @@ func (prophecy Prophecy) FindHighestClaim(ctx sdk.Context, stakeKeeper StakingKe
 				claimPower += power
 			}
 		}
-		totalClaimsPower += claimPower
-		if claimPower > highestClaimPower {
-			highestClaimPower = claimPower
-			highestClaim = claim
-		}
+		claimPowers[claim] = claimPower
+	}
+	return claimPowers
+}
+
+// RelayersPowerHash returns a hash of the power of every relayer, validatorsPowerMap is the result of
+// GetOracleRelayersPower.
+func RelayersPowerHash(validatorsPowerMap map[string]int64) []byte {
+	validators := make([]string, 0, len(validatorsPowerMap))
+	for validator := range validatorsPowerMap {
+		validators = append(validators, validator)
+	}
+	sort.Strings(validators)
+
+	hasher := sha256.New()
+	power := make([]byte, 8)
+	for _, validator := range validators {
+		hasher.Write([]byte(validator))
+		binary.BigEndian.PutUint64(power, uint64(validatorsPowerMap[validator]))
+		hasher.Write(power)
 	}
+	return hasher.Sum(nil)
+}
+
+// TotalRelayersPower returns the total power a claim is compared with, validatorsPowerMap is the result
+// of GetOracleRelayersPower.
+func TotalRelayersPower(ctx sdk.Context, stakeKeeper StakingKeeper, validatorsPowerMap map[string]int64) int64 {
 	totalPower := int64(0)
 	if !sdk.IsUpgrade(sdk.BEP159Phase2) {
 		totalPower = stakeKeeper.GetLastTotalPower(ctx)
@@ -132,7 +211,7 @@ func (prophecy Prophecy) FindHighestClaim(ctx sdk.Context, stakeKeeper StakingKe
 			totalPower += power
 		}
 	}
-	return highestClaim, highestClaimPower, totalClaimsPower, totalPower
+	return totalPower
 }
 
 // AddClaim adds a given claim to this prophecy
@@ -161,6 +240,40 @@ func NewProphecy(id string) Prophecy {
 	}
 }
 
+// ProphecyRecord is how a prophecy is saved since the ProphecyClaimStore upgrade. The claims of validators and
+// the power tally of every claimed payload are saved as separate entries under the prefix of the prophecy,
+// so processing a claim only touches the entries of the claiming validator and of the payloads involved.
+// The tallies are weighed with the relayers power hashed in RelayersHash, they are weighed again from the
+// claims when the power of the relayers changes.
+type ProphecyRecord struct {
+	ID                string `json:"id"`
+	Status            Status `json:"status"`
+	RelayersHash      []byte `json:"relayers_hash"`
+	TotalClaimsPower  int64  `json:"total_claims_power"`
+	HighestClaim      string `json:"highest_claim"`
+	HighestClaimPower int64  `json:"highest_claim_power"`
+}
+
+// NewProphecyRecord returns a new ProphecyRecord, initialized in pending status
+func NewProphecyRecord(id string) ProphecyRecord {
+	return ProphecyRecord{
+		ID:     id,
+		Status: NewStatus(PendingStatusText, ""),
+	}
+}
+
+// ValidatorClaim is the claim of a validator.
+type ValidatorClaim struct {
+	Payload string `json:"payload"`
+}
+
+// ClaimTally is the number of validators claiming a payload and the sum of their power.
+type ClaimTally struct {
+	Payload    string `json:"payload"`
+	Power      int64  `json:"power"`
+	Validators int64  `json:"validators"`
+}
+
 // Status is a struct that contains the status of a given prophecy
 type Status struct {
 	Text       StatusText `json:"text"`
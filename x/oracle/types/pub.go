@@ -8,6 +8,10 @@ const (
 	Topic = pubsub.Topic("oracle-event")
 )
 
+func init() {
+	pubsub.RegisterEvent(CrossAppFailEvent{})
+}
+
 type CrossAppFailEvent struct {
 	TxHash     string
 	ChainId    string
@@ -0,0 +1,57 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	QueryProphecies = "prophecies"
+	QueryClaim      = "claim"
+	QuerySequence   = "sequence"
+	QueryRelayers   = "relayers"
+)
+
+type QuerySequenceParams struct {
+	SideChainId string        `json:"side_chain_id"`
+	ChannelId   sdk.ChannelID `json:"channel_id"`
+}
+
+// ProphecyInfo describes a pending prophecy for relayer operators. A prophecy is stale once the receive
+// sequence of its channel moved past it, it can never be finalized then and waits to be swept.
+type ProphecyInfo struct {
+	ID             string        `json:"id"`
+	ChannelId      sdk.ChannelID `json:"channel_id"`
+	Sequence       uint64        `json:"sequence"`
+	Status         Status        `json:"status"`
+	Claims         int           `json:"claims"`
+	CreationHeight int64         `json:"creation_height"`
+	ExpireHeight   int64         `json:"expire_height"`
+	Stale          bool          `json:"stale"`
+}
+
+// ClaimStatus describes the claims of a prophecy with the current power of their validators,
+// MissingPower is the power the highest payload still needs to reach ConsensusNeeded.
+type ClaimStatus struct {
+	ID               string         `json:"id"`
+	Status           Status         `json:"status"`
+	ConsensusNeeded  sdk.Dec        `json:"consensus_needed"`
+	TotalPower       int64          `json:"total_power"`
+	TotalClaimsPower int64          `json:"total_claims_power"`
+	MissingPower     int64          `json:"missing_power"`
+	Tallies          []PayloadTally `json:"tallies"`
+}
+
+// PayloadTally is the current power of the validators claiming a payload.
+type PayloadTally struct {
+	Payload    string           `json:"payload"`
+	Power      int64            `json:"power"`
+	Validators []sdk.ValAddress `json:"validators"`
+}
+
+// SequenceInfo is the next sequence expected by a channel of a side chain.
+type SequenceInfo struct {
+	SideChainId string        `json:"side_chain_id"`
+	ChainId     sdk.ChainID   `json:"chain_id"`
+	ChannelId   sdk.ChannelID `json:"channel_id"`
+	Sequence    uint64        `json:"sequence"`
+}
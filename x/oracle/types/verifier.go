@@ -0,0 +1,11 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// PackageVerifier proves that a package was sent by the source chain before it is executed. The verifiers
+// are registered to the oracle keeper by name and selected per channel by the ChannelVerifiers param.
+type PackageVerifier interface {
+	VerifyPackage(ctx sdk.Context, chainId sdk.ChainID, channelId sdk.ChannelID, sequence uint64, payload []byte) sdk.Error
+}
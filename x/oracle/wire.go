@@ -11,6 +11,9 @@ func RegisterWire(cdc *codec.Codec) {
 	cdc.RegisterConcrete(Prophecy{}, "oracle/Prophecy", nil)
 	cdc.RegisterConcrete(Status{}, "oracle/Status", nil)
 	cdc.RegisterConcrete(DBProphecy{}, "oracle/DBProphecy", nil)
+	cdc.RegisterConcrete(ProphecyRecord{}, "oracle/ProphecyRecord", nil)
+	cdc.RegisterConcrete(ValidatorClaim{}, "oracle/ValidatorClaim", nil)
+	cdc.RegisterConcrete(ClaimTally{}, "oracle/ClaimTally", nil)
 	cdc.RegisterConcrete(ClaimMsg{}, "oracle/ClaimMsg", nil)
 	cdc.RegisterConcrete(&types.Params{}, "params/OracleParamSet", nil)
 }
@@ -30,13 +30,17 @@ const (
 	RefundHTLTFee  = 37500
 
 	// stake fee
-	CreateValidatorFee          = 10e8
-	RemoveValidatorFee          = 1e8
-	CreateSideChainValidatorFee = 10e8
-	EditSideChainValidatorFee   = 1e8
-	SideChainDelegateFee        = 1e5
-	SideChainRedelegateFee      = 3e5
-	SideChainUndelegateFee      = 2e5
+	CreateValidatorFee           = 10e8
+	RemoveValidatorFee           = 1e8
+	CreateSideChainValidatorFee  = 10e8
+	EditSideChainValidatorFee    = 1e8
+	SideChainDelegateFee         = 1e5
+	SideChainRedelegateFee       = 3e5
+	SideChainUndelegateFee       = 2e5
+	SetRewardRestakeFee          = 1e5
+	SideChainLiquidDelegateFee   = 1e5
+	SideChainLiquidUndelegateFee = 2e5
+	RetryCrossStakeRefundFee     = 1e5
 
 	// beacon chain stake fee
 	EditChainValidatorFee = 1e8
@@ -140,6 +140,37 @@ func RegisterUpgradeBeginBlocker(paramHub *ParamHub) {
 		}
 		paramHub.UpdateFeeParams(ctx, updateFeeParams)
 	})
+	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.BscDoubleSignEvidences, func(ctx sdk.Context) {
+		updateFeeParams := []param.FeeParam{
+			&param.FixedFeeParams{MsgType: "bsc_submit_evidences", Fee: BscSubmitEvidenceFee, FeeFor: sdk.FeeForProposer},
+		}
+		paramHub.UpdateFeeParams(ctx, updateFeeParams)
+	})
+	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.BscVoteEvidence, func(ctx sdk.Context) {
+		updateFeeParams := []param.FeeParam{
+			&param.FixedFeeParams{MsgType: "bsc_submit_vote_evidence", Fee: BscSubmitEvidenceFee, FeeFor: sdk.FeeForProposer},
+		}
+		paramHub.UpdateFeeParams(ctx, updateFeeParams)
+	})
+	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.RewardRestake, func(ctx sdk.Context) {
+		updateFeeParams := []param.FeeParam{
+			&param.FixedFeeParams{MsgType: "set_reward_restake", Fee: SetRewardRestakeFee, FeeFor: sdk.FeeForProposer},
+		}
+		paramHub.UpdateFeeParams(ctx, updateFeeParams)
+	})
+	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.LiquidStaking, func(ctx sdk.Context) {
+		updateFeeParams := []param.FeeParam{
+			&param.FixedFeeParams{MsgType: "side_liquid_delegate", Fee: SideChainLiquidDelegateFee, FeeFor: sdk.FeeForProposer},
+			&param.FixedFeeParams{MsgType: "side_liquid_undelegate", Fee: SideChainLiquidUndelegateFee, FeeFor: sdk.FeeForProposer},
+		}
+		paramHub.UpdateFeeParams(ctx, updateFeeParams)
+	})
+	sdk.UpgradeMgr.RegisterBeginBlocker(sdk.CrossStakeRecord, func(ctx sdk.Context) {
+		updateFeeParams := []param.FeeParam{
+			&param.FixedFeeParams{MsgType: "retry_cross_stake_refund", Fee: RetryCrossStakeRefundFee, FeeFor: sdk.FeeForProposer},
+		}
+		paramHub.UpdateFeeParams(ctx, updateFeeParams)
+	})
 }
 
 func EndBreatheBlock(ctx sdk.Context, paramHub *ParamHub) {
@@ -171,7 +202,13 @@ func init() {
 		"side_delegate":                        fees.FixedFeeCalculatorGen,
 		"side_redelegate":                      fees.FixedFeeCalculatorGen,
 		"side_undelegate":                      fees.FixedFeeCalculatorGen,
+		"set_reward_restake":                   fees.FixedFeeCalculatorGen,
+		"side_liquid_delegate":                 fees.FixedFeeCalculatorGen,
+		"side_liquid_undelegate":               fees.FixedFeeCalculatorGen,
+		"retry_cross_stake_refund":             fees.FixedFeeCalculatorGen,
 		"bsc_submit_evidence":                  fees.FixedFeeCalculatorGen,
+		"bsc_submit_evidences":                 fees.FixedFeeCalculatorGen,
+		"bsc_submit_vote_evidence":             fees.FixedFeeCalculatorGen,
 		"side_chain_unjail":                    fees.FixedFeeCalculatorGen,
 		"dexList":                              fees.FixedFeeCalculatorGen,
 		"orderNew":                             fees.FixedFeeCalculatorGen,
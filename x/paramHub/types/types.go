@@ -59,9 +59,15 @@ var (
 		"side_delegate":                        {},
 		"side_redelegate":                      {},
 		"side_undelegate":                      {},
-
-		"bsc_submit_evidence": {},
-		"side_chain_unjail":   {},
+		"set_reward_restake":                   {},
+		"side_liquid_delegate":                 {},
+		"side_liquid_undelegate":               {},
+		"retry_cross_stake_refund":             {},
+
+		"bsc_submit_evidence":      {},
+		"bsc_submit_evidences":     {},
+		"bsc_submit_vote_evidence": {},
+		"side_chain_unjail":        {},
 
 		"side_submit_proposal": {},
 		"side_deposit":         {},
@@ -19,6 +19,8 @@ func AddCommands(root *cobra.Command, cdc *codec.Codec) {
 		client.PostCommands(
 			GetCmdUnjail(cdc),
 			GetCmdBscSubmitEvidence(cdc),
+			GetCmdBscSubmitEvidences(cdc),
+			GetCmdBscSubmitVoteEvidence(cdc),
 			GetCmdSideChainUnjail(cdc),
 		)...)
 
@@ -28,6 +30,10 @@ func AddCommands(root *cobra.Command, cdc *codec.Codec) {
 			GetCmdQuerySideChainSlashRecord(slashingStoreName, cdc),
 			GetCmdQuerySideChainSlashRecords(cdc),
 			GetCmdQueryAllSideSlashRecords(slashingStoreName, cdc),
+			GetCmdQuerySideChainSlashRecordsPage(cdc),
+			GetCmdExportSideChainSlashRecords(cdc),
+			GetCmdQueryValidatorUptime(cdc),
+			GetCmdQueryValidatorsAtRisk(cdc),
 		)...)
 
 	root.AddCommand(slashingCmd)
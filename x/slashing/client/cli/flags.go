@@ -6,4 +6,13 @@ const (
 	FlagInfractionType   = "infraction-type"
 	FlagInfractionHeight = "infraction-height"
 	FlagSideChainId      = "side-chain-id"
+	FlagFromHeight       = "from-height"
+	FlagToHeight         = "to-height"
+	FlagFromTime         = "from-time"
+	FlagToTime           = "to-time"
+	FlagCursor           = "cursor"
+	FlagLimit            = "limit"
+	FlagFormat           = "format"
+	FlagOutFile          = "out-file"
+	FlagThreshold        = "threshold"
 )
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/spf13/cobra"
@@ -57,3 +58,92 @@ func GetCmdQuerySigningInfo(storeName string, cdc *codec.Codec) *cobra.Command {
 
 	return cmd
 }
+
+// GetCmdQueryValidatorUptime implements the command to query the uptime of a validator over a height range.
+func GetCmdQueryValidatorUptime(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "uptime [validator-pubkey]",
+		Short: "Query a validator's uptime from its checkpoints overlapping a height range",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pk, err := sdk.GetConsPubKeyBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			params := slashing.QueryValidatorUptimeParams{
+				ConsAddr:   pk.Address(),
+				FromHeight: viper.GetInt64(FlagFromHeight),
+				ToHeight:   viper.GetInt64(FlagToHeight),
+			}
+			bz, err := json.Marshal(params)
+			if err != nil {
+				return err
+			}
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			response, err := cliCtx.QueryWithData("custom/slashing/"+slashing.QueryValidatorUptime, bz)
+			if err != nil {
+				return err
+			}
+
+			switch viper.Get(cli.OutputFlag) {
+			case "text":
+				var uptime slashing.ValidatorUptime
+				if err = cdc.UnmarshalJSON(response, &uptime); err != nil {
+					return err
+				}
+				fmt.Printf("Heights: %d - %d, signed blocks: %d, missed blocks: %d, uptime: %s\n",
+					uptime.StartHeight, uptime.EndHeight, uptime.SignedBlocks, uptime.MissedBlocks, uptime.Uptime)
+			case "json":
+				fmt.Println(string(response))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64(FlagFromHeight, 0, "lowest height, inclusive")
+	cmd.Flags().Int64(FlagToHeight, 0, "highest height, inclusive, unbounded if 0")
+	return cmd
+}
+
+// GetCmdQueryValidatorsAtRisk implements the command to query the validators approaching the downtime slash.
+func GetCmdQueryValidatorsAtRisk(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validators-at-risk",
+		Short: "Query the validators which missed at least a fraction of the blocks they may miss in the signed blocks window",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			threshold, sdkErr := sdk.NewDecFromStr(viper.GetString(FlagThreshold))
+			if sdkErr != nil {
+				return sdkErr
+			}
+
+			bz, err := json.Marshal(slashing.QueryValidatorsAtRiskParams{Threshold: threshold})
+			if err != nil {
+				return err
+			}
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			response, err := cliCtx.QueryWithData("custom/slashing/"+slashing.QueryValidatorsAtRisk, bz)
+			if err != nil {
+				return err
+			}
+
+			switch viper.Get(cli.OutputFlag) {
+			case "text":
+				var validators []slashing.ValidatorAtRisk
+				if err = cdc.UnmarshalJSON(response, &validators); err != nil {
+					return err
+				}
+				for _, validator := range validators {
+					fmt.Printf("Validator: %s, missed blocks: %d of %d in window %d\n", validator.ConsAddr,
+						validator.MissedBlocksCounter, validator.MaxMissedBlocks, validator.SignedBlocksWindow)
+				}
+			case "json":
+				fmt.Println(string(response))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().String(FlagThreshold, "0.8", "fraction of the blocks a validator may miss before being slashed")
+	return cmd
+}
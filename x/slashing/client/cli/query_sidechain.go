@@ -1,9 +1,13 @@
 package cli
 
 import (
+	"encoding/csv"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -269,6 +273,188 @@ func GetCmdQuerySideChainSlashRecords(cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+// GetCmdQuerySideChainSlashRecordsPage implements the command to query a page of the slash records of all validators
+func GetCmdQuerySideChainSlashRecordsPage(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "side-slash-records",
+		Short: "Query a page of the slash records of all validators, filtered by infraction type, height range and time range",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			params, err := buildQuerySlashRecordsParams(cliCtx)
+			if err != nil {
+				return err
+			}
+			if cursor := viper.GetString(FlagCursor); len(cursor) != 0 {
+				if params.Cursor, err = sdk.HexDecode(cursor); err != nil {
+					return err
+				}
+			}
+			params.Limit = viper.GetInt(FlagLimit)
+
+			page, response, err := querySlashRecordsPage(cliCtx, cdc, params)
+			if err != nil {
+				return err
+			}
+
+			switch viper.Get(cli.OutputFlag) {
+			case "text":
+				for _, sr := range page.Records {
+					resp, err := sr.HumanReadableString()
+					if err != nil {
+						return err
+					}
+					fmt.Println(resp)
+				}
+				if len(page.NextCursor) != 0 {
+					fmt.Printf("Next Cursor: %s\n", page.NextCursor)
+				}
+			case "json":
+				fmt.Println(string(response))
+			}
+			return nil
+		},
+	}
+
+	addSlashRecordsFilterFlags(cmd)
+	cmd.Flags().String(FlagCursor, "", "cursor of the page in hex, the next cursor of the previous page")
+	cmd.Flags().Int(FlagLimit, slashing.DefaultSlashRecordsLimit, "max number of slash records of the page")
+	return cmd
+}
+
+// GetCmdExportSideChainSlashRecords implements the command to export the slash records of all validators to csv or json
+func GetCmdExportSideChainSlashRecords(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-side-slash-records",
+		Short: "Export the slash records of all validators to csv or json, filtered by infraction type, height range and time range",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format := viper.GetString(FlagFormat)
+			if format != "csv" && format != "json" {
+				return fmt.Errorf("unknown export format %s, csv or json", format)
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			params, err := buildQuerySlashRecordsParams(cliCtx)
+			if err != nil {
+				return err
+			}
+			params.Limit = slashing.MaxSlashRecordsLimit
+
+			slashRecords := make([]slashing.SlashRecord, 0)
+			for {
+				page, _, err := querySlashRecordsPage(cliCtx, cdc, params)
+				if err != nil {
+					return err
+				}
+				slashRecords = append(slashRecords, page.Records...)
+				if len(page.NextCursor) == 0 {
+					break
+				}
+				if params.Cursor, err = sdk.HexDecode(page.NextCursor); err != nil {
+					return err
+				}
+			}
+
+			out := os.Stdout
+			if outFile := viper.GetString(FlagOutFile); len(outFile) != 0 {
+				if out, err = os.Create(outFile); err != nil {
+					return err
+				}
+				defer out.Close()
+			}
+			if format == "json" {
+				output, err := codec.MarshalJSONIndent(cdc, slashRecords)
+				if err != nil {
+					return err
+				}
+				_, err = fmt.Fprintln(out, string(output))
+				return err
+			}
+			return writeSlashRecordsCSV(out, slashRecords)
+		},
+	}
+
+	addSlashRecordsFilterFlags(cmd)
+	cmd.Flags().String(FlagFormat, "csv", "export format, 'csv;json'")
+	cmd.Flags().String(FlagOutFile, "", "file to export to, stdout if empty")
+	return cmd
+}
+
+func addSlashRecordsFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().String(FlagInfractionType, "", "infraction type, 'DoubleSign;Downtime;MaliciousVote'")
+	cmd.Flags().Int64(FlagFromHeight, 0, "lowest slash height, inclusive")
+	cmd.Flags().Int64(FlagToHeight, 0, "highest slash height, inclusive")
+	cmd.Flags().String(FlagFromTime, "", "earliest slash time in RFC3339, inclusive")
+	cmd.Flags().String(FlagToTime, "", "latest slash time in RFC3339, inclusive")
+	cmd.Flags().String(FlagSideChainId, "", "chain-id of the side chain the validators belong to")
+}
+
+func buildQuerySlashRecordsParams(cliCtx context.CLIContext) (slashing.QuerySlashRecordsParams, error) {
+	var params slashing.QuerySlashRecordsParams
+	sideChainId, _, err := getSideChainConfig(cliCtx)
+	if err != nil {
+		return params, err
+	}
+	params.BaseParams = slashing.NewBaseParams(sideChainId)
+
+	if infractionType := viper.GetString(FlagInfractionType); len(infractionType) != 0 {
+		resType, err := convertInfractionType(infractionType)
+		if err != nil {
+			return params, err
+		}
+		params.InfractionType = &resType
+	}
+	params.FromHeight = viper.GetInt64(FlagFromHeight)
+	params.ToHeight = viper.GetInt64(FlagToHeight)
+	for flag, t := range map[string]*time.Time{FlagFromTime: &params.FromTime, FlagToTime: &params.ToTime} {
+		if str := viper.GetString(flag); len(str) != 0 {
+			if *t, err = time.Parse(time.RFC3339, str); err != nil {
+				return params, err
+			}
+		}
+	}
+	return params, nil
+}
+
+func querySlashRecordsPage(cliCtx context.CLIContext, cdc *codec.Codec, params slashing.QuerySlashRecordsParams) (slashing.SlashRecordsPage, []byte, error) {
+	var page slashing.SlashRecordsPage
+	bz, err := json.Marshal(params)
+	if err != nil {
+		return page, nil, err
+	}
+	response, err := cliCtx.QueryWithData("custom/slashing/"+slashing.QuerySlashRecords, bz)
+	if err != nil {
+		return page, nil, err
+	}
+	if err = cdc.UnmarshalJSON(response, &page); err != nil {
+		return page, nil, err
+	}
+	return page, response, nil
+}
+
+func writeSlashRecordsCSV(out io.Writer, slashRecords []slashing.SlashRecord) error {
+	writer := csv.NewWriter(out)
+	err := writer.Write([]string{"cons_addr", "infraction_type", "infraction_height", "slash_height", "jail_until", "slash_amount", "side_chain_id"})
+	if err != nil {
+		return err
+	}
+	for _, sr := range slashRecords {
+		err := writer.Write([]string{
+			sdk.HexEncode(sr.ConsAddr),
+			slashing.InfractionTypeString(sr.InfractionType),
+			strconv.FormatUint(sr.InfractionHeight, 10),
+			strconv.FormatInt(sr.SlashHeight, 10),
+			sr.JailUntil.UTC().Format(time.RFC3339),
+			strconv.FormatInt(sr.SlashAmt, 10),
+			sr.SideChainId,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
 func getSideChainConfig(cliCtx context.CLIContext) (sideChainId string, prefix []byte, error error) {
 	sideChainId, error = getSideChainId()
 	if error != nil {
@@ -285,15 +471,5 @@ func getSideChainConfig(cliCtx context.CLIContext) (sideChainId string, prefix [
 }
 
 func convertInfractionType(infractionTypeS string) (byte, error) {
-	var res byte
-	if infractionTypeS == "DoubleSign" {
-		res = slashing.DoubleSign
-	} else if infractionTypeS == "Downtime" {
-		res = slashing.Downtime
-	} else if infractionTypeS == "MaliciousVote" {
-		res = slashing.MaliciousVote
-	} else {
-		return 0, errors.New("unknown infraction type")
-	}
-	return res, nil
+	return slashing.ParseInfractionType(infractionTypeS)
 }
@@ -80,6 +80,144 @@ func GetCmdBscSubmitEvidence(cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+// GetCmdBscSubmitEvidences implements the submit evidences command handler.
+func GetCmdBscSubmitEvidences(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bsc-submit-evidences",
+		Short: "submit a batch of double sign evidences against the malicious validators on bsc",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+
+			from, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			filePath := viper.GetString(flagEvidenceFile)
+			evidenceBytes := make([]byte, 0)
+			if filePath != "" {
+				evidenceBytes, err = os.ReadFile(filePath)
+				if err != nil {
+					return err
+				}
+			} else {
+				txStr := viper.GetString(flagEvidence)
+				if txStr == "" {
+					return errors.New(fmt.Sprintf("either %s or %s is required", flagEvidenceFile, flagEvidence))
+				}
+				evidenceBytes = []byte(txStr)
+			}
+
+			evidences := make([]slashing.BscDoubleSignEvidence, 0)
+			err = json.Unmarshal(evidenceBytes, &evidences)
+			if err != nil {
+				return err
+			}
+
+			msg := slashing.NewMsgBscSubmitEvidences(from, evidences)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().String(flagEvidence, "", "Evidences details, each including two headers of the same number with json format, e.g. [{\"header_a\":{\"difficulty\":\"0x2\",...},\"header_b\":{\"difficulty\":\"0x2\",...}}]")
+	cmd.Flags().String(flagEvidenceFile, "", "File of evidences details, if evidence-file is not empty, --evidence will be ignored")
+	return cmd
+}
+
+type voteJSON struct {
+	Data      bsc.VoteData `json:"data"`
+	Signature string       `json:"signature"` // in hex
+}
+
+type voteEvidenceJSON struct {
+	VoteAddr string   `json:"vote_addr"` // in hex
+	VoteA    voteJSON `json:"vote_a"`
+	VoteB    voteJSON `json:"vote_b"`
+}
+
+func (v voteJSON) toBscVote() (slashing.BscVote, error) {
+	sig, err := sdk.HexDecode(v.Signature)
+	if err != nil {
+		return slashing.BscVote{}, err
+	}
+	return slashing.BscVote{Data: v.Data, Signature: sig}, nil
+}
+
+// GetCmdBscSubmitVoteEvidence implements the submit vote evidence command handler.
+func GetCmdBscSubmitVoteEvidence(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bsc-submit-vote-evidence",
+		Short: "submit evidence of two conflicting fast finality votes of the malicious validator on bsc",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+
+			from, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			filePath := viper.GetString(flagEvidenceFile)
+			evidenceBytes := make([]byte, 0)
+			if filePath != "" {
+				evidenceBytes, err = os.ReadFile(filePath)
+				if err != nil {
+					return err
+				}
+			} else {
+				txStr := viper.GetString(flagEvidence)
+				if txStr == "" {
+					return errors.New(fmt.Sprintf("either %s or %s is required", flagEvidenceFile, flagEvidence))
+				}
+				evidenceBytes = []byte(txStr)
+			}
+
+			var evidence voteEvidenceJSON
+			if err := json.Unmarshal(evidenceBytes, &evidence); err != nil {
+				return err
+			}
+			voteAddr, err := sdk.HexDecode(evidence.VoteAddr)
+			if err != nil {
+				return err
+			}
+			voteA, err := evidence.VoteA.toBscVote()
+			if err != nil {
+				return err
+			}
+			voteB, err := evidence.VoteB.toBscVote()
+			if err != nil {
+				return err
+			}
+
+			msg := slashing.NewMsgBscSubmitVoteEvidence(from, voteAddr, voteA, voteB)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+	cmd.Flags().String(flagEvidence, "", "Evidence details with json format, e.g. {\"vote_addr\":\"0x...\",\"vote_a\":{\"data\":{\"source_number\":1,\"source_hash\":\"0x...\",\"target_number\":2,\"target_hash\":\"0x...\"},\"signature\":\"0x...\"},\"vote_b\":{...}}")
+	cmd.Flags().String(flagEvidenceFile, "", "File of evidence details, if evidence-file is not empty, --evidence will be ignored")
+	return cmd
+}
+
 // GetCmdSideChainUnjail implements the create unjail validator command.
 func GetCmdSideChainUnjail(cdc *codec.Codec) *cobra.Command {
 	cmd := &cobra.Command{
@@ -1,7 +1,10 @@
 package rest
 
 import (
+	"encoding/json"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/client/utils"
@@ -16,6 +19,21 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Co
 		"/slashing/validators/{validatorPubKey}/signing_info",
 		signingInfoHandlerFn(cliCtx, "slashing", cdc),
 	).Methods("GET")
+
+	r.HandleFunc(
+		"/slashing/slash_records",
+		slashRecordsHandlerFn(cliCtx, cdc),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/slashing/validators/{validatorPubKey}/uptime",
+		validatorUptimeHandlerFn(cliCtx, cdc),
+	).Methods("GET")
+
+	r.HandleFunc(
+		"/slashing/validators_at_risk",
+		validatorsAtRiskHandlerFn(cliCtx, cdc),
+	).Methods("GET")
 }
 
 // http request handler to query signing info
@@ -54,3 +72,139 @@ func signingInfoHandlerFn(cliCtx context.CLIContext, storeName string, cdc *code
 		utils.PostProcessResponse(w, cdc, signingInfo, cliCtx.Indent)
 	}
 }
+
+// http request handler to query a page of slash records, filtered by the query parameters side_chain_id,
+// infraction_type, from_height, to_height, from_time and to_time in RFC3339, and paginated by cursor in hex and limit
+func slashRecordsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		params := slashing.QuerySlashRecordsParams{
+			BaseParams: slashing.NewBaseParams(query.Get("side_chain_id")),
+		}
+
+		if infractionType := query.Get("infraction_type"); len(infractionType) != 0 {
+			resType, err := slashing.ParseInfractionType(infractionType)
+			if err != nil {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			params.InfractionType = &resType
+		}
+		for name, height := range map[string]*int64{"from_height": &params.FromHeight, "to_height": &params.ToHeight} {
+			if str := query.Get(name); len(str) != 0 {
+				n, ok := utils.ParseInt64OrReturnBadRequest(w, str)
+				if !ok {
+					return
+				}
+				*height = n
+			}
+		}
+		for name, t := range map[string]*time.Time{"from_time": &params.FromTime, "to_time": &params.ToTime} {
+			if str := query.Get(name); len(str) != 0 {
+				parsed, err := time.Parse(time.RFC3339, str)
+				if err != nil {
+					utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+					return
+				}
+				*t = parsed
+			}
+		}
+		if cursor := query.Get("cursor"); len(cursor) != 0 {
+			bz, err := sdk.HexDecode(cursor)
+			if err != nil {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			params.Cursor = bz
+		}
+		if limit := query.Get("limit"); len(limit) != 0 {
+			n, err := strconv.Atoi(limit)
+			if err != nil {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			params.Limit = n
+		}
+
+		bz, err := json.Marshal(params)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, err := cliCtx.QueryWithData("custom/slashing/"+slashing.QuerySlashRecords, bz)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
+
+// http request handler to query the uptime of a validator over the height range from_height to to_height
+func validatorUptimeHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pk, err := sdk.GetConsPubKeyBech32(mux.Vars(r)["validatorPubKey"])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		params := slashing.QueryValidatorUptimeParams{ConsAddr: pk.Address()}
+		query := r.URL.Query()
+		for name, height := range map[string]*int64{"from_height": &params.FromHeight, "to_height": &params.ToHeight} {
+			if str := query.Get(name); len(str) != 0 {
+				n, ok := utils.ParseInt64OrReturnBadRequest(w, str)
+				if !ok {
+					return
+				}
+				*height = n
+			}
+		}
+
+		bz, err := json.Marshal(params)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, err := cliCtx.QueryWithData("custom/slashing/"+slashing.QueryValidatorUptime, bz)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
+
+// http request handler to query the validators which missed at least the fraction threshold, 0.8 by default, of the
+// blocks they may miss before being slashed for downtime
+func validatorsAtRiskHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		thresholdStr := r.URL.Query().Get("threshold")
+		if len(thresholdStr) == 0 {
+			thresholdStr = "0.8"
+		}
+		threshold, sdkErr := sdk.NewDecFromStr(thresholdStr)
+		if sdkErr != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, sdkErr.Error())
+			return
+		}
+
+		bz, err := json.Marshal(slashing.QueryValidatorsAtRiskParams{Threshold: threshold})
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		res, err := cliCtx.QueryWithData("custom/slashing/"+slashing.QueryValidatorsAtRisk, bz)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
@@ -28,6 +28,11 @@ func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec
 		"/slashing/bsc/evidence/submit",
 		bscEvidenceSubmitRequestHandlerFn(cdc, kb, cliCtx),
 	).Methods("POST")
+
+	r.HandleFunc(
+		"/slashing/bsc/evidences/submit",
+		bscEvidencesSubmitRequestHandlerFn(cdc, kb, cliCtx),
+	).Methods("POST")
 }
 
 // Unjail TX body
@@ -158,3 +163,54 @@ func bscEvidenceSubmitRequestHandlerFn(cdc *codec.Codec, kb keys.Keybase, cliCtx
 	}
 
 }
+
+type EvidencesSubmitReq struct {
+	BaseReq   utils.BaseReq                    `json:"base_req"`
+	Submitter string                           `json:"submitter"` // in bech 32
+	Evidences []slashing.BscDoubleSignEvidence `json:"evidences"`
+}
+
+func bscEvidencesSubmitRequestHandlerFn(cdc *codec.Codec, kb keys.Keybase, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		var req EvidencesSubmitReq
+		err = json.Unmarshal(body, &req)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		baseReq := req.BaseReq.Sanitize()
+		if !baseReq.ValidateBasic(w) {
+			return
+		}
+
+		info, err := kb.Get(baseReq.Name)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+
+		submitter, err := sdk.AccAddressFromBech32(req.Submitter)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if !bytes.Equal(info.GetPubKey().Address(), submitter) {
+			utils.WriteErrorResponse(w, http.StatusUnauthorized, "Must use own submitter address")
+			return
+		}
+
+		msg := slashing.NewMsgBscSubmitEvidences(submitter, req.Evidences)
+		if err := msg.ValidateBasic(); err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		utils.CompleteAndBroadcastTxREST(w, r, cliCtx, baseReq, []sdk.Msg{msg}, cdc)
+	}
+}
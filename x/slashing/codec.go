@@ -9,6 +9,8 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgUnjail{}, "cosmos-sdk/MsgUnjail", nil)
 	cdc.RegisterConcrete(MsgSideChainUnjail{}, "cosmos-sdk/MsgSideChainUnjail", nil)
 	cdc.RegisterConcrete(MsgBscSubmitEvidence{}, "cosmos-sdk/MsgBscSubmitEvidence", nil)
+	cdc.RegisterConcrete(MsgBscSubmitEvidences{}, "cosmos-sdk/MsgBscSubmitEvidences", nil)
+	cdc.RegisterConcrete(MsgBscSubmitVoteEvidence{}, "cosmos-sdk/MsgBscSubmitVoteEvidence", nil)
 	cdc.RegisterConcrete(&Params{}, "params/SlashParamSet", nil)
 }
 
@@ -21,6 +21,7 @@ const (
 	CodeMissingSelfDelegation        CodeType = 104
 	CodeSelfDelegationTooLowToUnjail CodeType = 105
 	CodeInvalidClaim                 CodeType = 106
+	CodeValidatorTombstoned          CodeType = 107
 
 	CodeExpiredEvidence             CodeType = 201
 	CodeFailSlash                   CodeType = 202
@@ -47,6 +48,10 @@ func ErrValidatorJailed(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeValidatorJailed, "validator still jailed, cannot yet be unjailed")
 }
 
+func ErrValidatorTombstoned(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeValidatorTombstoned, "validator tombstoned, cannot be unjailed")
+}
+
 func ErrValidatorNotJailed(codespace sdk.CodespaceType) sdk.Error {
 	return sdk.NewError(codespace, CodeValidatorNotJailed, "validator not jailed, cannot be unjailed")
 }
@@ -0,0 +1,88 @@
+package slashing
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// TombstoneJailedUntil is the jail time of the tombstoned validators, which are never unjailed.
+var TombstoneJailedUntil = time.Unix(253402300799, 0).UTC() // 9999-12-31T23:59:59Z
+
+// offenseMultiplier returns the multiplier of the next slash of a validator, which grows by OffenseEscalationRate
+// with every slash of the validator in the last OffenseLookbackBlocks blocks, capped by MaxOffenseMultiplier if set.
+func (k Keeper) offenseMultiplier(ctx sdk.Context, consAddr []byte) sdk.Dec {
+	if !sdk.IsUpgrade(sdk.ProgressiveSlashing) {
+		return sdk.OneDec()
+	}
+	lookback := k.OffenseLookbackBlocks(ctx)
+	rate := k.OffenseEscalationRate(ctx)
+	if lookback <= 0 || !rate.GT(sdk.ZeroDec()) {
+		return sdk.OneDec()
+	}
+
+	since := ctx.BlockHeight() - lookback
+	var offenses int64
+	for _, record := range k.getSlashRecordsByConsAddr(ctx, consAddr) {
+		if record.SlashHeight > since {
+			offenses++
+		}
+	}
+	multiplier := sdk.OneDec().Add(rate.Mul(sdk.NewDecWithoutFra(offenses)))
+	if maxMultiplier := k.MaxOffenseMultiplier(ctx); !maxMultiplier.IsZero() && multiplier.GT(maxMultiplier) {
+		multiplier = maxMultiplier
+	}
+	return multiplier
+}
+
+// escalateSlashAmount returns the side chain slash amount of a validator escalated by its prior offenses.
+func (k Keeper) escalateSlashAmount(ctx sdk.Context, consAddr []byte, slashAmt int64) int64 {
+	return sdk.NewDec(slashAmt).Mul(k.offenseMultiplier(ctx, consAddr)).RawInt()
+}
+
+// escalateSlashFraction returns the slash fraction of a validator escalated by its prior offenses, which is at most 1.
+func (k Keeper) escalateSlashFraction(ctx sdk.Context, consAddr []byte, fraction sdk.Dec) sdk.Dec {
+	fraction = fraction.Mul(k.offenseMultiplier(ctx, consAddr))
+	if fraction.GT(sdk.OneDec()) {
+		return sdk.OneDec()
+	}
+	return fraction
+}
+
+// tombstoneIfRepeated tombstones a validator once its recorded double signs reach TombstoneDoubleSigns, so that it
+// can never be unjailed. The malicious votes count as double signs, as they are the double signs of fast finality.
+// It tells if the validator is tombstoned.
+func (k Keeper) tombstoneIfRepeated(ctx sdk.Context, consAddr []byte, signInfo *ValidatorSigningInfo) bool {
+	if !sdk.IsUpgrade(sdk.ProgressiveSlashing) {
+		return false
+	}
+	limit := k.TombstoneDoubleSigns(ctx)
+	if limit <= 0 {
+		return false
+	}
+	doubleSigns := len(k.getSlashRecordsByConsAddrAndType(ctx, consAddr, DoubleSign)) +
+		len(k.getSlashRecordsByConsAddrAndType(ctx, consAddr, MaliciousVote))
+	if int64(doubleSigns) < limit {
+		return false
+	}
+	signInfo.Tombstoned = true
+	signInfo.JailedUntil = TombstoneJailedUntil
+	return true
+}
+
+// setBCSlashRecord records a slash of a beacon chain validator once progressive slashing is enabled, so that it
+// escalates the next slashes of the validator as the side chain slashes do.
+func (k Keeper) setBCSlashRecord(ctx sdk.Context, consAddr sdk.ConsAddress, infractionType byte, infractionHeight int64,
+	jailUntil time.Time, slashAmt sdk.Dec) {
+	if !sdk.IsUpgrade(sdk.ProgressiveSlashing) {
+		return
+	}
+	k.setSlashRecord(ctx, SlashRecord{
+		ConsAddr:         consAddr.Bytes(),
+		InfractionType:   infractionType,
+		InfractionHeight: uint64(infractionHeight),
+		SlashHeight:      ctx.BlockHeight(),
+		JailUntil:        jailUntil,
+		SlashAmt:         slashAmt.RawInt(),
+	})
+}
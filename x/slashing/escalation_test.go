@@ -0,0 +1,122 @@
+package slashing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func TestProgressiveSlashing(t *testing.T) {
+	params := keeperTestParams()
+	params.OffenseLookbackBlocks = 100
+	params.OffenseEscalationRate = sdk.OneDec()
+	params.TombstoneDoubleSigns = 2
+	ctx, _, sk, _, keeper := createTestInput(t, params)
+	amt := sdk.NewDecWithoutFra(100).RawInt()
+	operatorAddr, val := addrs[0], pks[0]
+	got := stake.NewStakeHandler(sk)(ctx, NewTestMsgCreateValidator(operatorAddr, val, amt))
+	require.True(t, got.IsOK())
+	validatorUpdates, _ := stake.EndBlocker(ctx, sk)
+	keeper.AddValidators(ctx, validatorUpdates)
+	consAddr := sdk.ConsAddress(val.Address())
+	keeper.handleValidatorSignature(ctx, val.Address(), amt, true)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ProgressiveSlashing, 199)
+	sdk.UpgradeMgr.SetHeight(200)
+
+	ctx = ctx.WithBlockHeight(10)
+	keeper.handleDoubleSign(ctx, val.Address(), 5, time.Unix(0, 0), amt)
+	require.True(t, sk.Validator(ctx, operatorAddr).GetJailed())
+	records := keeper.getSlashRecordsByConsAddr(ctx, consAddr)
+	require.Len(t, records, 1)
+	require.EqualValues(t, 10, records[0].SlashHeight)
+	require.EqualValues(t, amt/20, records[0].SlashAmt)
+	info, found := keeper.getValidatorSigningInfo(ctx, consAddr)
+	require.True(t, found)
+	require.False(t, info.Tombstoned)
+
+	// the prior slash doubles the next one within the lookback window, up to the max multiplier
+	fraction := keeper.SlashFractionDoubleSign(ctx)
+	require.Equal(t, fraction.Mul(sdk.NewDecWithoutFra(2)), keeper.escalateSlashFraction(ctx, consAddr, fraction))
+	require.EqualValues(t, 2*keeper.DowntimeSlashAmount(ctx), keeper.escalateSlashAmount(ctx, consAddr, keeper.DowntimeSlashAmount(ctx)))
+	params.MaxOffenseMultiplier = sdk.NewDecWithPrec(15, 1)
+	keeper.SetParams(ctx, params)
+	require.Equal(t, fraction.Mul(sdk.NewDecWithPrec(15, 1)), keeper.escalateSlashFraction(ctx, consAddr, fraction))
+	require.Equal(t, fraction, keeper.escalateSlashFraction(ctx.WithBlockHeight(110), consAddr, fraction))
+
+	// the second double sign tombstones the validator
+	ctx = ctx.WithBlockHeight(20)
+	keeper.handleDoubleSign(ctx, val.Address(), 15, time.Unix(0, 0), amt)
+	info, found = keeper.getValidatorSigningInfo(ctx, consAddr)
+	require.True(t, found)
+	require.True(t, info.Tombstoned)
+	require.Equal(t, TombstoneJailedUntil, info.JailedUntil)
+
+	err := keeper.Unjail(ctx.WithBlockTime(TombstoneJailedUntil.Add(time.Second)), operatorAddr)
+	require.NotNil(t, err)
+	require.EqualValues(t, CodeValidatorTombstoned, err.Code())
+}
+
+func TestSideChainProgressiveSlashing(t *testing.T) {
+	params := DefaultParams()
+	params.MaxEvidenceAge = 12 * 60 * 60 * time.Second
+	params.OffenseLookbackBlocks = 1000
+	params.OffenseEscalationRate = sdk.NewDecWithPrec(5, 1)
+	ctx, sideCtx, _, stakeKeeper, _, keeper := createSideTestInput(t, params)
+
+	bondAmount := int64(10000e8)
+	sideConsAddr, sideFeeAddr := createSideAddr(20), createSideAddr(20)
+	msgCreateVal := newTestMsgCreateSideValidator(addrs[0], sideConsAddr, sideFeeAddr, bondAmount)
+	got := stake.NewHandler(stakeKeeper, gov.Keeper{})(ctx, msgCreateVal)
+	require.True(t, got.IsOK(), "expected create validator msg to be ok, got: %v", got)
+	stake.EndBreatheBlock(ctx, stakeKeeper)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.ProgressiveSlashing, 199)
+	sdk.UpgradeMgr.SetHeight(200)
+
+	claim := SideSlashPackage{
+		SideAddr:      sideConsAddr,
+		SideHeight:    100,
+		SideChainId:   sdk.ChainID(1),
+		SideTimestamp: uint64(ctx.BlockHeader().Time.Add(-6 * 60 * 60 * time.Second).Unix()),
+	}
+	require.Nil(t, keeper.slashingSideDowntime(ctx, &claim))
+	claim.SideHeight = 200
+	require.Nil(t, keeper.slashingSideDowntime(ctx, &claim))
+
+	// the second downtime is slashed 1.5 times as the first one
+	first, found := keeper.getSlashRecord(sideCtx, sideConsAddr, Downtime, 100)
+	require.True(t, found)
+	require.EqualValues(t, params.DowntimeSlashAmount, first.SlashAmt)
+	second, found := keeper.getSlashRecord(sideCtx, sideConsAddr, Downtime, 200)
+	require.True(t, found)
+	require.EqualValues(t, params.DowntimeSlashAmount*3/2, second.SlashAmt)
+
+	validator, found := stakeKeeper.GetValidatorBySideConsAddr(sideCtx, sideConsAddr)
+	require.True(t, found)
+	require.EqualValues(t, bondAmount-first.SlashAmt-second.SlashAmt, validator.Tokens.RawInt())
+
+	// malicious votes count as double signs to tombstone
+	params.TombstoneDoubleSigns = 2
+	keeper.SetParams(sideCtx, params)
+	info, found := keeper.getValidatorSigningInfo(sideCtx, sideConsAddr)
+	require.True(t, found)
+	keeper.setSlashRecord(sideCtx, SlashRecord{ConsAddr: sideConsAddr, InfractionType: DoubleSign, InfractionHeight: 300, SideChainId: "bsc"})
+	require.False(t, keeper.tombstoneIfRepeated(sideCtx, sideConsAddr, &info))
+	keeper.setSlashRecord(sideCtx, SlashRecord{ConsAddr: sideConsAddr, InfractionType: MaliciousVote, InfractionHeight: 300, SideChainId: "bsc"})
+	require.True(t, keeper.tombstoneIfRepeated(sideCtx, sideConsAddr, &info))
+	keeper.setValidatorSigningInfo(sideCtx, sideConsAddr, info)
+
+	// a downtime does not shorten the jail of a tombstoned validator
+	claim.SideHeight = 400
+	require.Nil(t, keeper.slashingSideDowntime(ctx, &claim))
+	info, found = keeper.getValidatorSigningInfo(sideCtx, sideConsAddr)
+	require.True(t, found)
+	require.True(t, info.Tombstoned)
+	require.Equal(t, TombstoneJailedUntil, info.JailedUntil)
+}
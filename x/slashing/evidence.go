@@ -0,0 +1,184 @@
+package slashing
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/bsc"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// BscDoubleSignEvidence is the evidence of a bsc validator sealing two different headers of the same number,
+// whatever their parents are.
+type BscDoubleSignEvidence struct {
+	HeaderA bsc.Header `json:"header_a"`
+	HeaderB bsc.Header `json:"header_b"`
+}
+
+func (e BscDoubleSignEvidence) ValidateBasic() sdk.Error {
+	if err := headerEmptyCheck(e.HeaderA); err != nil {
+		return err
+	}
+	if err := headerEmptyCheck(e.HeaderB); err != nil {
+		return err
+	}
+	if e.HeaderA.Number != e.HeaderB.Number {
+		return ErrInvalidEvidence(DefaultCodespace, "The numbers of two block headers are not the same")
+	}
+	if e.HeaderA.Hash() == e.HeaderB.Hash() {
+		return ErrInvalidEvidence(DefaultCodespace, "The two blocks are the same")
+	}
+	return nil
+}
+
+// Number returns the number of the headers of the evidence.
+func (e BscDoubleSignEvidence) Number() int64 {
+	return e.HeaderA.Number
+}
+
+// Time returns the time of the latest header of the evidence, from which the evidence ages.
+func (e BscDoubleSignEvidence) Time() uint64 {
+	if e.HeaderA.Time < e.HeaderB.Time {
+		return e.HeaderB.Time
+	}
+	return e.HeaderA.Time
+}
+
+// BscEvidenceID returns the id of the double sign of a bsc validator at a number. It does not depend on the headers
+// proving the double sign, so that it is punished once whichever pair of headers is submitted.
+func BscEvidenceID(signer bsc.Address, number int64) []byte {
+	numberBz := make([]byte, 8)
+	binary.BigEndian.PutUint64(numberBz, uint64(number))
+	return bsc.Keccak256(signer.Bytes(), numberBz)
+}
+
+func (k Keeper) hasBscEvidence(ctx sdk.Context, evidenceId []byte) bool {
+	return ctx.KVStore(k.storeKey).Has(GetBscEvidenceKey(evidenceId))
+}
+
+// GetBscEvidence returns the height at which the bsc evidence of an id was handled.
+func (k Keeper) GetBscEvidence(ctx sdk.Context, evidenceId []byte) (int64, bool) {
+	bz := ctx.KVStore(k.storeKey).Get(GetBscEvidenceKey(evidenceId))
+	if bz == nil {
+		return 0, false
+	}
+	var slashHeight int64
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &slashHeight)
+	return slashHeight, true
+}
+
+func (k Keeper) setBscEvidence(ctx sdk.Context, evidenceId []byte, slashHeight int64) {
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(slashHeight)
+	ctx.KVStore(k.storeKey).Set(GetBscEvidenceKey(evidenceId), bz)
+}
+
+// verifyBscDoubleSign verifies that the headers of evidence are sealed by the same validator, and returns it.
+func (k Keeper) verifyBscDoubleSign(ctx sdk.Context, sideChainId string, chainID *big.Int, evidence *BscDoubleSignEvidence) (bsc.Address, sdk.Error) {
+	signer, err := evidence.HeaderA.ExtractSignerFromHeader(chainID)
+	if err != nil {
+		return bsc.Address{}, ErrInvalidEvidence(k.Codespace, fmt.Sprintf("Failed to extract signer from block header, %s", err.Error()))
+	}
+	signer2, err := evidence.HeaderB.ExtractSignerFromHeader(chainID)
+	if err != nil {
+		return bsc.Address{}, ErrInvalidEvidence(k.Codespace, fmt.Sprintf("Failed to extract signer from block header, %s", err.Error()))
+	}
+	if signer != signer2 {
+		return bsc.Address{}, ErrInvalidEvidence(k.Codespace, "The signers of two block headers are not the same")
+	}
+	if evidence.HeaderA.Coinbase != signer || evidence.HeaderB.Coinbase != signer {
+		return bsc.Address{}, ErrInvalidEvidence(k.Codespace, "The signer of block headers is not their coinbase")
+	}
+	if err := k.verifyBscSigner(ctx, sideChainId, signer, evidence); err != nil {
+		return bsc.Address{}, err
+	}
+	return signer, nil
+}
+
+// verifyBscSigner verifies that the signer of the headers of evidence is in the validator set of their number
+// tracked by the light client of the side chain, and that their difficulties tell the turn of the signer as
+// Parlia does. The evidences whose validator set is not tracked are rejected.
+func (k Keeper) verifyBscSigner(ctx sdk.Context, sideChainId string, signer bsc.Address, evidence *BscDoubleSignEvidence) sdk.Error {
+	if k.LightClientKeeper == nil {
+		return ErrInvalidEvidence(k.Codespace, fmt.Sprintf("The validator set of block header %d is unknown", evidence.Number()))
+	}
+	destChainId, err := k.ScKeeper.GetDestChainID(sideChainId)
+	if err != nil {
+		return ErrInvalidSideChainId(k.Codespace)
+	}
+	validatorSet, found := k.LightClientKeeper.GetValidatorSet(ctx, destChainId, evidence.Number())
+	if !found {
+		return ErrInvalidEvidence(k.Codespace, fmt.Sprintf("The validator set of block header %d is unknown", evidence.Number()))
+	}
+	isValidator := false
+	for _, validator := range validatorSet.Validators {
+		if validator == signer {
+			isValidator = true
+			break
+		}
+	}
+	if !isValidator {
+		return ErrInvalidEvidence(k.Codespace, fmt.Sprintf("The signer %s is not a validator of block header %d", signer.String(), evidence.Number()))
+	}
+	difficulty := int64(bsc.DiffNoTurn)
+	if bsc.InTurnValidator(validatorSet.Validators, evidence.Number()) == signer {
+		difficulty = bsc.DiffInTurn
+	}
+	if evidence.HeaderA.Difficulty != difficulty || evidence.HeaderB.Difficulty != difficulty {
+		return ErrInvalidEvidence(k.Codespace, fmt.Sprintf("The difficulty of block headers is not %d", difficulty))
+	}
+	return nil
+}
+
+func handleMsgBscSubmitEvidences(ctx sdk.Context, msg MsgBscSubmitEvidences, k Keeper) sdk.Result {
+	if !sdk.IsUpgrade(sdk.BscDoubleSignEvidences) {
+		return sdk.ErrMsgNotSupported("").Result()
+	}
+	sideChainId := k.ScKeeper.BscSideChainId(ctx)
+	sideCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, sideChainId)
+	if err != nil {
+		return ErrInvalidSideChainId(DefaultCodespace).Result()
+	}
+	chainID, err := SideChainIdFromText(sideChainId)
+	if err != nil {
+		return ErrInvalidEvidence(DefaultCodespace, err.Error()).Result()
+	}
+
+	// all the evidences are verified before any slash, so that a batch is handled as a whole
+	signers := make([]bsc.Address, len(msg.Evidences))
+	evidenceIds := make(map[string]bool, len(msg.Evidences))
+	for i := range msg.Evidences {
+		evidence := &msg.Evidences[i]
+		signer, sdkErr := k.verifyBscDoubleSign(ctx, sideChainId, chainID, evidence)
+		if sdkErr != nil {
+			return sdkErr.Result()
+		}
+		evidenceId := string(BscEvidenceID(signer, evidence.Number()))
+		if evidenceIds[evidenceId] || k.hasBscEvidence(sideCtx, []byte(evidenceId)) ||
+			k.hasSlashRecord(sideCtx, signer.Bytes(), DoubleSign, uint64(evidence.Number())) {
+			return ErrEvidenceHasBeenHandled(k.Codespace).Result()
+		}
+		age := sideCtx.BlockHeader().Time.Sub(time.Unix(int64(evidence.Time()), 0))
+		if age > k.MaxEvidenceAge(sideCtx) {
+			return ErrExpiredEvidence(k.Codespace).Result()
+		}
+		evidenceIds[evidenceId] = true
+		signers[i] = signer
+	}
+
+	events := make([]SideSlashEvent, 0, len(msg.Evidences))
+	for i, evidence := range msg.Evidences {
+		event, sdkErr := k.slashBscDoubleSign(ctx, sideCtx, sideChainId, msg.Submitter, signers[i], evidence.Number(), evidence.Time())
+		if sdkErr != nil {
+			return sdkErr.Result()
+		}
+		events = append(events, event)
+	}
+	if ctx.IsDeliverTx() && k.PbsbServer != nil {
+		for _, event := range events {
+			k.PbsbServer.Publish(event)
+		}
+	}
+	return sdk.Result{}
+}
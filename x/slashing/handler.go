@@ -35,6 +35,10 @@ func NewHandler(k Keeper) sdk.Handler {
 			return handleMsgSideChainUnjail(ctx, msg, k)
 		case MsgBscSubmitEvidence:
 			return handleMsgBscSubmitEvidence(ctx, msg, k)
+		case MsgBscSubmitEvidences:
+			return handleMsgBscSubmitEvidences(ctx, msg, k)
+		case MsgBscSubmitVoteEvidence:
+			return handleMsgBscSubmitVoteEvidence(ctx, msg, k)
 		case MsgUnjail:
 			return handleMsgUnjail(ctx, msg, k)
 		default:
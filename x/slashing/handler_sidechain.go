@@ -22,8 +22,6 @@ func handleMsgBscSubmitEvidence(ctx sdk.Context, msg MsgBscSubmitEvidence, k Kee
 		return ErrInvalidEvidence(DefaultCodespace, err.Error()).Result()
 	}
 
-	header := ctx.BlockHeader()
-
 	var sideConsAddr bsc.Address
 	var sideConsAddr2 bsc.Address
 	var err2 error
@@ -45,8 +43,11 @@ func handleMsgBscSubmitEvidence(ctx sdk.Context, msg MsgBscSubmitEvidence, k Kee
 		return ErrInvalidEvidence(DefaultCodespace, "The signers of two block headers are not the same").Result()
 	}
 
-	if k.hasSlashRecord(sideCtx, sideConsAddr.Bytes(), DoubleSign, uint64(msg.Headers[0].Number)) {
-		return ErrEvidenceHasBeenHandled(k.Codespace).Result()
+	if sdk.IsUpgrade(sdk.BscDoubleSignEvidences) {
+		evidence := BscDoubleSignEvidence{HeaderA: msg.Headers[0], HeaderB: msg.Headers[1]}
+		if _, err := k.verifyBscDoubleSign(ctx, sideChainId, chainID, &evidence); err != nil {
+			return err.Result()
+		}
 	}
 
 	//verify evidence age
@@ -54,15 +55,42 @@ func handleMsgBscSubmitEvidence(ctx sdk.Context, msg MsgBscSubmitEvidence, k Kee
 	if msg.Headers[0].Time < msg.Headers[1].Time {
 		evidenceTime = msg.Headers[1].Time
 	}
+	event, sdkErr := k.slashBscDoubleSign(ctx, sideCtx, sideChainId, msg.Submitter, sideConsAddr, msg.Headers[0].Number, evidenceTime)
+	if sdkErr != nil {
+		return sdkErr.Result()
+	}
+	if ctx.IsDeliverTx() && k.PbsbServer != nil {
+		k.PbsbServer.Publish(event)
+	}
+	return sdk.Result{}
+}
+
+// slashBscDoubleSign slashes and jails the side validator of sideConsAddr for sealing two headers of number, and
+// rewards the submitter of the evidence. It returns the event of the slash to publish.
+func (k Keeper) slashBscDoubleSign(ctx sdk.Context, sideCtx sdk.Context, sideChainId string, submitter sdk.AccAddress,
+	sideConsAddr bsc.Address, number int64, evidenceTime uint64) (SideSlashEvent, sdk.Error) {
+	header := ctx.BlockHeader()
+
+	if k.hasSlashRecord(sideCtx, sideConsAddr.Bytes(), DoubleSign, uint64(number)) {
+		return SideSlashEvent{}, ErrEvidenceHasBeenHandled(k.Codespace)
+	}
+	var evidenceId []byte
+	if sdk.IsUpgrade(sdk.BscDoubleSignEvidences) {
+		evidenceId = BscEvidenceID(sideConsAddr, number)
+		if k.hasBscEvidence(sideCtx, evidenceId) {
+			return SideSlashEvent{}, ErrEvidenceHasBeenHandled(k.Codespace)
+		}
+	}
+
 	age := sideCtx.BlockHeader().Time.Sub(time.Unix(int64(evidenceTime), 0))
 	if age > k.MaxEvidenceAge(sideCtx) {
-		return ErrExpiredEvidence(k.Codespace).Result()
+		return SideSlashEvent{}, ErrExpiredEvidence(k.Codespace)
 	}
 
-	slashAmount := k.DoubleSignSlashAmount(sideCtx)
+	slashAmount := k.escalateSlashAmount(sideCtx, sideConsAddr.Bytes(), k.DoubleSignSlashAmount(sideCtx))
 	validator, slashedAmount, slashErr := k.validatorSet.SlashSideChain(ctx, sideChainId, sideConsAddr.Bytes(), sdk.NewDec(slashAmount))
 	if slashErr != nil {
-		return ErrFailedToSlash(k.Codespace, slashErr.Error()).Result()
+		return SideSlashEvent{}, ErrFailedToSlash(k.Codespace, slashErr.Error())
 	}
 
 	bondDenom := k.validatorSet.BondDenom(sideCtx)
@@ -71,9 +99,9 @@ func handleMsgBscSubmitEvidence(ctx sdk.Context, msg MsgBscSubmitEvidence, k Kee
 	submitterRewardCoin := sdk.NewCoin(bondDenom, submitterRewardReal)
 
 	if submitterRewardReal > 0 {
-		submitterBalance := k.BankKeeper.GetCoins(ctx, msg.Submitter)
-		if err := k.BankKeeper.SetCoins(ctx, msg.Submitter, submitterBalance.Plus(sdk.Coins{submitterRewardCoin})); err != nil {
-			return ErrFailedToSlash(k.Codespace, err.Error()).Result()
+		submitterBalance := k.BankKeeper.GetCoins(ctx, submitter)
+		if err := k.BankKeeper.SetCoins(ctx, submitter, submitterBalance.Plus(sdk.Coins{submitterRewardCoin})); err != nil {
+			return SideSlashEvent{}, ErrFailedToSlash(k.Codespace, err.Error())
 		}
 	}
 
@@ -81,10 +109,11 @@ func handleMsgBscSubmitEvidence(ctx sdk.Context, msg MsgBscSubmitEvidence, k Kee
 	var toFeePool int64
 	var validatorsCompensation map[string]int64
 	var found bool
+	var err error
 	if remainingReward > 0 {
 		found, validatorsCompensation, err = k.validatorSet.AllocateSlashAmtToValidators(sideCtx, sideConsAddr.Bytes(), sdk.NewDec(remainingReward))
 		if err != nil {
-			return ErrFailedToSlash(k.Codespace, err.Error()).Result()
+			return SideSlashEvent{}, ErrFailedToSlash(k.Codespace, err.Error())
 		}
 		if !found && ctx.IsDeliverTx() { // if the related validators are not found, the amount will be added to fee pool
 			toFeePool = remainingReward
@@ -97,13 +126,16 @@ func handleMsgBscSubmitEvidence(ctx sdk.Context, msg MsgBscSubmitEvidence, k Kee
 	sr := SlashRecord{
 		ConsAddr:         sideConsAddr.Bytes(),
 		InfractionType:   DoubleSign,
-		InfractionHeight: uint64(msg.Headers[0].Number),
+		InfractionHeight: uint64(number),
 		SlashHeight:      header.Height,
 		JailUntil:        jailUntil,
 		SlashAmt:         slashedAmount.RawInt(),
 		SideChainId:      sideChainId,
 	}
 	k.setSlashRecord(sideCtx, sr)
+	if evidenceId != nil {
+		k.setBscEvidence(sideCtx, evidenceId, header.Height)
+	}
 
 	// Set or updated validator jail duration
 	signInfo, found := k.getValidatorSigningInfo(sideCtx, sideConsAddr.Bytes())
@@ -111,25 +143,24 @@ func handleMsgBscSubmitEvidence(ctx sdk.Context, msg MsgBscSubmitEvidence, k Kee
 		panic(fmt.Sprintf("Expected signing info for validator %s but not found", sideConsAddr.Hex()))
 	}
 	signInfo.JailedUntil = jailUntil
+	if k.tombstoneIfRepeated(sideCtx, sideConsAddr.Bytes(), &signInfo) {
+		ctx.Logger().With("module", "x/slashing").Info(fmt.Sprintf("Side validator %s tombstoned for repeated double signs", sideConsAddr.Hex()))
+	}
 	k.setValidatorSigningInfo(sideCtx, sideConsAddr.Bytes(), signInfo)
 
-	if ctx.IsDeliverTx() && k.PbsbServer != nil {
-		event := SideSlashEvent{
-			Validator:              validator.GetOperator(),
-			InfractionType:         DoubleSign,
-			InfractionHeight:       msg.Headers[0].Number,
-			SlashHeight:            header.Height,
-			JailUtil:               jailUntil,
-			SlashAmt:               slashedAmount.RawInt(),
-			SideChainId:            sideChainId,
-			ToFeePool:              toFeePool,
-			Submitter:              msg.Submitter,
-			SubmitterReward:        submitterRewardReal,
-			ValidatorsCompensation: validatorsCompensation,
-		}
-		k.PbsbServer.Publish(event)
-	}
-	return sdk.Result{}
+	return SideSlashEvent{
+		Validator:              validator.GetOperator(),
+		InfractionType:         DoubleSign,
+		InfractionHeight:       number,
+		SlashHeight:            header.Height,
+		JailUtil:               jailUntil,
+		SlashAmt:               slashedAmount.RawInt(),
+		SideChainId:            sideChainId,
+		ToFeePool:              toFeePool,
+		Submitter:              submitter,
+		SubmitterReward:        submitterRewardReal,
+		ValidatorsCompensation: validatorsCompensation,
+	}, nil
 }
 
 func handleMsgSideChainUnjail(ctx sdk.Context, msg MsgSideChainUnjail, k Keeper) sdk.Result {
@@ -6,10 +6,16 @@ import (
 	"testing"
 	"time"
 
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+	abci "github.com/tendermint/tendermint/abci/types"
+
 	"github.com/cosmos/cosmos-sdk/bsc"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/fees"
 	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/lightclient"
 	"github.com/cosmos/cosmos-sdk/x/stake"
 
 	"github.com/stretchr/testify/require"
@@ -190,3 +196,269 @@ func TestSideChainSlashDoubleSignUBD(t *testing.T) {
 	require.EqualValues(t, 4000e8, stakingPoolBalance)
 
 }
+
+type testBscSigner struct {
+	priv *btcec.PrivateKey
+	addr bsc.Address
+}
+
+func newTestBscSigner(seed byte) testBscSigner {
+	bz := make([]byte, 32)
+	bz[31] = seed
+	priv, pub := btcec.PrivKeyFromBytes(bz)
+	var signer testBscSigner
+	signer.priv = priv
+	copy(signer.addr[:], bsc.Keccak256(pub.SerializeUncompressed()[1:])[12:])
+	return signer
+}
+
+// newTestBscHeader returns a header of number sealed by signer for bsc, headers differing by gasUsed
+func newTestBscHeader(t *testing.T, signer testBscSigner, number int64, difficulty int64, gasUsed uint64) bsc.Header {
+	header := bsc.Header{
+		Coinbase:   signer.addr,
+		Difficulty: difficulty,
+		Number:     number,
+		GasLimit:   30000000,
+		GasUsed:    gasUsed,
+		Time:       uint64(time.Now().Unix()),
+	}
+	return sealTestBscHeader(t, signer, header)
+}
+
+// sealTestBscHeader seals header by signer for bsc, whatever its coinbase is
+func sealTestBscHeader(t *testing.T, signer testBscSigner, header bsc.Header) bsc.Header {
+	header.Extra = make([]byte, 97)
+	sig, err := ecdsa.SignCompact(signer.priv, bsc.SealHash(&header, SideChainIdBsc).Bytes(), false)
+	require.NoError(t, err)
+	copy(header.Extra[32:], sig[1:])
+	header.Extra[96] = sig[0] - 27
+	return header
+}
+
+func TestSideChainSlashDoubleSignEvidences(t *testing.T) {
+	slashParams := DefaultParams()
+	slashParams.MaxEvidenceAge = math.MaxInt64
+	slashParams.DoubleSignSlashAmount = 6000e8
+	slashParams.SubmitterReward = 3000e8
+	submitter := sdk.AccAddress(addrs[2])
+	ctx, sideCtx, bankKeeper, stakeKeeper, _, keeper := createSideTestInput(t, slashParams)
+
+	signers := []testBscSigner{newTestBscSigner(1), newTestBscSigner(2), newTestBscSigner(3)}
+	ctx = ctx.WithBlockHeight(100)
+	bondAmount := int64(10000e8)
+	for i, signer := range signers {
+		msgCreateVal := newTestMsgCreateSideValidator(addrs[i], signer.addr.Bytes(), createSideAddr(20), bondAmount)
+		got := stake.NewHandler(stakeKeeper, gov.Keeper{})(ctx, msgCreateVal)
+		require.True(t, got.IsOK(), "expected create validator msg to be ok, got: %v", got)
+	}
+	stake.EndBreatheBlock(ctx, stakeKeeper)
+
+	ctx = ctx.WithBlockHeight(300)
+	evidence := func(signer testBscSigner, number int64, difficulty int64) BscDoubleSignEvidence {
+		return BscDoubleSignEvidence{
+			HeaderA: newTestBscHeader(t, signer, number, difficulty, 0),
+			HeaderB: newTestBscHeader(t, signer, number, difficulty, 100),
+		}
+	}
+	msg := NewMsgBscSubmitEvidences(submitter, []BscDoubleSignEvidence{evidence(signers[0], 10, 2), evidence(signers[1], 10, 1)})
+	got := NewHandler(keeper)(ctx, msg)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeMsgNotSupported), got.Code)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.FixDoubleSignChainId, 199)
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.BscDoubleSignEvidences, 199)
+	sdk.UpgradeMgr.SetHeight(200)
+
+	// the evidences are rejected while their validator set is not tracked by the light client
+	got = NewHandler(keeper)(ctx, msg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidEvidence), got.Code)
+	legacyMsg := NewMsgBscSubmitEvidence(submitter, []bsc.Header{msg.Evidences[0].HeaderA, msg.Evidences[0].HeaderB})
+	got = NewHandler(keeper)(ctx, legacyMsg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidEvidence), got.Code)
+
+	require.Nil(t, keeper.LightClientKeeper.InitClient(ctx, lightclient.Checkpoint{
+		ChainId:    sdk.ChainID(1),
+		EvmChainId: SideChainIdBsc.Int64(),
+		Header:     bsc.Header{Number: 9, Extra: make([]byte, 97)},
+		Validators: []bsc.Address{signers[0].addr, signers[1].addr},
+	}))
+	validators := []bsc.Address{signers[0].addr, signers[1].addr}
+	bsc.SortValidators(validators)
+	difficulty := func(signer testBscSigner) int64 {
+		if bsc.InTurnValidator(validators, 10) == signer.addr {
+			return 2
+		}
+		return 1
+	}
+	msg = NewMsgBscSubmitEvidences(submitter, []BscDoubleSignEvidence{
+		evidence(signers[0], 10, difficulty(signers[0])), evidence(signers[1], 10, difficulty(signers[1]))})
+
+	// the legacy message is verified as the evidences are
+	notCoinbase := evidence(signers[0], 10, difficulty(signers[0]))
+	notCoinbase.HeaderB.Coinbase = signers[1].addr
+	notCoinbase.HeaderB = sealTestBscHeader(t, signers[0], notCoinbase.HeaderB)
+	got = NewHandler(keeper)(ctx, NewMsgBscSubmitEvidence(submitter, []bsc.Header{notCoinbase.HeaderA, notCoinbase.HeaderB}))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidEvidence), got.Code)
+
+	// a batch is rejected as a whole
+	dupMsg := NewMsgBscSubmitEvidences(submitter, []BscDoubleSignEvidence{msg.Evidences[0], msg.Evidences[0]})
+	got = NewHandler(keeper)(ctx, dupMsg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeHandledEvidence), got.Code)
+	_, found := keeper.GetBscEvidence(sideCtx, BscEvidenceID(signers[0].addr, 10))
+	require.False(t, found)
+
+	got = NewHandler(keeper)(ctx, msg)
+	require.True(t, got.IsOK(), "expected submit evidences msg to be ok, got: %v", got)
+	for i := range signers[:2] {
+		validator, found := stakeKeeper.GetValidator(sideCtx, addrs[i])
+		require.True(t, found)
+		require.True(t, validator.Jailed)
+		require.EqualValues(t, bondAmount-slashParams.DoubleSignSlashAmount, validator.Tokens.RawInt())
+		slashHeight, found := keeper.GetBscEvidence(sideCtx, BscEvidenceID(signers[i].addr, 10))
+		require.True(t, found)
+		require.EqualValues(t, 300, slashHeight)
+	}
+	submitterBalance := bankKeeper.GetCoins(ctx, submitter).AmountOf("steak")
+	require.EqualValues(t, initCoins-bondAmount+2*slashParams.SubmitterReward, submitterBalance)
+
+	// the double sign can not be punished again by another pair of headers
+	otherPairing := BscDoubleSignEvidence{
+		HeaderA: newTestBscHeader(t, signers[0], 10, difficulty(signers[0]), 200),
+		HeaderB: newTestBscHeader(t, signers[0], 10, difficulty(signers[0]), 300),
+	}
+	got = NewHandler(keeper)(ctx, NewMsgBscSubmitEvidences(submitter, []BscDoubleSignEvidence{otherPairing}))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeHandledEvidence), got.Code)
+
+	// the signers are verified against the validator sets tracked by the light client
+	require.Nil(t, keeper.LightClientKeeper.InitClient(ctx, lightclient.Checkpoint{
+		ChainId:    sdk.ChainID(1),
+		EvmChainId: SideChainIdBsc.Int64(),
+		Header:     bsc.Header{Number: 20, Extra: make([]byte, 97)},
+		Validators: []bsc.Address{signers[1].addr, signers[2].addr},
+	}))
+	validators = []bsc.Address{signers[1].addr, signers[2].addr}
+	bsc.SortValidators(validators)
+	inTurn, outTurn := signers[1], signers[2]
+	if bsc.InTurnValidator(validators, 21) != signers[1].addr {
+		inTurn, outTurn = signers[2], signers[1]
+	}
+	for _, invalid := range []BscDoubleSignEvidence{
+		evidence(signers[0], 21, 1), // not a validator
+		evidence(inTurn, 21, 1),     // wrong difficulty
+		evidence(outTurn, 22, 1),    // unknown validator set
+		evidence(signers[2], 20, 1), // before the checkpoint
+	} {
+		got = NewHandler(keeper)(ctx, NewMsgBscSubmitEvidences(submitter, []BscDoubleSignEvidence{invalid}))
+		require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidEvidence), got.Code)
+	}
+	got = NewHandler(keeper)(ctx, NewMsgBscSubmitEvidences(submitter, []BscDoubleSignEvidence{evidence(inTurn, 21, 2)}))
+	require.True(t, got.IsOK(), "expected submit evidences msg to be ok, got: %v", got)
+}
+
+func signTestBscVote(t *testing.T, sk bls.SecretKey, data bsc.VoteData) BscVote {
+	hash := data.Hash()
+	return BscVote{Data: data, Signature: sk.Sign(hash[:]).Marshal()}
+}
+
+func TestSideChainSlashMaliciousVoteEvidence(t *testing.T) {
+	slashParams := DefaultParams()
+	slashParams.DoubleSignUnbondDuration = 5 * time.Second
+	slashParams.DoubleSignSlashAmount = 6000e8
+	slashParams.SubmitterReward = 3000e8
+	submitter := sdk.AccAddress(addrs[2])
+	ctx, sideCtx, bankKeeper, stakeKeeper, _, keeper := createSideTestInput(t, slashParams)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.BEP126, 199)
+	sdk.UpgradeMgr.SetHeight(200)
+
+	sk, err := bls.RandKey()
+	require.Nil(t, err)
+	voteAddr := sk.PublicKey().Marshal()
+	ctx = ctx.WithBlockHeight(100)
+	bondAmount := int64(10000e8)
+	msgCreateVal := stake.MsgCreateSideChainValidatorWithVoteAddr{
+		Description:   stake.Description{},
+		Commission:    stake.NewCommissionMsg(sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec()),
+		DelegatorAddr: sdk.AccAddress(addrs[0]),
+		ValidatorAddr: addrs[0],
+		Delegation:    sdk.NewCoin("steak", bondAmount),
+		SideChainId:   "bsc",
+		SideConsAddr:  createSideAddr(20),
+		SideFeeAddr:   createSideAddr(20),
+		SideVoteAddr:  voteAddr,
+	}
+	got := stake.NewHandler(stakeKeeper, gov.Keeper{})(ctx, msgCreateVal)
+	require.True(t, got.IsOK(), "expected create validator msg to be ok, got: %v", got)
+	stake.EndBreatheBlock(ctx, stakeKeeper)
+
+	ctx = ctx.WithBlockHeight(300)
+	sideCtx = sideCtx.WithBlockHeight(300)
+	voteA := signTestBscVote(t, sk, bsc.VoteData{SourceNumber: 10, SourceHash: bsc.Hash{0x1}, TargetNumber: 11, TargetHash: bsc.Hash{0x2}})
+	voteB := signTestBscVote(t, sk, bsc.VoteData{SourceNumber: 10, SourceHash: bsc.Hash{0x1}, TargetNumber: 11, TargetHash: bsc.Hash{0x3}})
+	msg := NewMsgBscSubmitVoteEvidence(submitter, voteAddr, voteA, voteB)
+	require.Nil(t, msg.ValidateBasic())
+	got = NewHandler(keeper)(ctx, msg)
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeMsgNotSupported), got.Code)
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.BscVoteEvidence, 199)
+	sdk.UpgradeMgr.SetHeight(200)
+
+	// votes not signed by the vote address are rejected
+	otherSk, err := bls.RandKey()
+	require.Nil(t, err)
+	forged := signTestBscVote(t, otherSk, voteB.Data)
+	got = NewHandler(keeper)(ctx, NewMsgBscSubmitVoteEvidence(submitter, voteAddr, voteA, forged))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidEvidence), got.Code)
+
+	// votes of an unknown vote address are rejected
+	otherVoteA := signTestBscVote(t, otherSk, voteA.Data)
+	got = NewHandler(keeper)(ctx, NewMsgBscSubmitVoteEvidence(submitter, otherSk.PublicKey().Marshal(), otherVoteA, forged))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidValidator), got.Code)
+
+	// the age of votes is told by the latest header trusted by the light client
+	got = NewHandler(keeper)(ctx, msg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidEvidence), got.Code)
+	require.Nil(t, keeper.LightClientKeeper.InitClient(ctx, lightclient.Checkpoint{
+		ChainId:    sdk.ChainID(1),
+		EvmChainId: SideChainIdBsc.Int64(),
+		Header:     bsc.Header{Number: 266, Extra: make([]byte, 97)},
+		Validators: []bsc.Address{{0x1}},
+	}))
+	expiredA := signTestBscVote(t, sk, bsc.VoteData{SourceNumber: 9, SourceHash: bsc.Hash{0x1}, TargetNumber: 10, TargetHash: bsc.Hash{0x2}})
+	expiredB := signTestBscVote(t, sk, bsc.VoteData{SourceNumber: 9, SourceHash: bsc.Hash{0x1}, TargetNumber: 10, TargetHash: bsc.Hash{0x3}})
+	got = NewHandler(keeper)(ctx, NewMsgBscSubmitVoteEvidence(submitter, voteAddr, expiredA, expiredB))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeExpiredEvidence), got.Code)
+
+	got = NewHandler(keeper)(ctx, msg)
+	require.True(t, got.IsOK(), "expected submit vote evidence msg to be ok, got: %v", got)
+	validator, found := stakeKeeper.GetValidator(sideCtx, addrs[0])
+	require.True(t, found)
+	require.True(t, validator.Jailed)
+	require.EqualValues(t, bondAmount-slashParams.DoubleSignSlashAmount, validator.Tokens.RawInt())
+	submitterBalance := bankKeeper.GetCoins(ctx, submitter).AmountOf("steak")
+	require.EqualValues(t, initCoins+slashParams.SubmitterReward, submitterBalance)
+	slashRecord, found := keeper.getSlashRecord(sideCtx, validator.GetSideChainConsAddr(), MaliciousVote, 11)
+	require.True(t, found)
+	require.EqualValues(t, slashParams.DoubleSignSlashAmount, slashRecord.SlashAmt)
+
+	// the validator is slashed once in the jail duration of a malicious vote slash
+	voteC := signTestBscVote(t, sk, bsc.VoteData{SourceNumber: 20, SourceHash: bsc.Hash{0x4}, TargetNumber: 21, TargetHash: bsc.Hash{0x5}})
+	voteD := signTestBscVote(t, sk, bsc.VoteData{SourceNumber: 20, SourceHash: bsc.Hash{0x4}, TargetNumber: 21, TargetHash: bsc.Hash{0x6}})
+	got = NewHandler(keeper)(ctx, NewMsgBscSubmitVoteEvidence(submitter, voteAddr, voteC, voteD))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeFailSlash), got.Code)
+
+	// the same votes can not be claimed again after the jail duration
+	ctx = ctx.WithBlockHeader(abci.Header{Height: 300, Time: ctx.BlockHeader().Time.Add(10 * time.Second)})
+	got = NewHandler(keeper)(ctx, msg)
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeDuplicateMaliciousVoteClaim), got.Code)
+
+	// nor by a slash package from bsc submitted in the evidence window of the votes
+	claim := SideSlashPackage{
+		SideAddr:      voteAddr,
+		SideHeight:    11 + MaxVoteEvidenceAge - 1,
+		SideChainId:   sdk.ChainID(1),
+		SideTimestamp: uint64(ctx.BlockHeader().Time.Unix()),
+	}
+	sdkErr := keeper.slashingSideMaliciousVote(ctx, &claim)
+	require.NotNil(t, sdkErr)
+	require.EqualValues(t, CodeDuplicateMaliciousVoteClaim, sdkErr.Code())
+}
@@ -73,7 +73,7 @@ func (k Keeper) onSelfDelDropBelowMin(ctx sdk.Context, valAddress sdk.ValAddress
 			MissedBlocksCounter: 0,
 		}
 		k.setValidatorSigningInfo(ctx, consAddr, signingInfo)
-	} else {
+	} else if !signingInfo.Tombstoned {
 		signingInfo.JailedUntil = header.Time.Add(k.TooLowDelUnbondDuration(ctx))
 		k.setValidatorSigningInfo(ctx, consAddr, signingInfo)
 	}
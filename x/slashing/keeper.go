@@ -15,6 +15,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/fees"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/lightclient"
 	"github.com/cosmos/cosmos-sdk/x/paramHub/types"
 	param "github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/sidechain"
@@ -32,10 +33,15 @@ type Keeper struct {
 	// codespace
 	Codespace sdk.CodespaceType
 
-	BankKeeper bank.Keeper
-	ScKeeper   *sidechain.Keeper
+	BankKeeper        bank.Keeper
+	ScKeeper          *sidechain.Keeper
+	LightClientKeeper *lightclient.Keeper
 
 	PbsbServer *pubsub.Server
+
+	uptimeWarningThreshold sdk.Dec
+	// optional, the node keeps the uptime history if it is set
+	uptimeHistory *UptimeHistory
 }
 
 // NewKeeper creates a slashing keeper
@@ -63,6 +69,10 @@ func (k *Keeper) initIbc() {
 	}
 }
 
+func (k *Keeper) SetLightClient(lightClientKeeper *lightclient.Keeper) {
+	k.LightClientKeeper = lightClientKeeper
+}
+
 func (k *Keeper) SetPbsbServer(server *pubsub.Server) {
 	k.PbsbServer = server
 }
@@ -98,7 +108,7 @@ func (k Keeper) handleDoubleSign(ctx sdk.Context, addr crypto.Address, infractio
 
 	// Cap the amount slashed to the penalty for the worst infraction
 	// within the slashing period when this infraction was committed
-	fraction := k.SlashFractionDoubleSign(ctx)
+	fraction := k.escalateSlashFraction(ctx, consAddr, k.SlashFractionDoubleSign(ctx))
 	revisedFraction := k.capBySlashingPeriod(ctx, consAddr, fraction, distributionHeight)
 	logger.Info(fmt.Sprintf("Fraction slashed capped by slashing period from %v to %v", fraction, revisedFraction))
 
@@ -108,6 +118,7 @@ func (k Keeper) handleDoubleSign(ctx sdk.Context, addr crypto.Address, infractio
 	// ABCI, and now received as evidence.
 	// The revisedFraction (which is the new fraction to be slashed) is passed
 	// in separately to separately slash unbonding and rebonding delegations.
+	tokensBefore := k.validatorSet.ValidatorByConsAddr(ctx, consAddr).GetTokens()
 	k.validatorSet.Slash(ctx, consAddr, distributionHeight, power, revisedFraction)
 
 	// Jail validator if not already jailed
@@ -122,6 +133,10 @@ func (k Keeper) handleDoubleSign(ctx sdk.Context, addr crypto.Address, infractio
 		panic(fmt.Sprintf("Expected signing info for validator %s but not found", consAddr))
 	}
 	signInfo.JailedUntil = time.Add(k.DoubleSignUnbondDuration(ctx))
+	k.setBCSlashRecord(ctx, consAddr, DoubleSign, infractionHeight, signInfo.JailedUntil, tokensBefore.Sub(validator.GetTokens()))
+	if k.tombstoneIfRepeated(ctx, consAddr, &signInfo) {
+		logger.Info(fmt.Sprintf("Validator %s tombstoned for repeated double signs", pubkey.Address()))
+	}
 	k.setValidatorSigningInfo(ctx, consAddr, signInfo)
 }
 
@@ -149,6 +164,7 @@ func (k Keeper) handleValidatorSignature(ctx sdk.Context, addr crypto.Address, p
 	// That way we avoid needing to read/write the whole array each time
 	previous := k.getValidatorMissedBlockBitArray(ctx, consAddr, index)
 	missed := !signed
+	missedBefore := signInfo.MissedBlocksCounter
 	switch {
 	case !previous && missed:
 		// Array value has changed from not missed to missed, increment counter
@@ -165,6 +181,8 @@ func (k Keeper) handleValidatorSignature(ctx sdk.Context, addr crypto.Address, p
 	if missed {
 		logger.Info(fmt.Sprintf("Absent validator %s at height %d, %d missed, threshold %d", addr, height, signInfo.MissedBlocksCounter, k.MinSignedPerWindow(ctx)))
 	}
+	k.recordUptime(ctx, consAddr, height, signed)
+	k.publishUptimeWarning(ctx, consAddr, height, missedBefore, signInfo.MissedBlocksCounter)
 	minHeight := signInfo.StartHeight + k.SignedBlocksWindow(ctx)
 	maxMissed := k.SignedBlocksWindow(ctx) - k.MinSignedPerWindow(ctx)
 	if height > minHeight && signInfo.MissedBlocksCounter > maxMissed {
@@ -179,9 +197,12 @@ func (k Keeper) handleValidatorSignature(ctx sdk.Context, addr crypto.Address, p
 			// i.e. at the end of the pre-genesis block (none) = at the beginning of the genesis block.
 			// That's fine since this is just used to filter unbonding delegations & redelegations.
 			distributionHeight := height - stake.ValidatorUpdateDelay - 1
-			k.validatorSet.Slash(ctx, consAddr, distributionHeight, power, k.SlashFractionDowntime(ctx))
+			tokensBefore := validator.GetTokens()
+			k.validatorSet.Slash(ctx, consAddr, distributionHeight, power, k.escalateSlashFraction(ctx, consAddr, k.SlashFractionDowntime(ctx)))
 			k.validatorSet.Jail(ctx, consAddr)
 			signInfo.JailedUntil = ctx.BlockHeader().Time.Add(k.DowntimeUnbondDuration(ctx))
+			k.setBCSlashRecord(ctx, consAddr, Downtime, height, signInfo.JailedUntil,
+				tokensBefore.Sub(k.validatorSet.ValidatorByConsAddr(ctx, consAddr).GetTokens()))
 			// We need to reset the counter & array so that the validator won't be immediately slashed for downtime upon rebonding.
 			signInfo.MissedBlocksCounter = 0
 			signInfo.IndexOffset = 0
@@ -317,7 +338,7 @@ func (k *Keeper) slashingSideDowntime(ctx sdk.Context, pack *SideSlashPackage) s
 		return ErrDuplicateDowntimeClaim(k.Codespace)
 	}
 
-	slashAmt := k.DowntimeSlashAmount(sideCtx)
+	slashAmt := k.escalateSlashAmount(sideCtx, sideConsAddr, k.DowntimeSlashAmount(sideCtx))
 	validator, slashedAmt, err := k.validatorSet.SlashSideChain(ctx, sideChainName, sideConsAddr, sdk.NewDec(slashAmt))
 	if err != nil {
 		return ErrFailedToSlash(k.Codespace, err.Error())
@@ -365,9 +386,10 @@ func (k *Keeper) slashingSideDowntime(ctx sdk.Context, pack *SideSlashPackage) s
 	if !found {
 		return sdk.ErrInternal(fmt.Sprintf("Expected signing info for validator %s but not found", sdk.HexEncode(sideConsAddr)))
 	}
-	//if jailUntil.After(signInfo.JailedUntil) {
-	signInfo.JailedUntil = jailUntil
-	//}
+	// the tombstoned validators are never unjailed
+	if !signInfo.Tombstoned {
+		signInfo.JailedUntil = jailUntil
+	}
 	k.setValidatorSigningInfo(sideCtx, sideConsAddr, signInfo)
 
 	if k.PbsbServer != nil {
@@ -421,7 +443,7 @@ func (k *Keeper) slashingSideMaliciousVote(ctx sdk.Context, pack *SideSlashPacka
 	if k.isMaliciousVoteSlashed(sideCtx, sideConsAddr) && pack.SideTimestamp < uint64(signInfo.JailedUntil.Unix()) {
 		logger.Info(fmt.Sprintf("slashing is blocked because %s is still in duration of lastest malicious vote slash", sideConsAddr))
 		return ErrFailedToSlash(k.Codespace, "still in duration of lastest malicious vote slash")
-	} else if k.hasSlashRecord(sideCtx, sideConsAddr, MaliciousVote, pack.SideHeight) {
+	} else if k.maliciousVoteClaimed(sideCtx, sideConsAddr, pack.SideHeight) {
 		logger.Info("slashing is blocked for duplicate malicious vote claim")
 		return ErrDuplicateMaliciousVoteClaim(k.Codespace)
 	}
@@ -430,7 +452,7 @@ func (k *Keeper) slashingSideMaliciousVote(ctx sdk.Context, pack *SideSlashPacka
 	logger.Info(fmt.Sprintf("Confirmed malicious vote from %s at height %d, age %d is less than max age %d, summit at %d, jailed until %d before slashing",
 		sdk.HexAddress(sideConsAddr), pack.SideHeight, age, maxEvidenceAge, pack.SideTimestamp, uint64(signInfo.JailedUntil.Unix())))
 
-	slashAmt := k.DoubleSignSlashAmount(sideCtx)
+	slashAmt := k.escalateSlashAmount(sideCtx, sideConsAddr, k.DoubleSignSlashAmount(sideCtx))
 	validator, slashedAmt, err := k.validatorSet.SlashSideChain(ctx, sideChainName, sideConsAddr, sdk.NewDec(slashAmt))
 	if err != nil {
 		return ErrFailedToSlash(k.Codespace, err.Error())
@@ -467,6 +489,9 @@ func (k *Keeper) slashingSideMaliciousVote(ctx sdk.Context, pack *SideSlashPacka
 	if jailUntil.After(signInfo.JailedUntil) {
 		signInfo.JailedUntil = jailUntil
 	}
+	if k.tombstoneIfRepeated(sideCtx, sideConsAddr, &signInfo) {
+		logger.Info(fmt.Sprintf("Side validator %s tombstoned for repeated malicious votes", sdk.HexAddress(sideConsAddr)))
+	}
 	k.setValidatorSigningInfo(sideCtx, sideConsAddr, signInfo)
 
 	if k.PbsbServer != nil {
@@ -2,6 +2,7 @@ package slashing
 
 import (
 	"encoding/binary"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	stake "github.com/cosmos/cosmos-sdk/x/stake/types"
@@ -14,6 +15,10 @@ var (
 	ValidatorSlashingPeriodKey      = []byte{0x03} // Prefix for slashing period
 	AddrPubkeyRelationKey           = []byte{0x04} // Prefix for address-pubkey relation
 	SlashRecordKey                  = []byte{0x05} // Prefix for slash record
+	BscEvidenceKey                  = []byte{0x06} // Prefix for handled bsc evidence
+	SlashRecordByHeightKey          = []byte{0x07} // Prefix for slash record index by slash height
+	SlashRecordByTypeKey            = []byte{0x08} // Prefix for slash record index by infraction type and slash height
+	SlashRecordByTimeKey            = []byte{0x09} // Prefix for slash record index by slash time
 )
 
 // stored by *Tendermint* address (not operator address)
@@ -63,3 +68,40 @@ func GetSlashRecordsByAddrAndTypeIndexKey(sideConsAddr []byte, infractionType by
 func GetSlashRecordsByAddrIndexKey(sideConsAddr []byte) []byte {
 	return append(SlashRecordKey, sideConsAddr...)
 }
+
+// the slash record indexes end with the slash record key without its prefix
+func GetSlashRecordByHeightKey(slashHeight int64, recordKey []byte) []byte {
+	return append(GetSlashRecordsByHeightIndexKey(slashHeight), recordKey[len(SlashRecordKey):]...)
+}
+
+func GetSlashRecordsByHeightIndexKey(slashHeight int64) []byte {
+	heightBz := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBz, uint64(slashHeight))
+	return append(SlashRecordByHeightKey, heightBz...)
+}
+
+func GetSlashRecordByTypeKey(infractionType byte, slashHeight int64, recordKey []byte) []byte {
+	return append(GetSlashRecordsByTypeAndHeightIndexKey(infractionType, slashHeight), recordKey[len(SlashRecordKey):]...)
+}
+
+func GetSlashRecordsByTypeAndHeightIndexKey(infractionType byte, slashHeight int64) []byte {
+	heightBz := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBz, uint64(slashHeight))
+	return append(GetSlashRecordsByTypeIndexKey(infractionType), heightBz...)
+}
+
+func GetSlashRecordsByTypeIndexKey(infractionType byte) []byte {
+	return append(SlashRecordByTypeKey, infractionType)
+}
+
+func GetSlashRecordByTimeKey(slashTime time.Time, recordKey []byte) []byte {
+	return append(GetSlashRecordsByTimeIndexKey(slashTime), recordKey[len(SlashRecordKey):]...)
+}
+
+func GetSlashRecordsByTimeIndexKey(slashTime time.Time) []byte {
+	return append(SlashRecordByTimeKey, sdk.FormatTimeBytes(slashTime)...)
+}
+
+func GetBscEvidenceKey(evidenceId []byte) []byte {
+	return append(BscEvidenceKey, evidenceId...)
+}
@@ -14,10 +14,15 @@ var cdc = codec.New()
 
 // name to identify transaction types
 const (
-	MsgRoute                 = "slashing"
-	TypeMsgUnjail            = "unjail"
-	TypeMsgSideChainUnjail   = "side_chain_unjail"
-	TypeMsgBscSubmitEvidence = "bsc_submit_evidence"
+	MsgRoute                     = "slashing"
+	TypeMsgUnjail                = "unjail"
+	TypeMsgSideChainUnjail       = "side_chain_unjail"
+	TypeMsgBscSubmitEvidence     = "bsc_submit_evidence"
+	TypeMsgBscSubmitEvidences    = "bsc_submit_evidences"
+	TypeMsgBscSubmitVoteEvidence = "bsc_submit_vote_evidence"
+
+	// MaxEvidencesPerMsg is the max number of evidences submitted in a MsgBscSubmitEvidences
+	MaxEvidencesPerMsg = 10
 )
 
 // verify interface at compile time
@@ -193,3 +198,114 @@ func (msg MsgBscSubmitEvidence) GetSigners() []sdk.AccAddress {
 func (msg MsgBscSubmitEvidence) GetInvolvedAddresses() []sdk.AccAddress {
 	return msg.GetSigners()
 }
+
+//__________________________________________________________________
+
+// MsgBscSubmitEvidences - struct for submitting a batch of double sign evidences for bsc
+var _ sdk.Msg = &MsgBscSubmitEvidences{}
+
+type MsgBscSubmitEvidences struct {
+	Submitter sdk.AccAddress          `json:"submitter"`
+	Evidences []BscDoubleSignEvidence `json:"evidences"`
+}
+
+func NewMsgBscSubmitEvidences(submitter sdk.AccAddress, evidences []BscDoubleSignEvidence) MsgBscSubmitEvidences {
+	return MsgBscSubmitEvidences{
+		Submitter: submitter,
+		Evidences: evidences,
+	}
+}
+
+func (MsgBscSubmitEvidences) Route() string {
+	return MsgRoute
+}
+
+func (MsgBscSubmitEvidences) Type() string {
+	return TypeMsgBscSubmitEvidences
+}
+
+func (msg MsgBscSubmitEvidences) ValidateBasic() sdk.Error {
+	if len(msg.Submitter) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected delegator address length is %d, actual length is %d", sdk.AddrLen, len(msg.Submitter)))
+	}
+	if len(msg.Evidences) == 0 || len(msg.Evidences) > MaxEvidencesPerMsg {
+		return ErrInvalidEvidence(DefaultCodespace, fmt.Sprintf("Must have 1 to %d evidences", MaxEvidencesPerMsg))
+	}
+	for _, evidence := range msg.Evidences {
+		if err := evidence.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (msg MsgBscSubmitEvidences) GetSignBytes() []byte {
+	bz := MsgCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg MsgBscSubmitEvidences) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Submitter}
+}
+
+func (msg MsgBscSubmitEvidences) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
+
+//__________________________________________________________________
+
+// MsgBscSubmitVoteEvidence - struct for submitting two conflicting fast finality votes of a bsc validator
+var _ sdk.Msg = &MsgBscSubmitVoteEvidence{}
+
+type MsgBscSubmitVoteEvidence struct {
+	Submitter sdk.AccAddress `json:"submitter"`
+	VoteAddr  []byte         `json:"vote_addr"`
+	VoteA     BscVote        `json:"vote_a"`
+	VoteB     BscVote        `json:"vote_b"`
+}
+
+func NewMsgBscSubmitVoteEvidence(submitter sdk.AccAddress, voteAddr []byte, voteA, voteB BscVote) MsgBscSubmitVoteEvidence {
+	return MsgBscSubmitVoteEvidence{
+		Submitter: submitter,
+		VoteAddr:  voteAddr,
+		VoteA:     voteA,
+		VoteB:     voteB,
+	}
+}
+
+func (MsgBscSubmitVoteEvidence) Route() string {
+	return MsgRoute
+}
+
+func (MsgBscSubmitVoteEvidence) Type() string {
+	return TypeMsgBscSubmitVoteEvidence
+}
+
+func (msg MsgBscSubmitVoteEvidence) ValidateBasic() sdk.Error {
+	if len(msg.Submitter) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected delegator address length is %d, actual length is %d", sdk.AddrLen, len(msg.Submitter)))
+	}
+	if len(msg.VoteAddr) != sdk.VoteAddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected vote address length is %d, actual length is %d", sdk.VoteAddrLen, len(msg.VoteAddr)))
+	}
+	if len(msg.VoteA.Signature) != sdk.BLSSignatureLength || len(msg.VoteB.Signature) != sdk.BLSSignatureLength {
+		return ErrInvalidEvidence(DefaultCodespace, fmt.Sprintf("Expected vote signature length is %d", sdk.BLSSignatureLength))
+	}
+	if !bsc.IsMaliciousVotePair(&msg.VoteA.Data, &msg.VoteB.Data) {
+		return ErrInvalidEvidence(DefaultCodespace, "The two votes do not violate the fast finality rules")
+	}
+	return nil
+}
+
+func (msg MsgBscSubmitVoteEvidence) GetSignBytes() []byte {
+	bz := MsgCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+func (msg MsgBscSubmitVoteEvidence) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Submitter}
+}
+
+func (msg MsgBscSubmitVoteEvidence) GetInvolvedAddresses() []sdk.AccAddress {
+	return msg.GetSigners()
+}
@@ -13,3 +13,26 @@ func TestMsgUnjailGetSignBytes(t *testing.T) {
 	bytes := msg.GetSignBytes()
 	require.Equal(t, string(bytes), `{"type":"cosmos-sdk/MsgUnjail","value":{"address":"cosmosvaloper1v93xxeqhg9nn6"}}`)
 }
+
+func TestMsgBscSubmitEvidencesValidateBasic(t *testing.T) {
+	submitter := sdk.AccAddress(addrs[0])
+	signer := newTestBscSigner(1)
+	valid := BscDoubleSignEvidence{
+		HeaderA: newTestBscHeader(t, signer, 10, 2, 0),
+		HeaderB: newTestBscHeader(t, signer, 10, 2, 100),
+	}
+	require.Nil(t, NewMsgBscSubmitEvidences(submitter, []BscDoubleSignEvidence{valid}).ValidateBasic())
+
+	require.NotNil(t, NewMsgBscSubmitEvidences(sdk.AccAddress("abcd"), []BscDoubleSignEvidence{valid}).ValidateBasic())
+	require.NotNil(t, NewMsgBscSubmitEvidences(submitter, nil).ValidateBasic())
+	tooMany := make([]BscDoubleSignEvidence, MaxEvidencesPerMsg+1)
+	for i := range tooMany {
+		tooMany[i] = valid
+	}
+	require.NotNil(t, NewMsgBscSubmitEvidences(submitter, tooMany).ValidateBasic())
+
+	same := BscDoubleSignEvidence{HeaderA: valid.HeaderA, HeaderB: valid.HeaderA}
+	require.NotNil(t, NewMsgBscSubmitEvidences(submitter, []BscDoubleSignEvidence{same}).ValidateBasic())
+	otherNumber := BscDoubleSignEvidence{HeaderA: valid.HeaderA, HeaderB: newTestBscHeader(t, signer, 11, 2, 0)}
+	require.NotNil(t, NewMsgBscSubmitEvidences(submitter, []BscDoubleSignEvidence{otherNumber}).ValidateBasic())
+}
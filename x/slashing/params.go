@@ -27,6 +27,10 @@ var (
 	KeyDowntimeSlashAmount      = []byte("DowntimeSlashAmount")
 	KeySubmitterReward          = []byte("SubmitterReward")
 	KeyDowntimeSlashFee         = []byte("DowntimeSlashFee")
+	KeyOffenseLookbackBlocks    = []byte("OffenseLookbackBlocks")
+	KeyOffenseEscalationRate    = []byte("OffenseEscalationRate")
+	KeyMaxOffenseMultiplier     = []byte("MaxOffenseMultiplier")
+	KeyTombstoneDoubleSigns     = []byte("TombstoneDoubleSigns")
 )
 
 // ParamTypeTable for slashing module
@@ -48,6 +52,10 @@ type Params struct {
 	DowntimeSlashAmount      int64         `json:"downtime_slash_amount"`
 	SubmitterReward          int64         `json:"submitter_reward"`
 	DowntimeSlashFee         int64         `json:"downtime_slash_fee"`
+	OffenseLookbackBlocks    int64         `json:"offense_lookback_blocks"`
+	OffenseEscalationRate    sdk.Dec       `json:"offense_escalation_rate"`
+	MaxOffenseMultiplier     sdk.Dec       `json:"max_offense_multiplier"`
+	TombstoneDoubleSigns     int64         `json:"tombstone_double_signs"`
 }
 
 func (p *Params) GetParamAttribute() (string, bool) {
@@ -80,6 +88,18 @@ func (p *Params) UpdateCheck() error {
 	if p.DowntimeSlashFee < 1e8 || p.DowntimeSlashFee > 1000e8 {
 		return fmt.Errorf("the downtime_slash_fee should be in range 1e8 to 1000e8")
 	}
+	if p.OffenseLookbackBlocks < 0 {
+		return fmt.Errorf("the offense_lookback_blocks should not be negative")
+	}
+	if p.OffenseEscalationRate.LT(sdk.ZeroDec()) {
+		return fmt.Errorf("the offense_escalation_rate should not be negative")
+	}
+	if !p.MaxOffenseMultiplier.IsZero() && p.MaxOffenseMultiplier.LT(sdk.OneDec()) {
+		return fmt.Errorf("the max_offense_multiplier should be 0 or no less than 1")
+	}
+	if p.TombstoneDoubleSigns < 0 {
+		return fmt.Errorf("the tombstone_double_signs should not be negative")
+	}
 	return nil
 }
 
@@ -98,6 +118,10 @@ func (p *Params) KeyValuePairs() params.KeyValuePairs {
 		{KeyDowntimeSlashAmount, &p.DowntimeSlashAmount},
 		{KeySubmitterReward, &p.SubmitterReward},
 		{KeyDowntimeSlashFee, &p.DowntimeSlashFee},
+		{KeyOffenseLookbackBlocks, &p.OffenseLookbackBlocks},
+		{KeyOffenseEscalationRate, &p.OffenseEscalationRate},
+		{KeyMaxOffenseMultiplier, &p.MaxOffenseMultiplier},
+		{KeyTombstoneDoubleSigns, &p.TombstoneDoubleSigns},
 	}
 }
 
@@ -133,6 +157,15 @@ func DefaultParams() Params {
 		SubmitterReward: 10e8,
 
 		DowntimeSlashFee: 10e8,
+
+		// progressive slashing is disabled by default
+		OffenseLookbackBlocks: 0,
+
+		OffenseEscalationRate: sdk.ZeroDec(),
+
+		MaxOffenseMultiplier: sdk.ZeroDec(),
+
+		TombstoneDoubleSigns: 0,
 	}
 }
 
@@ -206,6 +239,33 @@ func (k Keeper) DowntimeSlashFee(ctx sdk.Context) (downtimeSlashFee int64) {
 	return
 }
 
+// OffenseLookbackBlocks - the blocks in which the prior slashes of a validator escalate its next slash,
+// progressive slashing is disabled if 0
+func (k Keeper) OffenseLookbackBlocks(ctx sdk.Context) (res int64) {
+	k.paramspace.GetIfExists(ctx, KeyOffenseLookbackBlocks, &res)
+	return
+}
+
+// OffenseEscalationRate - the increase of the slash multiplier by every prior slash in the lookback window
+func (k Keeper) OffenseEscalationRate(ctx sdk.Context) sdk.Dec {
+	res := sdk.ZeroDec()
+	k.paramspace.GetIfExists(ctx, KeyOffenseEscalationRate, &res)
+	return res
+}
+
+// MaxOffenseMultiplier - the cap of the slash multiplier, uncapped if 0
+func (k Keeper) MaxOffenseMultiplier(ctx sdk.Context) sdk.Dec {
+	res := sdk.ZeroDec()
+	k.paramspace.GetIfExists(ctx, KeyMaxOffenseMultiplier, &res)
+	return res
+}
+
+// TombstoneDoubleSigns - the double signs after which a validator is tombstoned, never if 0
+func (k Keeper) TombstoneDoubleSigns(ctx sdk.Context) (res int64) {
+	k.paramspace.GetIfExists(ctx, KeyTombstoneDoubleSigns, &res)
+	return
+}
+
 // set the params
 func (k Keeper) SetParams(ctx sdk.Context, params Params) {
 	k.paramspace.SetParamSet(ctx, &params)
@@ -9,6 +9,11 @@ import (
 
 const Topic = pubsub.Topic("slashing")
 
+func init() {
+	pubsub.RegisterEvent(SideSlashEvent{})
+	pubsub.RegisterEvent(ValidatorUptimeWarningEvent{})
+}
+
 type SideSlashEvent struct {
 	Validator              sdk.ValAddress
 	InfractionType         byte
@@ -26,3 +31,16 @@ type SideSlashEvent struct {
 func (event SideSlashEvent) GetTopic() pubsub.Topic {
 	return Topic
 }
+
+// ValidatorUptimeWarningEvent is published when a validator has missed the warning threshold of the blocks it may
+// miss in the signed blocks window before being slashed for downtime.
+type ValidatorUptimeWarningEvent struct {
+	Validator       sdk.ConsAddress
+	Height          int64
+	MissedBlocks    int64
+	MaxMissedBlocks int64
+}
+
+func (event ValidatorUptimeWarningEvent) GetTopic() pubsub.Topic {
+	return Topic
+}
@@ -12,6 +12,14 @@ import (
 const (
 	QueryConsAddrSlashRecords     = "consAddrSlashHistories"
 	QueryConsAddrTypeSlashRecords = "consAddrTypeSlashHistories"
+	QuerySlashRecords             = "slashRecords"
+	QueryValidatorUptime          = "validatorUptime"
+	QueryValidatorsAtRisk         = "validatorsAtRisk"
+
+	// DefaultSlashRecordsLimit is the number of slash records of a page if the limit is not set, MaxSlashRecordsLimit
+	// is the most of a page
+	DefaultSlashRecordsLimit = 100
+	MaxSlashRecordsLimit     = 1000
 )
 
 // creates a querier for staking REST endpoints
@@ -32,6 +40,27 @@ func NewQuerier(k Keeper, cdc *codec.Codec) sdk.Querier {
 				return res, err
 			}
 			return queryConsAddrTypeSlashRecords(ctx, k, param)
+		case QuerySlashRecords:
+			param := new(QuerySlashRecordsParams)
+			ctx, err = RequestPrepare(ctx, k, req, param)
+			if err != nil {
+				return res, err
+			}
+			return querySlashRecords(ctx, k, param)
+		case QueryValidatorUptime:
+			param := new(QueryValidatorUptimeParams)
+			ctx, err = RequestPrepare(ctx, k, req, param)
+			if err != nil {
+				return res, err
+			}
+			return queryValidatorUptime(ctx, k, param)
+		case QueryValidatorsAtRisk:
+			param := new(QueryValidatorsAtRiskParams)
+			ctx, err = RequestPrepare(ctx, k, req, param)
+			if err != nil {
+				return res, err
+			}
+			return queryValidatorsAtRisk(ctx, k, param)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown slashing query endpoint")
 		}
@@ -64,6 +93,37 @@ type QueryConsAddrTypeParams struct {
 	InfractionType byte
 }
 
+// QuerySlashRecordsParams queries a page of the slash records selected by the filter, starting from Cursor which is
+// the NextCursor of the previous page.
+type QuerySlashRecordsParams struct {
+	BaseParams
+	SlashRecordFilter
+	Cursor []byte
+	Limit  int
+}
+
+// SlashRecordsPage is a page of slash records, NextCursor is the cursor of the next page in hex, empty on the last page.
+type SlashRecordsPage struct {
+	Records    []SlashRecord `json:"records"`
+	NextCursor string        `json:"next_cursor"`
+}
+
+// QueryValidatorUptimeParams queries the uptime of a validator from its checkpoints overlapping the height range,
+// a zero ToHeight is unbounded.
+type QueryValidatorUptimeParams struct {
+	BaseParams
+	ConsAddr   []byte
+	FromHeight int64
+	ToHeight   int64
+}
+
+// QueryValidatorsAtRiskParams queries the validators which missed at least the fraction Threshold of the blocks
+// they may miss before being slashed for downtime.
+type QueryValidatorsAtRiskParams struct {
+	BaseParams
+	Threshold sdk.Dec
+}
+
 func RequestPrepare(ctx sdk.Context, k Keeper, req abci.RequestQuery, p types.SideChainIder) (newCtx sdk.Context, err sdk.Error) {
 	if req.Data == nil || len(req.Data) == 0 {
 		return ctx, nil
@@ -118,3 +178,58 @@ func queryConsAddrTypeSlashRecords(ctx sdk.Context, k Keeper, params *QueryConsA
 
 	return res, nil
 }
+
+func querySlashRecords(ctx sdk.Context, k Keeper, params *QuerySlashRecordsParams) (res []byte, err sdk.Error) {
+	limit := params.Limit
+	if limit <= 0 {
+		limit = DefaultSlashRecordsLimit
+	} else if limit > MaxSlashRecordsLimit {
+		limit = MaxSlashRecordsLimit
+	}
+	records, nextCursor, err := k.GetSlashRecords(ctx, params.SlashRecordFilter, params.Cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	page := SlashRecordsPage{Records: records}
+	if len(nextCursor) != 0 {
+		page.NextCursor = sdk.HexEncode(nextCursor)
+	}
+	res, resErr := codec.MarshalJSONIndent(k.cdc, page)
+	if resErr != nil {
+		return res, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", resErr.Error()))
+	}
+
+	return res, nil
+}
+
+func queryValidatorUptime(ctx sdk.Context, k Keeper, params *QueryValidatorUptimeParams) (res []byte, err sdk.Error) {
+	if params.ToHeight > 0 && params.ToHeight < params.FromHeight {
+		return nil, sdk.ErrUnknownRequest("the to height is lower than the from height")
+	}
+	uptime, err := k.GetValidatorUptime(sdk.ConsAddress(params.ConsAddr), params.FromHeight, params.ToHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	res, resErr := codec.MarshalJSONIndent(k.cdc, uptime)
+	if resErr != nil {
+		return res, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", resErr.Error()))
+	}
+
+	return res, nil
+}
+
+func queryValidatorsAtRisk(ctx sdk.Context, k Keeper, params *QueryValidatorsAtRiskParams) (res []byte, err sdk.Error) {
+	if params.Threshold.LT(sdk.ZeroDec()) || params.Threshold.GT(sdk.OneDec()) {
+		return nil, sdk.ErrUnknownRequest("the threshold should be in range 0 to 1")
+	}
+	validators := k.GetValidatorsAtRisk(ctx, params.Threshold)
+
+	res, resErr := codec.MarshalJSONIndent(k.cdc, validators)
+	if resErr != nil {
+		return res, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", resErr.Error()))
+	}
+
+	return res, nil
+}
@@ -73,10 +73,25 @@ type ValidatorSigningInfo struct {
 	IndexOffset         int64     `json:"index_offset"`          // index offset into signed block bit array
 	JailedUntil         time.Time `json:"jailed_until"`          // timestamp validator cannot be unjailed until
 	MissedBlocksCounter int64     `json:"missed_blocks_counter"` // missed blocks counter (to avoid scanning the array every time)
+	Tombstoned          bool      `json:"tombstoned"`            // whether the validator is tombstoned, it can never be unjailed
 }
 
 // Return human readable signing info
 func (i ValidatorSigningInfo) HumanReadableString() string {
-	return fmt.Sprintf("Start height: %d, index offset: %d, jailed until: %v, missed blocks counter: %d",
-		i.StartHeight, i.IndexOffset, i.JailedUntil, i.MissedBlocksCounter)
+	return fmt.Sprintf("Start height: %d, index offset: %d, jailed until: %v, missed blocks counter: %d, tombstoned: %t",
+		i.StartHeight, i.IndexOffset, i.JailedUntil, i.MissedBlocksCounter, i.Tombstoned)
+}
+
+// IterateValidatorSigningInfos iterates over the signing infos of the validators until handler returns true.
+func (k Keeper) IterateValidatorSigningInfos(ctx sdk.Context, handler func(address sdk.ConsAddress, info ValidatorSigningInfo) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, ValidatorSigningInfoKey)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var info ValidatorSigningInfo
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iter.Value(), &info)
+		if handler(sdk.ConsAddress(iter.Key()[len(ValidatorSigningInfoKey):]), info) {
+			break
+		}
+	}
 }
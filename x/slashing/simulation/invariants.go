@@ -1,14 +1,35 @@
 package simulation
 
 import (
+	"fmt"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
 	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/mock/simulation"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
 )
 
 // AllInvariants tests all slashing invariants
-func AllInvariants() simulation.Invariant {
+func AllInvariants(k slashing.Keeper) simulation.Invariant {
+	return func(app *baseapp.BaseApp) error {
+		return TombstoneInvariant(k)(app)
+	}
+}
+
+// TombstoneInvariant checks that the tombstoned validators are jailed forever
+func TombstoneInvariant(k slashing.Keeper) simulation.Invariant {
 	return func(app *baseapp.BaseApp) error {
-		// TODO Any invariants to check here?
-		return nil
+		ctx := app.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+		var err error
+		k.IterateValidatorSigningInfos(ctx, func(address sdk.ConsAddress, info slashing.ValidatorSigningInfo) bool {
+			if info.Tombstoned && !info.JailedUntil.Equal(slashing.TombstoneJailedUntil) {
+				err = fmt.Errorf("tombstoned validator %s is jailed until %v", address, info.JailedUntil)
+				return true
+			}
+			return false
+		})
+		return err
 	}
 }
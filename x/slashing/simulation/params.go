@@ -0,0 +1,21 @@
+package simulation
+
+import (
+	"math/rand"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/slashing"
+)
+
+// RandomizedParams returns params with random progressive slashing params, so that the simulations escalate the
+// slashes of repeat offenders and tombstone repeated double signers.
+func RandomizedParams(r *rand.Rand, params slashing.Params) slashing.Params {
+	params.OffenseLookbackBlocks = r.Int63n(200)
+	params.OffenseEscalationRate = sdk.NewDecWithPrec(r.Int63n(100), 2)
+	params.MaxOffenseMultiplier = sdk.ZeroDec()
+	if r.Intn(2) == 0 {
+		params.MaxOffenseMultiplier = sdk.NewDecWithoutFra(1 + r.Int63n(4))
+	}
+	params.TombstoneDoubleSigns = r.Int63n(4)
+	return params
+}
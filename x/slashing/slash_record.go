@@ -1,10 +1,13 @@
 package slashing
 
 import (
+	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	cryptoAmino "github.com/tendermint/tendermint/crypto/encoding/amino"
@@ -26,18 +29,41 @@ type SlashRecord struct {
 	SideChainId      string
 }
 
-func (r SlashRecord) HumanReadableString() (string, error) {
-	var infraType string
-	if r.InfractionType == 0 {
-		infraType = "DoubleSign"
-	} else if r.InfractionType == 1 {
-		infraType = "Downtime"
-	} else if r.InfractionType == 2 {
-		infraType = "MaliciousVote"
+// InfractionTypeString returns the name of an infraction type.
+func InfractionTypeString(infractionType byte) string {
+	switch infractionType {
+	case DoubleSign:
+		return "DoubleSign"
+	case Downtime:
+		return "Downtime"
+	case MaliciousVote:
+		return "MaliciousVote"
+	default:
+		return ""
+	}
+}
+
+// ParseInfractionType returns the infraction type of a name.
+func ParseInfractionType(name string) (byte, error) {
+	switch name {
+	case "DoubleSign":
+		return DoubleSign, nil
+	case "Downtime":
+		return Downtime, nil
+	case "MaliciousVote":
+		return MaliciousVote, nil
+	default:
+		return 0, errors.New("unknown infraction type")
 	}
+}
+
+func (r SlashRecord) HumanReadableString() (string, error) {
+	infraType := InfractionTypeString(r.InfractionType)
 
 	var consAddr string
-	if len(r.SideChainId) == 0 {
+	if len(r.SideChainId) == 0 && len(r.ConsAddr) == sdk.AddrLen {
+		consAddr = sdk.ConsAddress(r.ConsAddr).String()
+	} else if len(r.SideChainId) == 0 {
 		pk, err := cryptoAmino.PubKeyFromBytes(r.ConsAddr)
 		if err != nil {
 			return "", err
@@ -123,6 +149,21 @@ func (k Keeper) setSlashRecord(ctx sdk.Context, record SlashRecord) {
 	store := ctx.KVStore(k.storeKey)
 	bz := MustMarshalSlashRecord(k.cdc, record)
 	store.Set(GetSlashRecordKey(record.ConsAddr, record.InfractionType, record.InfractionHeight), bz)
+	if sdk.IsUpgrade(sdk.SlashRecordIndex) {
+		k.setSlashRecordIndexes(ctx, record, ctx.BlockHeader().Time)
+	}
+}
+
+// setSlashRecordIndexes indexes a slash record by its slash height, by its infraction type and by the time it was slashed at.
+func (k Keeper) setSlashRecordIndexes(ctx sdk.Context, record SlashRecord, slashTime time.Time) {
+	store := ctx.KVStore(k.storeKey)
+	recordKey := GetSlashRecordKey(record.ConsAddr, record.InfractionType, record.InfractionHeight)
+	timeBz := sdk.FormatTimeBytes(slashTime)
+	heightBz := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBz, uint64(record.SlashHeight))
+	store.Set(GetSlashRecordByHeightKey(record.SlashHeight, recordKey), timeBz)
+	store.Set(GetSlashRecordByTypeKey(record.InfractionType, record.SlashHeight, recordKey), timeBz)
+	store.Set(GetSlashRecordByTimeKey(slashTime, recordKey), heightBz)
 }
 
 func (k Keeper) getSlashRecord(ctx sdk.Context, consAddr []byte, infractionType byte, infractionHeight uint64) (sr SlashRecord, found bool) {
@@ -180,3 +221,158 @@ func (k Keeper) getSlashRecordsByConsAddrAndType(ctx sdk.Context, consAddr []byt
 	}
 	return
 }
+
+// SlashRecordFilter selects the slash records of an infraction type, if it is set, which are slashed in a height range and
+// a time range. The bounds are inclusive, and a zero bound is unbounded.
+type SlashRecordFilter struct {
+	InfractionType *byte
+	FromHeight     int64
+	ToHeight       int64
+	FromTime       time.Time
+	ToTime         time.Time
+}
+
+func (f SlashRecordFilter) match(slashHeight int64, slashTime time.Time) (matched bool, passed bool) {
+	if (f.ToHeight > 0 && slashHeight > f.ToHeight) || (!f.ToTime.IsZero() && slashTime.After(f.ToTime)) {
+		return false, true
+	}
+	if slashHeight < f.FromHeight || (!f.FromTime.IsZero() && slashTime.Before(f.FromTime)) {
+		return false, false
+	}
+	return true, false
+}
+
+// slashRecordIndexLayout is the part of the slash record indexes which the slash records are ordered by
+type slashRecordIndexLayout struct {
+	prefix []byte
+	byTime bool
+}
+
+// indexRange returns the index used to look up the slash records of filter and the range of its keys.
+func (f SlashRecordFilter) indexRange() (layout slashRecordIndexLayout, start []byte, end []byte) {
+	heightBound := func(prefix []byte, height int64) []byte {
+		heightBz := make([]byte, 8)
+		binary.BigEndian.PutUint64(heightBz, uint64(height))
+		return append(append([]byte{}, prefix...), heightBz...)
+	}
+	switch {
+	case f.InfractionType != nil:
+		layout.prefix = GetSlashRecordsByTypeIndexKey(*f.InfractionType)
+	case f.FromHeight == 0 && f.ToHeight == 0 && (!f.FromTime.IsZero() || !f.ToTime.IsZero()):
+		layout.prefix, layout.byTime = SlashRecordByTimeKey, true
+		start, end = layout.prefix, sdk.PrefixEndBytes(layout.prefix)
+		if !f.FromTime.IsZero() {
+			start = GetSlashRecordsByTimeIndexKey(f.FromTime)
+		}
+		if !f.ToTime.IsZero() {
+			end = sdk.PrefixEndBytes(GetSlashRecordsByTimeIndexKey(f.ToTime))
+		}
+		return layout, start, end
+	default:
+		layout.prefix = SlashRecordByHeightKey
+	}
+	start, end = heightBound(layout.prefix, f.FromHeight), sdk.PrefixEndBytes(layout.prefix)
+	if f.ToHeight > 0 {
+		end = heightBound(layout.prefix, f.ToHeight+1)
+	}
+	return layout, start, end
+}
+
+// parse returns the slash height, the slash time and the slash record key of an index entry.
+func (l slashRecordIndexLayout) parse(key, value []byte) (int64, time.Time, []byte, error) {
+	recordKey := append(append([]byte{}, SlashRecordKey...), key[len(key)-slashRecordKeyLen:]...)
+	if l.byTime {
+		slashTime, err := sdk.ParseTimeBytes(key[len(l.prefix) : len(key)-slashRecordKeyLen])
+		return int64(binary.BigEndian.Uint64(value)), slashTime, recordKey, err
+	}
+	slashTime, err := sdk.ParseTimeBytes(value)
+	return int64(binary.BigEndian.Uint64(key[len(l.prefix) : len(l.prefix)+8])), slashTime, recordKey, err
+}
+
+// slashRecordKeyLen is the length of a slash record key without its prefix: the consensus address, the infraction type
+// and the infraction height
+const slashRecordKeyLen = sdk.AddrLen + 1 + 8
+
+// GetSlashRecords returns at most limit slash records selected by filter in order of slash height, from cursor if it is
+// set. The returned cursor is where the next page starts, it is nil if there are no more records.
+func (k Keeper) GetSlashRecords(ctx sdk.Context, filter SlashRecordFilter, cursor []byte, limit int) ([]SlashRecord, []byte, sdk.Error) {
+	layout, start, end := filter.indexRange()
+	if len(cursor) != 0 {
+		if !bytes.HasPrefix(cursor, layout.prefix) || len(cursor) <= len(layout.prefix)+slashRecordKeyLen {
+			return nil, nil, sdk.ErrUnknownRequest("invalid cursor of slash records")
+		}
+		if bytes.Compare(cursor, start) > 0 {
+			start = cursor
+		}
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(start, end)
+	defer iterator.Close()
+
+	records := make([]SlashRecord, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		slashHeight, slashTime, recordKey, err := layout.parse(iterator.Key(), iterator.Value())
+		if err != nil {
+			return nil, nil, sdk.ErrInternal(err.Error())
+		}
+		// heights and times of slashes grow together, so no more record matches once a bound is passed
+		matched, passed := filter.match(slashHeight, slashTime)
+		if passed {
+			break
+		} else if !matched {
+			continue
+		}
+		if len(records) == limit {
+			return records, iterator.Key(), nil
+		}
+		records = append(records, MustUnmarshalSlashRecord(k.cdc, recordKey, store.Get(recordKey)))
+	}
+	return records, nil, nil
+}
+
+// StoreMigrations returns the migrations of the slashing store, the app registers them to the multistore.
+func (k Keeper) StoreMigrations() []sdk.StoreMigration {
+	return []sdk.StoreMigration{
+		{StoreKey: k.storeKey.Name(), From: 0, To: 1, Upgrade: sdk.SlashRecordIndex, Migrate: k.MigrateSlashRecordIndexes},
+	}
+}
+
+// RegisterStoreMigrations registers the migrations of the slashing store, they run at the heights of their upgrades.
+func RegisterStoreMigrations(app *baseapp.BaseApp, keeper Keeper) {
+	for _, migration := range keeper.StoreMigrations() {
+		app.RegisterStoreMigration(migration)
+	}
+}
+
+// MigrateSlashRecordIndexes indexes the slash records of all side chains. The time of the records slashed before is not
+// stored, they are indexed at the time they are jailed until less the jail duration of their infraction type.
+func (k Keeper) MigrateSlashRecordIndexes(ctx sdk.Context) error {
+	_, prefixes := k.ScKeeper.GetAllSideChainPrefixes(ctx)
+	count := 0
+	for _, prefix := range prefixes {
+		sideCtx := ctx.WithSideChainKeyPrefix(prefix)
+		var records []SlashRecord
+		iterator := sdk.KVStorePrefixIterator(sideCtx.KVStore(k.storeKey), SlashRecordKey)
+		for ; iterator.Valid(); iterator.Next() {
+			record, err := UnmarshalSlashRecord(k.cdc, iterator.Key(), iterator.Value())
+			if err != nil {
+				iterator.Close()
+				return err
+			}
+			records = append(records, record)
+		}
+		iterator.Close()
+
+		for _, record := range records {
+			jailDuration := k.DoubleSignUnbondDuration(sideCtx)
+			if record.InfractionType == Downtime {
+				jailDuration = k.DowntimeUnbondDuration(sideCtx)
+			}
+			k.setSlashRecordIndexes(sideCtx, record, record.JailUntil.Add(-jailDuration))
+		}
+		count += len(records)
+	}
+	ctx.Logger().With("module", "slashing").Info("indexed slash records", "count", count)
+	return nil
+}
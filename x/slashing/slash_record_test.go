@@ -1,11 +1,15 @@
 package slashing
 
 import (
+	"encoding/json"
 	"math/rand"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 func TestSetGetSlashRecord(t *testing.T) {
@@ -41,3 +45,100 @@ func randomSideConsAddr() []byte {
 	rand.Read(bz)
 	return bz
 }
+
+func TestSlashRecordIndexes(t *testing.T) {
+	ctx, sideCtx, _, _, _, keeper := createSideTestInput(t, DefaultParams())
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.SlashRecordIndex, 199)
+
+	genesisTime := time.Unix(1600000000, 0).UTC()
+	newRecord := func(infractionType byte, slashHeight int64) SlashRecord {
+		return SlashRecord{
+			ConsAddr:         randomSideConsAddr(),
+			InfractionType:   infractionType,
+			InfractionHeight: uint64(slashHeight - 1),
+			SlashHeight:      slashHeight,
+			JailUntil:        genesisTime.Add(time.Duration(slashHeight)*time.Second + keeper.DoubleSignUnbondDuration(sideCtx)),
+			SlashAmt:         100e8,
+			SideChainId:      "bsc",
+		}
+	}
+	setRecord := func(record SlashRecord) {
+		header := abci.Header{Height: record.SlashHeight, Time: genesisTime.Add(time.Duration(record.SlashHeight) * time.Second)}
+		keeper.setSlashRecord(sideCtx.WithBlockHeader(header), record)
+	}
+
+	// the records slashed before the upgrade are indexed by the migration
+	sdk.UpgradeMgr.SetHeight(100)
+	setRecord(newRecord(DoubleSign, 10))
+	setRecord(newRecord(DoubleSign, 20))
+	records, _, err := keeper.GetSlashRecords(sideCtx, SlashRecordFilter{}, nil, 10)
+	require.Nil(t, err)
+	require.Len(t, records, 0)
+	sdk.UpgradeMgr.SetHeight(200)
+	require.Nil(t, keeper.MigrateSlashRecordIndexes(ctx))
+
+	for height := int64(30); height <= 100; height += 10 {
+		infractionType := Downtime
+		if height%20 == 0 {
+			infractionType = MaliciousVote
+		}
+		setRecord(newRecord(infractionType, height))
+	}
+
+	heights := func(records []SlashRecord) []int64 {
+		res := make([]int64, 0, len(records))
+		for _, record := range records {
+			res = append(res, record.SlashHeight)
+		}
+		return res
+	}
+	malicious := MaliciousVote
+	for _, c := range []struct {
+		filter  SlashRecordFilter
+		heights []int64
+	}{
+		{SlashRecordFilter{}, []int64{10, 20, 30, 40, 50, 60, 70, 80, 90, 100}},
+		{SlashRecordFilter{InfractionType: &malicious}, []int64{40, 60, 80, 100}},
+		{SlashRecordFilter{FromHeight: 25, ToHeight: 60}, []int64{30, 40, 50, 60}},
+		{SlashRecordFilter{InfractionType: &malicious, ToHeight: 60}, []int64{40, 60}},
+		{SlashRecordFilter{FromTime: genesisTime.Add(15 * time.Second), ToTime: genesisTime.Add(50 * time.Second)}, []int64{20, 30, 40, 50}},
+		{SlashRecordFilter{InfractionType: &malicious, FromTime: genesisTime.Add(70 * time.Second)}, []int64{80, 100}},
+	} {
+		records, nextCursor, err := keeper.GetSlashRecords(sideCtx, c.filter, nil, 100)
+		require.Nil(t, err)
+		require.Nil(t, nextCursor)
+		require.Equal(t, c.heights, heights(records))
+	}
+
+	// the records are paginated by cursors
+	var paged []SlashRecord
+	var cursor []byte
+	for {
+		records, nextCursor, err := keeper.GetSlashRecords(sideCtx, SlashRecordFilter{FromHeight: 20}, cursor, 4)
+		require.Nil(t, err)
+		require.True(t, len(records) <= 4)
+		paged = append(paged, records...)
+		if nextCursor == nil {
+			break
+		}
+		cursor = nextCursor
+	}
+	require.Equal(t, []int64{20, 30, 40, 50, 60, 70, 80, 90, 100}, heights(paged))
+	_, _, err = keeper.GetSlashRecords(sideCtx, SlashRecordFilter{InfractionType: &malicious}, cursor, 4)
+	require.NotNil(t, err)
+
+	// query a page by the querier
+	querier := NewQuerier(keeper, keeper.cdc)
+	bz, jsonErr := json.Marshal(QuerySlashRecordsParams{
+		BaseParams:        NewBaseParams("bsc"),
+		SlashRecordFilter: SlashRecordFilter{InfractionType: &malicious},
+		Limit:             3,
+	})
+	require.Nil(t, jsonErr)
+	res, err := querier(ctx, []string{QuerySlashRecords}, abci.RequestQuery{Data: bz})
+	require.Nil(t, err)
+	var page SlashRecordsPage
+	require.Nil(t, keeper.cdc.UnmarshalJSON(res, &page))
+	require.Equal(t, []int64{40, 60, 80}, heights(page.Records))
+	require.NotEmpty(t, page.NextCursor)
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/cosmos/cosmos-sdk/x/ibc"
+	"github.com/cosmos/cosmos-sdk/x/lightclient"
 	"github.com/cosmos/cosmos-sdk/x/sidechain"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -128,6 +129,7 @@ func createSideTestInput(t *testing.T, defaults Params) (sdk.Context, sdk.Contex
 	tkeyParams := sdk.NewTransientStoreKey("transient_params")
 	keyIbc := sdk.NewKVStoreKey("ibc")
 	keySideChain := sdk.NewKVStoreKey("sc")
+	keyLightClient := sdk.NewKVStoreKey(lightclient.StoreKey)
 
 	db := dbm.NewMemDB()
 	ms := store.NewCommitMultiStore(db)
@@ -140,6 +142,7 @@ func createSideTestInput(t *testing.T, defaults Params) (sdk.Context, sdk.Contex
 	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
 	ms.MountStoreWithDB(keyIbc, sdk.StoreTypeIAVL, db)
 	ms.MountStoreWithDB(keySideChain, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyLightClient, sdk.StoreTypeIAVL, db)
 
 	err := ms.LoadLatestVersion()
 	require.Nil(t, err)
@@ -189,6 +192,8 @@ func createSideTestInput(t *testing.T, defaults Params) (sdk.Context, sdk.Contex
 	keeper := NewKeeper(cdc, keySlashing, sk, paramstore, DefaultCodespace, ck)
 	sk = sk.WithHooks(keeper.Hooks())
 	keeper.SetSideChain(&scKeeper)
+	lightClientKeeper := lightclient.NewKeeper(cdc, keyLightClient, lightclient.DefaultCodespace, scKeeper)
+	keeper.SetLightClient(&lightClientKeeper)
 	keeper.SetParams(sideCtx, defaults)
 	scKeeper.SetChannelSendPermission(ctx, sdk.ChainID(1), sdk.ChannelID(8), sdk.ChannelAllow)
 
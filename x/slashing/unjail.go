@@ -37,6 +37,10 @@ func (k Keeper) Unjail(ctx sdk.Context, validatorAddr sdk.ValAddress) sdk.Error
 		return ErrNoValidatorForAddress(k.Codespace)
 	}
 
+	if info.Tombstoned {
+		return ErrValidatorTombstoned(k.Codespace)
+	}
+
 	// cannot be unjailed until out of jail
 	if ctx.BlockHeader().Time.Before(info.JailedUntil) {
 		return ErrValidatorJailed(k.Codespace)
@@ -0,0 +1,276 @@
+package slashing
+
+import (
+	"encoding/binary"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// UptimeCheckpoint counts the blocks a validator signed and missed from StartHeight to EndHeight. A checkpoint of
+// the validators is made in the uptime history at the end of every SignedBlocksWindow blocks, so that their uptime
+// is kept after the missed block bit array rolls.
+type UptimeCheckpoint struct {
+	StartHeight  int64 `json:"start_height"`
+	EndHeight    int64 `json:"end_height"`
+	SignedBlocks int64 `json:"signed_blocks"`
+	MissedBlocks int64 `json:"missed_blocks"`
+}
+
+// ValidatorUptime is the uptime of a validator from StartHeight to EndHeight, summed from its checkpoints.
+type ValidatorUptime struct {
+	ConsAddr     sdk.ConsAddress    `json:"cons_addr"`
+	StartHeight  int64              `json:"start_height"`
+	EndHeight    int64              `json:"end_height"`
+	SignedBlocks int64              `json:"signed_blocks"`
+	MissedBlocks int64              `json:"missed_blocks"`
+	Uptime       sdk.Dec            `json:"uptime"`
+	Checkpoints  []UptimeCheckpoint `json:"checkpoints"`
+}
+
+// ValidatorAtRisk is a validator which missed at least a fraction of the blocks it may miss in the signed blocks
+// window before being slashed for downtime.
+type ValidatorAtRisk struct {
+	ConsAddr            sdk.ConsAddress `json:"cons_addr"`
+	MissedBlocksCounter int64           `json:"missed_blocks_counter"`
+	MaxMissedBlocks     int64           `json:"max_missed_blocks"`
+	SignedBlocksWindow  int64           `json:"signed_blocks_window"`
+}
+
+var (
+	uptimeCounterPrefix    = []byte{0x01} // prefix for the signing counts of the current uptime checkpoint of validators
+	uptimeCheckpointPrefix = []byte{0x02} // prefix for the uptime checkpoints of validators, by end height
+)
+
+// UptimeHistory keeps the uptime checkpoints of the validators. It is node-local and does not take part in the
+// consensus, a node keeps it only if it is set to the slashing keeper.
+type UptimeHistory struct {
+	db  dbm.DB
+	cdc *codec.Codec
+
+	// number of recent checkpoints of a validator to keep, 0 means keep everything
+	retainCheckpoints int64
+}
+
+// NewUptimeHistory creates an uptime history on top of db, e.g. a goleveldb opened under the node home.
+// retainCheckpoints is the number of recent checkpoints of a validator kept whenever a checkpoint is made,
+// 0 disables pruning.
+func NewUptimeHistory(db dbm.DB, cdc *codec.Codec, retainCheckpoints int64) *UptimeHistory {
+	return &UptimeHistory{
+		db:                db,
+		cdc:               cdc,
+		retainCheckpoints: retainCheckpoints,
+	}
+}
+
+// SetUptimeHistory makes the keeper keep the uptime checkpoints of the validators in history.
+func (k *Keeper) SetUptimeHistory(history *UptimeHistory) {
+	k.uptimeHistory = history
+}
+
+// UptimeHistoryEnabled tells if the node keeps the uptime history.
+func (k Keeper) UptimeHistoryEnabled() bool {
+	return k.uptimeHistory != nil
+}
+
+// SetUptimeWarningThreshold sets the fraction of the blocks a validator may miss in the signed blocks window, at
+// which a ValidatorUptimeWarningEvent is published. No event is published if it is not positive.
+func (k *Keeper) SetUptimeWarningThreshold(threshold sdk.Dec) {
+	k.uptimeWarningThreshold = threshold
+}
+
+func (k Keeper) maxMissedBlocks(ctx sdk.Context) int64 {
+	return k.SignedBlocksWindow(ctx) - k.MinSignedPerWindow(ctx)
+}
+
+// recordUptime counts a block which a validator should have signed in the uptime history, and makes the checkpoint
+// of the validator at the end of the signed blocks window. Only delivered blocks are counted, once even if the node
+// executes a block again.
+func (k Keeper) recordUptime(ctx sdk.Context, consAddr sdk.ConsAddress, height int64, signed bool) {
+	if k.uptimeHistory == nil || !ctx.IsDeliverTx() {
+		return
+	}
+	window := k.SignedBlocksWindow(ctx)
+	if !k.uptimeHistory.count(consAddr, height, signed, window) || k.uptimeHistory.retainCheckpoints <= 0 {
+		return
+	}
+	k.uptimeHistory.prune(consAddr, height-k.uptimeHistory.retainCheckpoints*window)
+}
+
+// publishUptimeWarning publishes a ValidatorUptimeWarningEvent when the missed blocks counter of a validator reaches
+// the warning threshold.
+func (k Keeper) publishUptimeWarning(ctx sdk.Context, consAddr sdk.ConsAddress, height int64, missedBefore, missed int64) {
+	if k.PbsbServer == nil || !ctx.IsDeliverTx() || !k.uptimeWarningThreshold.GT(sdk.ZeroDec()) {
+		return
+	}
+	maxMissed := k.maxMissedBlocks(ctx)
+	warningMissed := sdk.NewDec(maxMissed).Mul(k.uptimeWarningThreshold).RawInt()
+	if missedBefore < warningMissed && missed >= warningMissed {
+		k.PbsbServer.Publish(ValidatorUptimeWarningEvent{
+			Validator:       consAddr,
+			Height:          height,
+			MissedBlocks:    missed,
+			MaxMissedBlocks: maxMissed,
+		})
+	}
+}
+
+// GetUptimeCheckpoints returns the checkpoints of a validator which overlap the height range, including the signing
+// counts since its latest checkpoint. A zero toHeight is unbounded.
+func (k Keeper) GetUptimeCheckpoints(consAddr sdk.ConsAddress, fromHeight, toHeight int64) ([]UptimeCheckpoint, sdk.Error) {
+	if k.uptimeHistory == nil {
+		return nil, sdk.ErrUnknownRequest("uptime history is not kept by this node")
+	}
+	return k.uptimeHistory.checkpoints(consAddr, fromHeight, toHeight), nil
+}
+
+// GetValidatorUptime returns the uptime of a validator over the checkpoints overlapping the height range, the
+// range it covers is rounded to the checkpoints.
+func (k Keeper) GetValidatorUptime(consAddr sdk.ConsAddress, fromHeight, toHeight int64) (ValidatorUptime, sdk.Error) {
+	checkpoints, err := k.GetUptimeCheckpoints(consAddr, fromHeight, toHeight)
+	if err != nil {
+		return ValidatorUptime{}, err
+	}
+	uptime := ValidatorUptime{
+		ConsAddr:    consAddr,
+		Uptime:      sdk.ZeroDec(),
+		Checkpoints: checkpoints,
+	}
+	if len(uptime.Checkpoints) == 0 {
+		return uptime, nil
+	}
+	uptime.StartHeight = uptime.Checkpoints[0].StartHeight
+	uptime.EndHeight = uptime.Checkpoints[len(uptime.Checkpoints)-1].EndHeight
+	for _, checkpoint := range uptime.Checkpoints {
+		uptime.SignedBlocks += checkpoint.SignedBlocks
+		uptime.MissedBlocks += checkpoint.MissedBlocks
+	}
+	if total := uptime.SignedBlocks + uptime.MissedBlocks; total > 0 {
+		uptime.Uptime = sdk.NewDecWithoutFra(uptime.SignedBlocks).Quo(sdk.NewDecWithoutFra(total))
+	}
+	return uptime, nil
+}
+
+// GetValidatorsAtRisk returns the validators which missed at least the fraction threshold of the blocks they may
+// miss in the signed blocks window before being slashed for downtime.
+func (k Keeper) GetValidatorsAtRisk(ctx sdk.Context, threshold sdk.Dec) []ValidatorAtRisk {
+	window := k.SignedBlocksWindow(ctx)
+	maxMissed := k.maxMissedBlocks(ctx)
+	riskMissed := sdk.NewDec(maxMissed).Mul(threshold).RawInt()
+
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, ValidatorSigningInfoKey)
+	defer iterator.Close()
+
+	validators := make([]ValidatorAtRisk, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		var info ValidatorSigningInfo
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &info)
+		if info.MissedBlocksCounter == 0 || info.MissedBlocksCounter < riskMissed {
+			continue
+		}
+		validators = append(validators, ValidatorAtRisk{
+			ConsAddr:            sdk.ConsAddress(iterator.Key()[len(ValidatorSigningInfoKey):]),
+			MissedBlocksCounter: info.MissedBlocksCounter,
+			MaxMissedBlocks:     maxMissed,
+			SignedBlocksWindow:  window,
+		})
+	}
+	return validators
+}
+
+// count adds a block to the signing counts of a validator, and makes its checkpoint if the block ends a window.
+// It tells if a checkpoint is made, blocks already counted are skipped.
+func (h *UptimeHistory) count(consAddr sdk.ConsAddress, height int64, signed bool, window int64) bool {
+	counter := UptimeCheckpoint{StartHeight: height}
+	if bz := h.db.Get(getUptimeCounterKey(consAddr)); bz != nil {
+		h.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &counter)
+		if height <= counter.EndHeight {
+			return false
+		}
+	}
+	counter.EndHeight = height
+	if signed {
+		counter.SignedBlocks++
+	} else {
+		counter.MissedBlocks++
+	}
+
+	batch := h.db.NewBatch()
+	defer batch.Close()
+	checkpoint := height%window == 0
+	if checkpoint {
+		batch.Set(getUptimeCheckpointKey(consAddr, height), h.cdc.MustMarshalBinaryLengthPrefixed(counter))
+		// the empty counter keeps the counted height
+		counter = UptimeCheckpoint{StartHeight: height + 1, EndHeight: height}
+	}
+	batch.Set(getUptimeCounterKey(consAddr), h.cdc.MustMarshalBinaryLengthPrefixed(counter))
+	batch.Write()
+	return checkpoint
+}
+
+// prune drops the checkpoints of a validator ending at or before the height.
+func (h *UptimeHistory) prune(consAddr sdk.ConsAddress, height int64) {
+	if height <= 0 {
+		return
+	}
+	iterator := h.db.Iterator(getUptimeCheckpointsKey(consAddr), getUptimeCheckpointKey(consAddr, height+1))
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+	iterator.Close()
+	if len(keys) == 0 {
+		return
+	}
+
+	batch := h.db.NewBatch()
+	defer batch.Close()
+	for _, key := range keys {
+		batch.Delete(key)
+	}
+	batch.Write()
+}
+
+func (h *UptimeHistory) checkpoints(consAddr sdk.ConsAddress, fromHeight, toHeight int64) []UptimeCheckpoint {
+	iterator := h.db.Iterator(getUptimeCheckpointKey(consAddr, fromHeight), sdk.PrefixEndBytes(getUptimeCheckpointsKey(consAddr)))
+	defer iterator.Close()
+
+	checkpoints := make([]UptimeCheckpoint, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		var checkpoint UptimeCheckpoint
+		h.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &checkpoint)
+		if toHeight > 0 && checkpoint.StartHeight > toHeight {
+			return checkpoints
+		}
+		checkpoints = append(checkpoints, checkpoint)
+	}
+
+	if bz := h.db.Get(getUptimeCounterKey(consAddr)); bz != nil {
+		var counter UptimeCheckpoint
+		h.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &counter)
+		if counter.EndHeight >= counter.StartHeight && counter.EndHeight >= fromHeight &&
+			(toHeight == 0 || counter.StartHeight <= toHeight) {
+			checkpoints = append(checkpoints, counter)
+		}
+	}
+	return checkpoints
+}
+
+// stored by *Tendermint* address (not operator address)
+func getUptimeCounterKey(consAddr sdk.ConsAddress) []byte {
+	return append(append([]byte{}, uptimeCounterPrefix...), consAddr.Bytes()...)
+}
+
+func getUptimeCheckpointsKey(consAddr sdk.ConsAddress) []byte {
+	return append(append([]byte{}, uptimeCheckpointPrefix...), consAddr.Bytes()...)
+}
+
+// stored by *Tendermint* address (not operator address) followed by the end height of the checkpoint
+func getUptimeCheckpointKey(consAddr sdk.ConsAddress, endHeight int64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(endHeight))
+	return append(getUptimeCheckpointsKey(consAddr), b...)
+}
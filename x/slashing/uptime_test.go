@@ -0,0 +1,103 @@
+package slashing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/cosmos/cosmos-sdk/pubsub"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func TestValidatorUptime(t *testing.T) {
+	params := keeperTestParams()
+	params.SignedBlocksWindow = 100
+	ctx, _, sk, _, keeper := createTestInput(t, params)
+	stakeParam := stake.DefaultParams()
+	stakeParam.MinSelfDelegation = 10e8
+	sk.SetParams(ctx, stakeParam)
+	amt := sdk.NewDecWithoutFra(100).RawInt()
+	addr, val := addrs[0], pks[0]
+	got := stake.NewStakeHandler(sk)(ctx, NewTestMsgCreateValidator(addr, val, amt))
+	require.True(t, got.IsOK())
+	validatorUpdates, _ := stake.EndBlocker(ctx, sk)
+	keeper.AddValidators(ctx, validatorUpdates)
+	consAddr := sdk.ConsAddress(val.Address())
+
+	server := pubsub.NewServer(nil)
+	require.Nil(t, server.Start())
+	defer server.Stop()
+	sub, err := server.NewSubscriber("uptime", nil)
+	require.Nil(t, err)
+	warnings := make(chan ValidatorUptimeWarningEvent, 10)
+	require.Nil(t, sub.Subscribe(Topic, func(event pubsub.Event) {
+		if warning, ok := event.(ValidatorUptimeWarningEvent); ok {
+			warnings <- warning
+		}
+	}))
+	keeper.PbsbServer = server
+	keeper.SetUptimeWarningThreshold(sdk.NewDecWithPrec(8, 1))
+
+	_, err = keeper.GetValidatorUptime(consAddr, 0, 0)
+	require.Error(t, err)
+	keeper.SetUptimeHistory(NewUptimeHistory(dbm.NewMemDB(), keeper.cdc, 2))
+
+	// 70 blocks signed and 45 missed over one and a half windows
+	for height := int64(1); height <= 115; height++ {
+		ctx = ctx.WithBlockHeight(height)
+		keeper.handleValidatorSignature(ctx, val.Address(), amt, height <= 70)
+	}
+
+	// the warning is published once the validator missed 80% of the 50 blocks it may miss
+	select {
+	case warning := <-warnings:
+		require.Equal(t, consAddr, warning.Validator)
+		require.EqualValues(t, 110, warning.Height)
+		require.EqualValues(t, 40, warning.MissedBlocks)
+		require.EqualValues(t, 50, warning.MaxMissedBlocks)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no uptime warning published")
+	}
+
+	// a block executed again is counted once
+	keeper.recordUptime(ctx, consAddr, 115, true)
+
+	checkpoints, err := keeper.GetUptimeCheckpoints(consAddr, 0, 0)
+	require.Nil(t, err)
+	require.Equal(t, []UptimeCheckpoint{
+		{StartHeight: 1, EndHeight: 100, SignedBlocks: 70, MissedBlocks: 30},
+		{StartHeight: 101, EndHeight: 115, SignedBlocks: 0, MissedBlocks: 15},
+	}, checkpoints)
+
+	uptime, err := keeper.GetValidatorUptime(consAddr, 0, 0)
+	require.Nil(t, err)
+	require.EqualValues(t, 1, uptime.StartHeight)
+	require.EqualValues(t, 115, uptime.EndHeight)
+	require.EqualValues(t, 70, uptime.SignedBlocks)
+	require.EqualValues(t, 45, uptime.MissedBlocks)
+	require.Equal(t, sdk.NewDecWithoutFra(70).Quo(sdk.NewDecWithoutFra(115)), uptime.Uptime)
+
+	uptime, _ = keeper.GetValidatorUptime(consAddr, 0, 50)
+	require.EqualValues(t, 100, uptime.EndHeight)
+	require.EqualValues(t, 30, uptime.MissedBlocks)
+	uptime, _ = keeper.GetValidatorUptime(consAddr, 101, 0)
+	require.EqualValues(t, 101, uptime.StartHeight)
+	require.EqualValues(t, 15, uptime.MissedBlocks)
+
+	atRisk := keeper.GetValidatorsAtRisk(ctx, sdk.NewDecWithPrec(8, 1))
+	require.Equal(t, []ValidatorAtRisk{{ConsAddr: consAddr, MissedBlocksCounter: 45, MaxMissedBlocks: 50, SignedBlocksWindow: 100}}, atRisk)
+	require.Len(t, keeper.GetValidatorsAtRisk(ctx, sdk.NewDecWithPrec(95, 2)), 0)
+
+	// only the 2 latest checkpoints are kept
+	for height := int64(116); height <= 300; height++ {
+		keeper.handleValidatorSignature(ctx.WithBlockHeight(height), val.Address(), amt, true)
+	}
+	checkpoints, _ = keeper.GetUptimeCheckpoints(consAddr, 0, 0)
+	require.Equal(t, []UptimeCheckpoint{
+		{StartHeight: 101, EndHeight: 200, SignedBlocks: 85, MissedBlocks: 15},
+		{StartHeight: 201, EndHeight: 300, SignedBlocks: 100, MissedBlocks: 0},
+	}, checkpoints)
+}
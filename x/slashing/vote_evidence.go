@@ -0,0 +1,188 @@
+package slashing
+
+import (
+	"fmt"
+
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+
+	"github.com/cosmos/cosmos-sdk/bsc"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/fees"
+)
+
+// MaxVoteEvidenceAge is the number of bsc blocks after its target in which a malicious vote can be claimed,
+// the same as the slash indicator of bsc accepts the evidences in.
+const MaxVoteEvidenceAge uint64 = 256
+
+// BscVote is a fast finality vote of a bsc validator with its BLS signature of the vote data.
+type BscVote struct {
+	Data      bsc.VoteData `json:"data"`
+	Signature []byte       `json:"signature"`
+}
+
+// verifyBscVoteSignature verifies that vote is signed by the BLS key of voteAddr.
+func verifyBscVoteSignature(voteAddr []byte, vote *BscVote) error {
+	pubKey, err := bls.PublicKeyFromBytes(voteAddr)
+	if err != nil {
+		return fmt.Errorf("invalid vote address, %v", err)
+	}
+	sig, err := bls.SignatureFromBytes(vote.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid vote signature, %v", err)
+	}
+	hash := vote.Data.Hash()
+	if !sig.Verify(pubKey, hash[:]) {
+		return fmt.Errorf("the vote of target %d is not signed by the vote address", vote.Data.TargetNumber)
+	}
+	return nil
+}
+
+// maliciousVoteClaimed tells if a malicious vote of the validator has been slashed within MaxVoteEvidenceAge blocks
+// of height. The slash packages from bsc carry the height the evidence is submitted at instead of the votes, which
+// is less than MaxVoteEvidenceAge blocks after their target, so the claims of both ways within the window are taken
+// as the same evidence.
+func (k Keeper) maliciousVoteClaimed(ctx sdk.Context, consAddr []byte, height uint64) bool {
+	start := uint64(0)
+	if height >= MaxVoteEvidenceAge {
+		start = height - MaxVoteEvidenceAge + 1
+	}
+	store := ctx.KVStore(k.storeKey)
+	iterator := store.Iterator(GetSlashRecordKey(consAddr, MaliciousVote, start),
+		GetSlashRecordKey(consAddr, MaliciousVote, height+MaxVoteEvidenceAge))
+	defer iterator.Close()
+	return iterator.Valid()
+}
+
+// verifyVoteEvidenceAge verifies that the target of the votes is not older than MaxVoteEvidenceAge blocks before the
+// latest header of bsc trusted by the light client.
+func (k Keeper) verifyVoteEvidenceAge(ctx sdk.Context, sideChainId string, targetNumber uint64) sdk.Error {
+	if k.LightClientKeeper == nil {
+		return ErrInvalidEvidence(k.Codespace, "The latest header of bsc is unknown")
+	}
+	destChainId, err := k.ScKeeper.GetDestChainID(sideChainId)
+	if err != nil {
+		return ErrInvalidSideChainId(k.Codespace)
+	}
+	state, found := k.LightClientKeeper.GetClientState(ctx, destChainId)
+	if !found {
+		return ErrInvalidEvidence(k.Codespace, "The latest header of bsc is unknown")
+	}
+	if targetNumber+MaxVoteEvidenceAge <= uint64(state.LatestNumber) {
+		return ErrExpiredEvidence(k.Codespace)
+	}
+	return nil
+}
+
+func handleMsgBscSubmitVoteEvidence(ctx sdk.Context, msg MsgBscSubmitVoteEvidence, k Keeper) sdk.Result {
+	if !sdk.IsUpgrade(sdk.BscVoteEvidence) {
+		return sdk.ErrMsgNotSupported("").Result()
+	}
+	logger := ctx.Logger().With("module", "x/slashing")
+	sideChainId := k.ScKeeper.BscSideChainId(ctx)
+	sideCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, sideChainId)
+	if err != nil {
+		return ErrInvalidSideChainId(DefaultCodespace).Result()
+	}
+
+	if err := verifyBscVoteSignature(msg.VoteAddr, &msg.VoteA); err != nil {
+		return ErrInvalidEvidence(k.Codespace, err.Error()).Result()
+	}
+	if err := verifyBscVoteSignature(msg.VoteAddr, &msg.VoteB); err != nil {
+		return ErrInvalidEvidence(k.Codespace, err.Error()).Result()
+	}
+
+	validator := k.validatorSet.ValidatorByVoteAddr(sideCtx, msg.VoteAddr)
+	if validator == nil {
+		return ErrNoValidatorWithVoteAddr(k.Codespace).Result()
+	}
+
+	// the infraction is recorded at the latest target of the votes
+	infractionHeight := msg.VoteA.Data.TargetNumber
+	if infractionHeight < msg.VoteB.Data.TargetNumber {
+		infractionHeight = msg.VoteB.Data.TargetNumber
+	}
+	if err := k.verifyVoteEvidenceAge(ctx, sideChainId, infractionHeight); err != nil {
+		return err.Result()
+	}
+	header := sideCtx.BlockHeader()
+	sideConsAddr := []byte(validator.GetSideChainConsAddr())
+	signInfo, found := k.getValidatorSigningInfo(sideCtx, sideConsAddr)
+	if !found {
+		return sdk.ErrInternal(fmt.Sprintf("Expected signing info for validator %s but not found", sdk.HexEncode(sideConsAddr))).Result()
+	}
+	// in duration of malicious vote slash, validator can only be slashed once, to protect validator from funds drained
+	if k.isMaliciousVoteSlashed(sideCtx, sideConsAddr) && header.Time.Before(signInfo.JailedUntil) {
+		return ErrFailedToSlash(k.Codespace, "still in duration of lastest malicious vote slash").Result()
+	} else if k.maliciousVoteClaimed(sideCtx, sideConsAddr, infractionHeight) {
+		return ErrDuplicateMaliciousVoteClaim(k.Codespace).Result()
+	}
+	logger.Info(fmt.Sprintf("Confirmed malicious vote from %s at height %d by submitted evidence", sdk.HexAddress(sideConsAddr), infractionHeight))
+
+	slashAmount := k.escalateSlashAmount(sideCtx, sideConsAddr, k.DoubleSignSlashAmount(sideCtx))
+	validator, slashedAmount, err := k.validatorSet.SlashSideChain(ctx, sideChainId, sideConsAddr, sdk.NewDec(slashAmount))
+	if err != nil {
+		return ErrFailedToSlash(k.Codespace, err.Error()).Result()
+	}
+
+	bondDenom := k.validatorSet.BondDenom(sideCtx)
+	submitterReward := sdk.MinInt64(slashedAmount.RawInt(), k.SubmitterReward(sideCtx))
+	if submitterReward > 0 {
+		submitterBalance := k.BankKeeper.GetCoins(ctx, msg.Submitter)
+		if err := k.BankKeeper.SetCoins(ctx, msg.Submitter, submitterBalance.Plus(sdk.Coins{sdk.NewCoin(bondDenom, submitterReward)})); err != nil {
+			return ErrFailedToSlash(k.Codespace, err.Error()).Result()
+		}
+	}
+
+	remainingReward := slashedAmount.RawInt() - submitterReward
+	var toFeePool int64
+	var validatorsCompensation map[string]int64
+	if remainingReward > 0 {
+		found, validatorsCompensation, err = k.validatorSet.AllocateSlashAmtToValidators(sideCtx, sideConsAddr, sdk.NewDec(remainingReward))
+		if err != nil {
+			return ErrFailedToSlash(k.Codespace, err.Error()).Result()
+		}
+		if !found && ctx.IsDeliverTx() { // if the related validators are not found, the amount will be added to fee pool
+			toFeePool = remainingReward
+			remainingCoin := sdk.NewCoin(bondDenom, remainingReward)
+			fees.Pool.AddAndCommitFee("side_malicious_vote_slash", sdk.NewFee(sdk.Coins{remainingCoin}, sdk.FeeForAll))
+		}
+	}
+
+	jailUntil := header.Time.Add(k.DoubleSignUnbondDuration(sideCtx))
+	sr := SlashRecord{
+		ConsAddr:         sideConsAddr,
+		InfractionType:   MaliciousVote,
+		InfractionHeight: infractionHeight,
+		SlashHeight:      header.Height,
+		JailUntil:        jailUntil,
+		SlashAmt:         slashedAmount.RawInt(),
+		SideChainId:      sideChainId,
+	}
+	k.setSlashRecord(sideCtx, sr)
+
+	if jailUntil.After(signInfo.JailedUntil) {
+		signInfo.JailedUntil = jailUntil
+	}
+	if k.tombstoneIfRepeated(sideCtx, sideConsAddr, &signInfo) {
+		logger.Info(fmt.Sprintf("Side validator %s tombstoned for repeated malicious votes", sdk.HexAddress(sideConsAddr)))
+	}
+	k.setValidatorSigningInfo(sideCtx, sideConsAddr, signInfo)
+
+	if ctx.IsDeliverTx() && k.PbsbServer != nil {
+		event := SideSlashEvent{
+			Validator:              validator.GetOperator(),
+			InfractionType:         MaliciousVote,
+			InfractionHeight:       int64(infractionHeight),
+			SlashHeight:            header.Height,
+			JailUtil:               jailUntil,
+			SlashAmt:               slashedAmount.RawInt(),
+			SideChainId:            sideChainId,
+			ToFeePool:              toFeePool,
+			Submitter:              msg.Submitter,
+			SubmitterReward:        submitterReward,
+			ValidatorsCompensation: validatorsCompensation,
+		}
+		k.PbsbServer.Publish(event)
+	}
+	return sdk.Result{}
+}
@@ -51,6 +51,10 @@ func AddCommands(root *cobra.Command, cdc *codec.Codec) {
 			GetCmdSideChainDelegate(cdc),
 			GetCmdSideChainRedelegate(cdc),
 			GetCmdSideChainUnbond(cdc),
+			GetCmdSetRewardRestake(cdc),
+			GetCmdSideChainLiquidDelegate(cdc),
+			GetCmdSideChainLiquidUnbond(cdc),
+			GetCmdRetryCrossStakeRefund(cdc),
 		)...,
 	)
 	stakingCmd.AddCommand(client.LineBreak)
@@ -70,6 +74,11 @@ func AddCommands(root *cobra.Command, cdc *codec.Codec) {
 			GetCmdQuerySideChainTopValidators(cdc),
 			GetCmdQuerySideAllValidatorsCount(cdc),
 			GetCmdQueryCrossStakeInfoByBscAddress(cdc),
+			GetCmdQueryRewardRestake(cdc),
+			GetCmdQueryRewards(cdc),
+			GetCmdQueryPendingCommissions(cdc),
+			GetCmdQueryLiquidStakeReceipts(cdc),
+			GetCmdQueryCrossStakeRecords(cdc),
 		)...,
 	)
 
@@ -44,6 +44,15 @@ const (
 	FlagSideVoteAddr = "side-vote-addr"
 	FlagBLSWalletDir = "bls-wallet"
 	FlagBLSPassword  = "bls-password"
+
+	FlagRestake = "restake"
+
+	FlagFromDate = "from-date"
+	FlagToDate   = "to-date"
+	FlagCursor   = "cursor"
+	FlagLimit    = "limit"
+
+	FlagSequence = "sequence"
 )
 
 // common flagsets to add to various functions
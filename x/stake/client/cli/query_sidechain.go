@@ -846,6 +846,267 @@ func GetCmdQueryCrossStakeInfoByBscAddress(cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+func GetCmdQueryRewardRestake(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reward-restake [delegator-addr]",
+		Short: fmt.Sprintf("Query whether the rewards of a delegator are delegated back to their validators, use %s as side chain id for the beacon chain", types.ChainIDForBeaconChain),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			delAddr, err := sdk.AccAddressFromBech32(args[0])
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			sideChainId, err := getSideChainId()
+			if err != nil {
+				return err
+			}
+			if sideChainId == types.ChainIDForBeaconChain {
+				sideChainId = ""
+			}
+
+			params := stake.QueryDelegatorParams{
+				BaseParams:    stake.NewBaseParams(sideChainId),
+				DelegatorAddr: delAddr,
+			}
+			bz, err := json.Marshal(params)
+			if err != nil {
+				return err
+			}
+
+			response, err := cliCtx.QueryWithData("custom/stake/"+stake.QueryRewardRestake, bz)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(response))
+			return nil
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsSideChainId)
+	return cmd
+}
+
+// GetCmdQueryRewards implements the reward history query command.
+func GetCmdQueryRewards(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rewards [delegator-addr]",
+		Short: "Query the rewards distributed to a delegator, or to the delegators of the validator if no delegator is given",
+		Long: fmt.Sprintf(`Query the rewards distributed to a delegator, or to the delegators of the validator if no delegator is given.
+The rewards are distributed from %s to %s in UTC, both are inclusive and in the layout %s. A page of at most %s rewards
+is returned, the next page starts from the %s of the returned next cursor. The rewards of the beacon chain are returned
+if %s is not set or is %s. The rewards are kept only by the nodes which enable the reward history.`,
+			FlagFromDate, FlagToDate, stake.RewardHistoryDateLayout, FlagLimit, FlagCursor, FlagSideChainId, types.ChainIDForBeaconChain),
+		Args: cobra.RangeArgs(0, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var params stake.QueryRewardHistoryParams
+			var err error
+			if len(args) == 1 {
+				if params.DelegatorAddr, err = sdk.AccAddressFromBech32(args[0]); err != nil {
+					return err
+				}
+			}
+			if validator := viper.GetString(FlagAddressValidator); len(validator) != 0 {
+				if params.ValidatorAddr, err = sdk.ValAddressFromBech32(validator); err != nil {
+					return err
+				}
+			} else if len(params.DelegatorAddr) == 0 {
+				return fmt.Errorf("either the delegator or %s is required", FlagAddressValidator)
+			}
+			if sideChainId := viper.GetString(FlagSideChainId); sideChainId != types.ChainIDForBeaconChain {
+				params.BaseParams = stake.NewBaseParams(sideChainId)
+			}
+			params.FromTime, params.ToTime, err = stake.ParseRewardHistoryDates(viper.GetString(FlagFromDate), viper.GetString(FlagToDate))
+			if err != nil {
+				return err
+			}
+			if cursor := viper.GetString(FlagCursor); len(cursor) != 0 {
+				if params.Cursor, err = sdk.HexDecode(cursor); err != nil {
+					return err
+				}
+			}
+			params.Limit = viper.GetInt(FlagLimit)
+
+			bz, err := json.Marshal(params)
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			response, err := cliCtx.QueryWithData("custom/stake/"+stake.QueryRewardHistory, bz)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(response))
+			return nil
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsValidator)
+	cmd.Flags().AddFlagSet(fsSideChainId)
+	cmd.Flags().String(FlagFromDate, "", "The first date of the rewards")
+	cmd.Flags().String(FlagToDate, "", "The last date of the rewards")
+	cmd.Flags().String(FlagCursor, "", "The next cursor returned by the previous page")
+	cmd.Flags().Int(FlagLimit, 0, "The max number of rewards returned")
+	return cmd
+}
+
+// GetCmdQueryPendingCommissions implements the pending commission raises query command.
+func GetCmdQueryPendingCommissions(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pending-commissions [validator-addr]",
+		Short: fmt.Sprintf("Query the pending commission raise of a validator, or of all validators if no validator is given, use %s as side chain id for the beacon chain", types.ChainIDForBeaconChain),
+		Args:  cobra.RangeArgs(0, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var params stake.QueryValidatorParams
+			if len(args) == 1 {
+				valAddr, err := sdk.ValAddressFromBech32(args[0])
+				if err != nil {
+					return err
+				}
+				params.ValidatorAddr = valAddr
+			}
+
+			sideChainId, err := getSideChainId()
+			if err != nil {
+				return err
+			}
+			if sideChainId != types.ChainIDForBeaconChain {
+				params.BaseParams = stake.NewBaseParams(sideChainId)
+			}
+			bz, err := json.Marshal(params)
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			response, err := cliCtx.QueryWithData("custom/stake/"+stake.QueryPendingCommissions, bz)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(response))
+			return nil
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsSideChainId)
+	return cmd
+}
+
+func GetCmdQueryLiquidStakeReceipts(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "liquid-stake-receipts [validator-addr]",
+		Short: "Query the liquid stake receipt of a side chain validator with the tokens it represents, or of all validators of the side chain if no validator is given",
+		Args:  cobra.RangeArgs(0, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var params stake.QueryValidatorParams
+			if len(args) == 1 {
+				valAddr, err := sdk.ValAddressFromBech32(args[0])
+				if err != nil {
+					return err
+				}
+				params.ValidatorAddr = valAddr
+			}
+
+			sideChainId, err := getSideChainId()
+			if err != nil {
+				return err
+			}
+			params.BaseParams = stake.NewBaseParams(sideChainId)
+			bz, err := json.Marshal(params)
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			response, err := cliCtx.QueryWithData("custom/stake/"+stake.QueryLiquidStakeReceipts, bz)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(response))
+			return nil
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsSideChainId)
+	return cmd
+}
+
+// GetCmdQueryCrossStakeRecords implements the cross stake records query command.
+func GetCmdQueryCrossStakeRecords(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cross-stake-records [bsc-address]",
+		Short: fmt.Sprintf("Query the records of the cross stake requests and refunds of a BSC address, or the record of the package of %s", FlagSequence),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bscAddress, err := sdk.NewSmartChainAddress(args[0])
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			sideChainId, _, err := getSideChainConfig(cliCtx)
+			if err != nil {
+				return err
+			}
+
+			var bz []byte
+			route := "custom/stake/" + stake.QueryCrossStakeRecords
+			if cmd.Flags().Changed(FlagSequence) {
+				route = "custom/stake/" + stake.QueryCrossStakeRecord
+				bz, err = json.Marshal(stake.QueryCrossStakeRecordParams{
+					BaseParams: stake.NewBaseParams(sideChainId),
+					BscAddress: bscAddress,
+					Sequence:   viper.GetUint64(FlagSequence),
+				})
+			} else {
+				bz, err = json.Marshal(stake.QueryCrossStakeInfoParams{
+					BaseParams: stake.NewBaseParams(sideChainId),
+					BscAddress: bscAddress,
+				})
+			}
+			if err != nil {
+				return err
+			}
+
+			response, err := cliCtx.QueryWithData(route, bz)
+			if err != nil {
+				return err
+			}
+
+			switch viper.Get(cli.OutputFlag) {
+			case "text":
+				var records []types.CrossStakeRecord
+				if cmd.Flags().Changed(FlagSequence) {
+					var record types.CrossStakeRecord
+					if err = cdc.UnmarshalJSON(response, &record); err != nil {
+						return err
+					}
+					records = append(records, record)
+				} else if err = cdc.UnmarshalJSON(response, &records); err != nil {
+					return err
+				}
+				for _, record := range records {
+					resp, err := record.HumanReadableString()
+					if err != nil {
+						return err
+					}
+					fmt.Println(resp)
+				}
+			case "json":
+				fmt.Println(string(response))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsSideChainId)
+	cmd.Flags().Uint64(FlagSequence, 0, "The receive sequence of the package on the cross stake channel")
+	return cmd
+}
+
 func getSideChainConfig(cliCtx context.CLIContext) (sideChainId string, prefix []byte, error error) {
 	sideChainId, error = getSideChainId()
 	if error != nil {
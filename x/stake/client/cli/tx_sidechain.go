@@ -300,6 +300,150 @@ func GetCmdSideChainUnbond(cdc *codec.Codec) *cobra.Command {
 	return cmd
 }
 
+func GetCmdSideChainLiquidDelegate(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bsc-liquid-delegate",
+		Short: "Delegate liquid tokens to a validator, and receive the transferable receipt tokens of the validator",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			amount, err := getAmount()
+			if err != nil {
+				return err
+			}
+
+			delAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			valAddr, err := getValidatorAddr(FlagAddressValidator)
+			if err != nil {
+				return err
+			}
+
+			sideChainId, err := getSideChainId()
+			if err != nil {
+				return err
+			}
+
+			msg := stake.NewMsgSideChainLiquidDelegate(sideChainId, delAddr, valAddr, amount)
+			return utils.GenerateOrBroadcastMsgs(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsAmount)
+	cmd.Flags().AddFlagSet(fsValidator)
+	cmd.Flags().AddFlagSet(fsSideChainId)
+	return cmd
+}
+
+func GetCmdSideChainLiquidUnbond(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bsc-liquid-unbond",
+		Short: "Burn the receipt tokens of a validator, and unbond the delegation they represent, the amount is in the receipt tokens",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			delAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+			valAddr, err := getValidatorAddr(FlagAddressValidator)
+			if err != nil {
+				return err
+			}
+
+			amount, err := getAmount()
+			if err != nil {
+				return err
+			}
+
+			sideChainId, err := getSideChainId()
+			if err != nil {
+				return err
+			}
+
+			msg := stake.NewMsgSideChainLiquidUndelegate(sideChainId, delAddr, valAddr, amount)
+			return utils.GenerateOrBroadcastMsgs(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().AddFlagSet(fsAmount)
+	cmd.Flags().AddFlagSet(fsValidator)
+	cmd.Flags().AddFlagSet(fsSideChainId)
+	return cmd
+}
+
+func GetCmdSetRewardRestake(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-reward-restake",
+		Short: fmt.Sprintf("opt in or out of delegating the rewards of a chain back to their validators, use %s as side chain id for the beacon chain", stake.ChainIDForBeaconChain),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			delAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			sideChainId, err := getSideChainId()
+			if err != nil {
+				return err
+			}
+
+			msg := stake.NewMsgSetRewardRestake(sideChainId, delAddr, viper.GetBool(FlagRestake))
+			return utils.GenerateOrBroadcastMsgs(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().Bool(FlagRestake, true, "whether the rewards are delegated back to their validators")
+	cmd.Flags().AddFlagSet(fsSideChainId)
+	return cmd
+}
+
+func GetCmdRetryCrossStakeRefund(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "retry-cross-stake-refund [bsc-address]",
+		Short: fmt.Sprintf("retry the abandoned refund of the cross stake package of %s from a BSC address", FlagSequence),
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			from, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			bscAddress, err := sdk.NewSmartChainAddress(args[0])
+			if err != nil {
+				return err
+			}
+			if !cmd.Flags().Changed(FlagSequence) {
+				return fmt.Errorf("%s is required", FlagSequence)
+			}
+
+			msg := stake.NewMsgRetryCrossStakeRefund(from, bscAddress, viper.GetUint64(FlagSequence))
+			return utils.GenerateOrBroadcastMsgs(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().Uint64(FlagSequence, 0, "The receive sequence of the package on the cross stake channel")
+	return cmd
+}
+
 func getSideChainId() (sideChainId string, err error) {
 	sideChainId = viper.GetString(FlagSideChainId)
 	if len(sideChainId) == 0 {
@@ -9,6 +9,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/utils"
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 	"github.com/gorilla/mux"
 )
@@ -101,6 +102,18 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Co
 		paramsHandlerFn(cliCtx, cdc),
 	).Methods("GET")
 
+	// Get the rewards distributed to a delegator
+	r.HandleFunc(
+		"/stake/delegators/{delegatorAddr}/rewards",
+		delegatorRewardsHandlerFn(cliCtx, cdc),
+	).Methods("GET")
+
+	// Get the rewards distributed to the delegators of a validator
+	r.HandleFunc(
+		"/stake/validators/{validatorAddr}/rewards",
+		validatorRewardsHandlerFn(cliCtx, cdc),
+	).Methods("GET")
+
 }
 
 // HTTP request handler to query a delegator delegations
@@ -256,3 +269,36 @@ func paramsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFu
 		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
 	}
 }
+
+// HTTP request handler to query a page of the rewards distributed to a delegator, filtered by the query parameters
+// side_chain_id, validator, from_date and to_date, and paginated by cursor in hex and limit
+func delegatorRewardsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		delegatorAddr, err := sdk.AccAddressFromBech32(mux.Vars(r)["delegatorAddr"])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		params := stake.QueryRewardHistoryParams{DelegatorAddr: delegatorAddr}
+		if validator := r.URL.Query().Get("validator"); len(validator) != 0 {
+			if params.ValidatorAddr, err = sdk.ValAddressFromBech32(validator); err != nil {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+		queryRewardHistory(w, r, cliCtx, cdc, params)
+	}
+}
+
+// HTTP request handler to query a page of the rewards distributed to the delegators of a validator, filtered by the
+// query parameters side_chain_id, from_date and to_date, and paginated by cursor in hex and limit
+func validatorRewardsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		validatorAddr, err := sdk.ValAddressFromBech32(mux.Vars(r)["validatorAddr"])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		queryRewardHistory(w, r, cliCtx, cdc, stake.QueryRewardHistoryParams{ValidatorAddr: validatorAddr})
+	}
+}
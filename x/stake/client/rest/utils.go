@@ -1,8 +1,10 @@
 package rest
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/client/tx"
@@ -141,3 +143,43 @@ func queryValidator(cliCtx context.CLIContext, cdc *codec.Codec, endpoint string
 		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
 	}
 }
+
+// queryRewardHistory completes the reward history query of params by the query parameters of the request
+func queryRewardHistory(w http.ResponseWriter, r *http.Request, cliCtx context.CLIContext, cdc *codec.Codec, params stake.QueryRewardHistoryParams) {
+	query := r.URL.Query()
+	if sideChainId := query.Get("side_chain_id"); sideChainId != types.ChainIDForBeaconChain {
+		params.BaseParams = stake.NewBaseParams(sideChainId)
+	}
+
+	var err error
+	params.FromTime, params.ToTime, err = stake.ParseRewardHistoryDates(query.Get("from_date"), query.Get("to_date"))
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if cursor := query.Get("cursor"); len(cursor) != 0 {
+		if params.Cursor, err = sdk.HexDecode(cursor); err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+	if limit := query.Get("limit"); len(limit) != 0 {
+		if params.Limit, err = strconv.Atoi(limit); err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	bz, err := json.Marshal(params)
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	res, err := cliCtx.QueryWithData("custom/stake/"+stake.QueryRewardHistory, bz)
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+}
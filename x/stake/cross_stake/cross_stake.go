@@ -1,6 +1,7 @@
 package cross_stake
 
 import (
+	"fmt"
 	"math/big"
 
 	"github.com/cosmos/cosmos-sdk/baseapp"
@@ -91,6 +92,10 @@ func (app *CrossStakeApp) ExecuteAckPackage(ctx sdk.Context, payload []byte) sdk
 	}
 	if err != nil {
 		app.stakeKeeper.Logger(ctx).Error("handle cross stake refund package error", "err", err.Error(), "package", string(payload))
+		if sdk.IsUpgrade(sdk.CrossStakeRecord) {
+			// the refund is recorded to be retried
+			return sdk.ExecuteResult{Err: sdk.ErrInternal(err.Error())}
+		}
 		return sdk.ExecuteResult{}
 	}
 
@@ -109,34 +114,145 @@ func (app *CrossStakeApp) ExecuteFailAckPackage(ctx sdk.Context, payload []byte)
 		return sdk.ExecuteResult{}
 	}
 
+	refundPackage, err := getFailAckRefundPackage(pack)
+	if err != nil {
+		app.stakeKeeper.Logger(ctx).Error("unknown cross stake fail ack event type", "err", err.Error(), "package", string(payload))
+		return sdk.ExecuteResult{}
+	}
+
 	var result sdk.ExecuteResult
+	switch refundPackage.EventType {
+	case types.CrossStakeTypeDistributeReward:
+		result, err = app.handleDistributeRewardRefund(ctx, refundPackage)
+	case types.CrossStakeTypeDistributeUndelegated:
+		result, err = app.handleDistributeUndelegatedRefund(ctx, refundPackage)
+	}
+	if err != nil {
+		app.stakeKeeper.Logger(ctx).Error("handle cross stake fail ack package error", "err", err.Error(), "package", string(payload))
+		if sdk.IsUpgrade(sdk.CrossStakeRecord) {
+			// the refund is recorded to be retried
+			return sdk.ExecuteResult{Err: sdk.ErrInternal(err.Error())}
+		}
+		return sdk.ExecuteResult{}
+	}
+
+	return result
+}
+
+// RecordPackage implements sdk.CrossChainPackageRecorder. It records the result of a cross stake request, or of a
+// refund of a cross stake distribution which fails on BSC, keyed by the BSC address and the sequence of the package.
+// A refund which fails is kept pending, to be retried at the breathe blocks.
+func (app *CrossStakeApp) RecordPackage(ctx sdk.Context, packageType sdk.CrossChainPackageType, sequence uint64, payload []byte, crash bool, result sdk.ExecuteResult) {
+	if !sdk.IsUpgrade(sdk.CrossStakeRecord) || len(payload) == 0 {
+		return
+	}
+
+	var record types.CrossStakeRecord
+	var err error
+	switch packageType {
+	case sdk.SynCrossChainPackageType:
+		record, err = getRequestRecord(payload, result)
+	case sdk.AckCrossChainPackageType:
+		var refundPackage *types.CrossStakeRefundPackage
+		if refundPackage, err = DeserializeCrossStakeRefundPackage(payload); err == nil {
+			record, err = getRefundRecord(refundPackage)
+		}
+	case sdk.FailAckCrossChainPackageType:
+		var pack interface{}
+		if pack, err = DeserializeCrossStakeFailAckPackage(payload); err == nil {
+			var refundPackage *types.CrossStakeRefundPackage
+			if refundPackage, err = getFailAckRefundPackage(pack); err == nil {
+				record, err = getRefundRecord(refundPackage)
+			}
+		}
+	default:
+		return
+	}
+	if err != nil {
+		app.stakeKeeper.Logger(ctx).Error("record cross stake package error", "err", err.Error(), "sequence", sequence)
+		return
+	}
+
+	record.Sequence = sequence
+	record.Height = ctx.BlockHeight()
+	if crash {
+		record.Status = types.CrossStakeRecordCrashed
+	} else if !result.IsOk() {
+		record.Status = types.CrossStakeRecordFailed
+	}
+	if record.RefundStatus == types.CrossStakeRefunded && record.Status != types.CrossStakeRecordSuccess {
+		record.RefundStatus = types.CrossStakeRefundPending
+	}
+	app.stakeKeeper.SetCrossStakeRecord(ctx, record)
+}
+
+func getRequestRecord(payload []byte, result sdk.ExecuteResult) (types.CrossStakeRecord, error) {
+	pack, err := DeserializeCrossStakeSynPackage(payload)
+	if err != nil {
+		return types.CrossStakeRecord{}, err
+	}
+
+	var record types.CrossStakeRecord
+	switch p := pack.(type) {
+	case *types.CrossStakeDelegateSynPackage:
+		record = types.CrossStakeRecord{BscAddress: p.DelAddr, EventType: types.CrossStakeTypeDelegate, Amount: p.Amount.Int64()}
+	case *types.CrossStakeUndelegateSynPackage:
+		record = types.CrossStakeRecord{BscAddress: p.DelAddr, EventType: types.CrossStakeTypeUndelegate, Amount: p.Amount.Int64()}
+	case *types.CrossStakeRedelegateSynPackage:
+		record = types.CrossStakeRecord{BscAddress: p.DelAddr, EventType: types.CrossStakeTypeRedelegate, Amount: p.Amount.Int64()}
+	}
+
+	// the error code of a failed request is the one acknowledged to BSC
+	if len(result.Payload) != 0 {
+		var ackPackage types.CrossStakeAckPackage
+		if err := rlp.DecodeBytes(result.Payload, &ackPackage); err == nil {
+			record.ErrorCode = ackPackage.ErrorCode
+		}
+	}
+	return record, nil
+}
+
+func getRefundRecord(pack *types.CrossStakeRefundPackage) (types.CrossStakeRecord, error) {
+	if pack.EventType != types.CrossStakeTypeDistributeReward && pack.EventType != types.CrossStakeTypeDistributeUndelegated {
+		return types.CrossStakeRecord{}, fmt.Errorf("unknown cross stake refund event type: %d", pack.EventType)
+	}
+	return types.CrossStakeRecord{
+		BscAddress:   pack.Recipient,
+		EventType:    pack.EventType,
+		Amount:       pack.Amount.Int64(),
+		RefundAddr:   getRefundAddr(pack),
+		RefundStatus: types.CrossStakeRefunded,
+	}, nil
+}
+
+// getFailAckRefundPackage returns the refund of a cross stake distribution package which crashes on BSC.
+func getFailAckRefundPackage(pack interface{}) (*types.CrossStakeRefundPackage, error) {
 	switch p := pack.(type) {
 	case *types.CrossStakeDistributeRewardSynPackage:
-		bcAmount := bsc.ConvertBSCAmountToBCAmount(p.Amount)
-		refundPackage := &types.CrossStakeRefundPackage{
+		return &types.CrossStakeRefundPackage{
 			EventType: types.CrossStakeTypeDistributeReward,
-			Amount:    big.NewInt(bcAmount),
+			Amount:    big.NewInt(bsc.ConvertBSCAmountToBCAmount(p.Amount)),
 			Recipient: p.Recipient,
-		}
-		result, err = app.handleDistributeRewardRefund(ctx, refundPackage)
+		}, nil
 	case *types.CrossStakeDistributeUndelegatedSynPackage:
-		bcAmount := bsc.ConvertBSCAmountToBCAmount(p.Amount)
-		refundPackage := &types.CrossStakeRefundPackage{
+		return &types.CrossStakeRefundPackage{
 			EventType: types.CrossStakeTypeDistributeUndelegated,
-			Amount:    big.NewInt(bcAmount),
+			Amount:    big.NewInt(bsc.ConvertBSCAmountToBCAmount(p.Amount)),
 			Recipient: p.Recipient,
-		}
-		result, err = app.handleDistributeUndelegatedRefund(ctx, refundPackage)
+		}, nil
 	default:
-		app.stakeKeeper.Logger(ctx).Error("unknown cross stake fail ack event type", "err", err.Error(), "package", string(payload))
-		return sdk.ExecuteResult{}
-	}
-	if err != nil {
-		app.stakeKeeper.Logger(ctx).Error("handle cross stake fail ack package error", "err", err.Error(), "package", string(payload))
-		return sdk.ExecuteResult{}
+		return nil, fmt.Errorf("unrecognized cross stake fail ack package")
 	}
+}
 
-	return result
+// getRefundAddr returns the account a refund goes to, which is the reward account of the delegator for the rewards,
+// and the delegation account of the delegator for the undelegated tokens.
+func getRefundAddr(pack *types.CrossStakeRefundPackage) sdk.AccAddress {
+	delAddr := types.GetStakeCAoB(pack.Recipient[:], types.DelegateCAoBSalt)
+	if pack.EventType == types.CrossStakeTypeDistributeReward {
+		return types.GetStakeCAoB(delAddr.Bytes(), types.RewardCAoBSalt)
+	}
+	return delAddr
 }
 
 func (app *CrossStakeApp) handleDelegate(ctx sdk.Context, pack *types.CrossStakeDelegateSynPackage, relayFee int64) (sdk.ExecuteResult, uint8, error) {
@@ -340,8 +456,7 @@ func (app *CrossStakeApp) handleRedelegate(ctx sdk.Context, pack *types.CrossSta
 func (app *CrossStakeApp) handleDistributeRewardRefund(ctx sdk.Context, pack *types.CrossStakeRefundPackage) (sdk.ExecuteResult, error) {
 	symbol := app.stakeKeeper.BondDenom(ctx)
 	coins := sdk.Coins{sdk.NewCoin(symbol, pack.Amount.Int64())}
-	delAddr := types.GetStakeCAoB(pack.Recipient[:], types.DelegateCAoBSalt)
-	refundAddr := types.GetStakeCAoB(delAddr.Bytes(), types.RewardCAoBSalt)
+	refundAddr := getRefundAddr(pack)
 	_, err := app.stakeKeeper.BankKeeper.SendCoins(ctx, sdk.PegAccount, refundAddr, coins)
 	if err != nil {
 		return sdk.ExecuteResult{}, err
@@ -362,7 +477,7 @@ func (app *CrossStakeApp) handleDistributeRewardRefund(ctx sdk.Context, pack *ty
 func (app *CrossStakeApp) handleDistributeUndelegatedRefund(ctx sdk.Context, pack *types.CrossStakeRefundPackage) (sdk.ExecuteResult, error) {
 	symbol := app.stakeKeeper.BondDenom(ctx)
 	coins := sdk.Coins{sdk.NewCoin(symbol, pack.Amount.Int64())}
-	refundAddr := types.GetStakeCAoB(pack.Recipient[:], types.DelegateCAoBSalt)
+	refundAddr := getRefundAddr(pack)
 	_, err := app.stakeKeeper.BankKeeper.SendCoins(ctx, sdk.PegAccount, refundAddr, coins)
 	if err != nil {
 		return sdk.ExecuteResult{}, err
@@ -0,0 +1,93 @@
+package cross_stake
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/bsc/rlp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/keeper"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+func TestRecordPackage(t *testing.T) {
+	ctx, _, k := keeper.CreateTestInput(t, false, 1000)
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.CrossStakeRecord, 199)
+	sdk.UpgradeMgr.SetHeight(200)
+	ctx = ctx.WithBlockHeight(200)
+	app := NewCrossStakeApp(k)
+
+	bscAddr, err := sdk.NewSmartChainAddress("0x0000000000000000000000000000000000001000")
+	require.Nil(t, err)
+
+	// a failed request is recorded with the error code acknowledged to BSC
+	paramsBytes, err := rlp.EncodeToBytes(types.CrossStakeDelegateSynPackage{
+		DelAddr:   bscAddr,
+		Validator: sdk.ValAddress(keeper.Addrs[0]),
+		Amount:    big.NewInt(10e8),
+	})
+	require.Nil(t, err)
+	payload, err := rlp.EncodeToBytes(CrossStakeSynPackageFromBSC{EventType: types.CrossStakeTypeDelegate, ParamsBytes: paramsBytes})
+	require.Nil(t, err)
+	ackPayload, err := rlp.EncodeToBytes(types.CrossStakeAckPackage{
+		Status:    types.CrossStakeFailed,
+		ErrorCode: CrossStakeErrValidatorNotFound,
+		PackBytes: payload,
+	})
+	require.Nil(t, err)
+	result := sdk.ExecuteResult{Err: types.ErrNoValidatorFound(types.DefaultCodespace), Payload: ackPayload}
+	app.RecordPackage(ctx, sdk.SynCrossChainPackageType, 5, payload, false, result)
+
+	record, found := k.GetCrossStakeRecord(ctx, bscAddr, 5)
+	require.True(t, found)
+	require.Equal(t, types.CrossStakeRecord{
+		BscAddress: bscAddr,
+		Sequence:   5,
+		EventType:  types.CrossStakeTypeDelegate,
+		Height:     200,
+		Status:     types.CrossStakeRecordFailed,
+		ErrorCode:  CrossStakeErrValidatorNotFound,
+		Amount:     10e8,
+	}, record)
+
+	// a refund which fails on the fail ack package is pending, and is retried at the breathe blocks
+	payload, err = rlp.EncodeToBytes(types.CrossStakeDistributeRewardSynPackage{
+		EventType: types.CrossStakeTypeDistributeReward,
+		Recipient: bscAddr,
+		Amount:    big.NewInt(1e18),
+	})
+	require.Nil(t, err)
+	result = app.ExecuteFailAckPackage(ctx, payload)
+	require.False(t, result.IsOk())
+	app.RecordPackage(ctx, sdk.FailAckCrossChainPackageType, 6, payload, false, result)
+
+	refundAddr := types.GetStakeCAoB(types.GetStakeCAoB(bscAddr[:], types.DelegateCAoBSalt).Bytes(), types.RewardCAoBSalt)
+	record, found = k.GetCrossStakeRecord(ctx, bscAddr, 6)
+	require.True(t, found)
+	require.Equal(t, types.CrossStakeRecordFailed, record.Status)
+	require.Equal(t, types.CrossStakeRefundPending, record.RefundStatus)
+	require.Equal(t, refundAddr, record.RefundAddr)
+	require.EqualValues(t, 1e8, record.Amount)
+	require.Len(t, k.GetCrossStakeRecords(ctx, bscAddr), 2)
+
+	bondDenom := k.BondDenom(ctx)
+	_, _, sdkErr := k.BankKeeper.AddCoins(ctx, sdk.PegAccount, sdk.Coins{sdk.NewCoin(bondDenom, 1e8)})
+	require.Nil(t, sdkErr)
+	require.Len(t, k.RetryCrossStakeRefunds(ctx), 1)
+	record, _ = k.GetCrossStakeRecord(ctx, bscAddr, 6)
+	require.Equal(t, types.CrossStakeRefunded, record.RefundStatus)
+	require.EqualValues(t, 1e8, k.BankKeeper.GetCoins(ctx, refundAddr).AmountOf(bondDenom))
+
+	// a refund which succeeds is recorded as refunded
+	_, _, sdkErr = k.BankKeeper.AddCoins(ctx, sdk.PegAccount, sdk.Coins{sdk.NewCoin(bondDenom, 1e8)})
+	require.Nil(t, sdkErr)
+	result = app.ExecuteFailAckPackage(ctx, payload)
+	require.True(t, result.IsOk())
+	app.RecordPackage(ctx, sdk.FailAckCrossChainPackageType, 7, payload, false, result)
+	record, _ = k.GetCrossStakeRecord(ctx, bscAddr, 7)
+	require.Equal(t, types.CrossStakeRecordSuccess, record.Status)
+	require.Equal(t, types.CrossStakeRefunded, record.RefundStatus)
+	require.Len(t, k.GetPendingCrossStakeRefunds(ctx), 0)
+}
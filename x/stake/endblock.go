@@ -42,7 +42,10 @@ func EndBreatheBlock(ctx sdk.Context, k keeper.Keeper) (validatorUpdates []abci.
 	var events sdk.Events
 	var newVals []types.Validator
 	var completedREDs []types.DVVTriplet
+	// the commission raises apply before the validators are elected, so that they are in the snapshot of this day
+	commissionEvents := k.ApplyPendingCommissions(ctx, ChainIDForBeaconChain)
 	newVals, validatorUpdates, completedUbds, completedREDs, events = handleValidatorAndDelegations(ctx, k)
+	events = events.AppendEvents(commissionEvents)
 	ctx.Logger().Debug("EndBreatheBlock", "newValsLen", len(newVals), "newVals", newVals)
 	publishCompletedUBD(k, completedUbds, ChainIDForBeaconChain, ctx.BlockHeight())
 	publishCompletedRED(k, completedREDs, ChainIDForBeaconChain)
@@ -62,6 +65,7 @@ func EndBreatheBlock(ctx sdk.Context, k keeper.Keeper) (validatorUpdates []abci.
 		sideChainIds, storePrefixes := k.ScKeeper.GetAllSideChainPrefixes(ctx)
 		for i := range storePrefixes {
 			sideChainCtx := ctx.WithSideChainKeyPrefix(storePrefixes[i])
+			events = events.AppendEvents(k.ApplyPendingCommissions(sideChainCtx, sideChainIds[i]))
 			newVals, _, completedUbds, completedREDs, scEvents := handleValidatorAndDelegations(sideChainCtx, k)
 			if k.ExistHeightValidators(sideChainCtx) { // will not send ibc package if no snapshot of validators stored ever
 				saveSideChainValidatorsToIBC(ctx, sideChainIds[i], newVals, k)
@@ -91,6 +95,7 @@ func EndBreatheBlock(ctx sdk.Context, k keeper.Keeper) (validatorUpdates []abci.
 			// distribute beacon chain rewards
 			k.DistributeInBreathBlock(ctx, types.ChainIDForBeaconChain)
 		}
+		events = events.AppendEvents(k.RetryCrossStakeRefunds(ctx))
 	}
 	ctx.EventManager().EmitEvents(events)
 	return
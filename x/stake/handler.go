@@ -53,6 +53,26 @@ func NewHandler(k keeper.Keeper, govKeeper gov.Keeper) sdk.Handler {
 			return handleMsgSideChainRedelegate(ctx, msg, k)
 		case types.MsgSideChainUndelegate:
 			return handleMsgSideChainUndelegate(ctx, msg, k)
+		case types.MsgSetRewardRestake:
+			if !sdk.IsUpgrade(sdk.RewardRestake) {
+				return sdk.ErrMsgNotSupported("").Result()
+			}
+			return handleMsgSetRewardRestake(ctx, msg, k)
+		case types.MsgSideChainLiquidDelegate:
+			if !sdk.IsUpgrade(sdk.LiquidStaking) {
+				return sdk.ErrMsgNotSupported("").Result()
+			}
+			return handleMsgSideChainLiquidDelegate(ctx, msg, k)
+		case types.MsgSideChainLiquidUndelegate:
+			if !sdk.IsUpgrade(sdk.LiquidStaking) {
+				return sdk.ErrMsgNotSupported("").Result()
+			}
+			return handleMsgSideChainLiquidUndelegate(ctx, msg, k)
+		case types.MsgRetryCrossStakeRefund:
+			if !sdk.IsUpgrade(sdk.CrossStakeRecord) {
+				return sdk.ErrMsgNotSupported("").Result()
+			}
+			return handleMsgRetryCrossStakeRefund(ctx, msg, k)
 		default:
 			return sdk.ErrTxDecode("invalid message parse in staking module").Result()
 		}
@@ -318,13 +338,16 @@ func handleMsgEditValidator(ctx sdk.Context, msg types.MsgEditValidator, k keepe
 
 	validator.Description = description
 
+	scheduled := false
 	if msg.CommissionRate != nil {
-		commission, err := k.UpdateValidatorCommission(ctx, validator, *msg.CommissionRate)
+		commission, isScheduled, err := k.ChangeValidatorCommission(ctx, validator, *msg.CommissionRate, types.ChainIDForBeaconChain)
 		if err != nil {
 			return err.Result()
 		}
-		validator.Commission = commission
-		onValidatorModified = true
+		if scheduled = isScheduled; !scheduled {
+			validator.Commission = commission
+			onValidatorModified = true
+		}
 	}
 	if onValidatorModified {
 		k.OnValidatorModified(ctx, msg.ValidatorAddr)
@@ -332,14 +355,17 @@ func handleMsgEditValidator(ctx sdk.Context, msg types.MsgEditValidator, k keepe
 
 	k.SetValidator(ctx, validator)
 
-	tags := sdk.NewTags(
+	resTags := sdk.NewTags(
 		tags.DstValidator, []byte(msg.ValidatorAddr.String()),
 		tags.Moniker, []byte(description.Moniker),
 		tags.Identity, []byte(description.Identity),
 	)
+	if scheduled {
+		resTags = resTags.AppendTag(tags.PendingCommission, []byte(msg.CommissionRate.String()))
+	}
 
 	return sdk.Result{
-		Tags: tags,
+		Tags: resTags,
 	}
 }
 
@@ -3,6 +3,7 @@ package stake
 import (
 	"bytes"
 	"fmt"
+	"strconv"
 
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -101,13 +102,16 @@ func handleMsgEditSideChainValidator(ctx sdk.Context, msg MsgEditSideChainValida
 		validator.Description = description
 	}
 
+	scheduled := false
 	if msg.CommissionRate != nil {
-		commission, err := k.UpdateValidatorCommission(ctx, validator, *msg.CommissionRate)
+		commission, isScheduled, err := k.ChangeValidatorCommission(ctx, validator, *msg.CommissionRate, msg.SideChainId)
 		if err != nil {
 			return err.Result()
 		}
-		validator.Commission = commission
-		k.OnValidatorModified(ctx, msg.ValidatorAddr)
+		if scheduled = isScheduled; !scheduled {
+			validator.Commission = commission
+			k.OnValidatorModified(ctx, msg.ValidatorAddr)
+		}
 	}
 
 	if len(msg.SideFeeAddr) != 0 {
@@ -136,12 +140,16 @@ func handleMsgEditSideChainValidator(ctx sdk.Context, msg MsgEditSideChainValida
 	}
 
 	k.SetValidator(ctx, validator)
+	resTags := sdk.NewTags(
+		tags.DstValidator, []byte(msg.ValidatorAddr.String()),
+		tags.Moniker, []byte(validator.Description.Moniker),
+		tags.Identity, []byte(validator.Description.Identity),
+	)
+	if scheduled {
+		resTags = resTags.AppendTag(tags.PendingCommission, []byte(msg.CommissionRate.String()))
+	}
 	return sdk.Result{
-		Tags: sdk.NewTags(
-			tags.DstValidator, []byte(msg.ValidatorAddr.String()),
-			tags.Moniker, []byte(validator.Description.Moniker),
-			tags.Identity, []byte(validator.Description.Identity),
-		),
+		Tags: resTags,
 	}
 }
 
@@ -243,13 +251,16 @@ func handleMsgEditSideChainValidatorWithVoteAddr(ctx sdk.Context, msg MsgEditSid
 		validator.Description = description
 	}
 
+	scheduled := false
 	if msg.CommissionRate != nil {
-		commission, err := k.UpdateValidatorCommission(ctx, validator, *msg.CommissionRate)
+		commission, isScheduled, err := k.ChangeValidatorCommission(ctx, validator, *msg.CommissionRate, msg.SideChainId)
 		if err != nil {
 			return err.Result()
 		}
-		validator.Commission = commission
-		k.OnValidatorModified(ctx, msg.ValidatorAddr)
+		if scheduled = isScheduled; !scheduled {
+			validator.Commission = commission
+			k.OnValidatorModified(ctx, msg.ValidatorAddr)
+		}
 	}
 
 	if len(msg.SideFeeAddr) != 0 {
@@ -288,12 +299,16 @@ func handleMsgEditSideChainValidatorWithVoteAddr(ctx sdk.Context, msg MsgEditSid
 	}
 
 	k.SetValidator(ctx, validator)
+	resTags := sdk.NewTags(
+		tags.DstValidator, []byte(msg.ValidatorAddr.String()),
+		tags.Moniker, []byte(validator.Description.Moniker),
+		tags.Identity, []byte(validator.Description.Identity),
+	)
+	if scheduled {
+		resTags = resTags.AppendTag(tags.PendingCommission, []byte(msg.CommissionRate.String()))
+	}
 	return sdk.Result{
-		Tags: sdk.NewTags(
-			tags.DstValidator, []byte(msg.ValidatorAddr.String()),
-			tags.Moniker, []byte(validator.Description.Moniker),
-			tags.Identity, []byte(validator.Description.Identity),
-		),
+		Tags: resTags,
 	}
 }
 
@@ -471,14 +486,107 @@ func handleMsgSideChainUndelegate(ctx sdk.Context, msg MsgSideChainUndelegate, k
 	return sdk.Result{Data: finishTime, Tags: tags}
 }
 
-// we allow the self-delegator delegating/redelegating to its validator.
-// but the operator is not allowed if it is not a self-delegator
 func checkOperatorAsDelegator(k Keeper, delegator sdk.AccAddress, validator Validator) sdk.Error {
-	delegatorIsOperator := bytes.Equal(delegator.Bytes(), validator.OperatorAddr.Bytes())
-	operatorIsSelfDelegator := validator.IsSelfDelegator(sdk.AccAddress(validator.OperatorAddr))
+	return k.CheckOperatorAsDelegator(delegator, validator)
+}
+
+func handleMsgSetRewardRestake(ctx sdk.Context, msg MsgSetRewardRestake, k keeper.Keeper) sdk.Result {
+	if msg.SideChainId != types.ChainIDForBeaconChain {
+		if scCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, msg.SideChainId); err != nil {
+			return ErrInvalidSideChainId(k.Codespace()).Result()
+		} else {
+			ctx = scCtx
+		}
+	}
+
+	k.SetRewardRestake(ctx, msg.DelegatorAddr, msg.Restake)
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			tags.Delegator, []byte(msg.DelegatorAddr.String()),
+			tags.RewardRestake, []byte(strconv.FormatBool(msg.Restake)),
+		),
+	}
+}
+
+func handleMsgSideChainLiquidDelegate(ctx sdk.Context, msg MsgSideChainLiquidDelegate, k keeper.Keeper) sdk.Result {
+	if scCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, msg.SideChainId); err != nil {
+		return ErrInvalidSideChainId(k.Codespace()).Result()
+	} else {
+		ctx = scCtx
+	}
+
+	minDelegationChange := k.MinDelegationChange(ctx)
+	if msg.Delegation.Amount < minDelegationChange {
+		return ErrBadDelegationAmount(DefaultCodespace, fmt.Sprintf("delegation must not be less than %d", minDelegationChange)).Result()
+	}
+
+	validator, found := k.GetValidator(ctx, msg.ValidatorAddr)
+	if !found {
+		return ErrNoValidatorFound(k.Codespace()).Result()
+	}
+
+	if msg.Delegation.Denom != k.BondDenom(ctx) {
+		return ErrBadDenom(k.Codespace()).Result()
+	}
+
+	if err := checkOperatorAsDelegator(k, msg.DelegatorAddr, validator); err != nil {
+		return err.Result()
+	}
+
+	// the liquid stake account is never the self-delegator
+	if validator.Jailed {
+		return ErrValidatorJailed(k.Codespace()).Result()
+	}
+
+	receipt, err := k.LiquidDelegate(ctx, msg.DelegatorAddr, msg.Delegation, validator)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			tags.Delegator, []byte(msg.DelegatorAddr.String()),
+			tags.DstValidator, []byte(msg.ValidatorAddr.String()),
+			tags.LiquidStakeReceipt, []byte(receipt.String()),
+		),
+	}
+}
+
+func handleMsgSideChainLiquidUndelegate(ctx sdk.Context, msg MsgSideChainLiquidUndelegate, k keeper.Keeper) sdk.Result {
+	if scCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, msg.SideChainId); err != nil {
+		return ErrInvalidSideChainId(k.Codespace()).Result()
+	} else {
+		ctx = scCtx
+	}
 
-	if delegatorIsOperator && !operatorIsSelfDelegator {
-		return ErrInvalidDelegator(k.Codespace())
+	ubd, err := k.LiquidUndelegate(ctx, msg.DelegatorAddr, msg.ValidatorAddr, msg.Amount)
+	if err != nil {
+		return err.Result()
 	}
-	return nil
+
+	finishTime := types.MsgCdc.MustMarshalBinaryLengthPrefixed(ubd.MinTime)
+
+	return sdk.Result{
+		Data: finishTime,
+		Tags: sdk.NewTags(
+			tags.Delegator, []byte(msg.DelegatorAddr.String()),
+			tags.SrcValidator, []byte(msg.ValidatorAddr.String()),
+			tags.EndTime, finishTime,
+			tags.LiquidStakeReceipt, []byte(msg.Amount.String()),
+		),
+	}
+}
+
+func handleMsgRetryCrossStakeRefund(ctx sdk.Context, msg MsgRetryCrossStakeRefund, k keeper.Keeper) sdk.Result {
+	record, err := k.RetryAbandonedCrossStakeRefund(ctx, msg.BscAddress, msg.Sequence)
+	if err != nil {
+		return err.Result()
+	}
+	resTags := sdk.NewTags(
+		types.AttributeKeyBscAddress, []byte(record.BscAddress.String()),
+		types.AttributeKeySequence, []byte(strconv.FormatUint(record.Sequence, 10)),
+		types.AttributeKeyRefundStatus, []byte(record.RefundStatus.String()),
+	)
+	resTags = append(resTags, sdk.GetPegOutTag(k.BondDenom(ctx), record.Amount))
+	return sdk.Result{Tags: resTags}
 }
@@ -0,0 +1,127 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// GetPendingCommission returns the pending commission raise of a validator.
+func (k Keeper) GetPendingCommission(ctx sdk.Context, operatorAddr sdk.ValAddress) (pending types.PendingCommission, found bool) {
+	bz := ctx.KVStore(k.storeKey).Get(GetPendingCommissionKey(operatorAddr))
+	if bz == nil {
+		return pending, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &pending)
+	return pending, true
+}
+
+func (k Keeper) SetPendingCommission(ctx sdk.Context, pending types.PendingCommission) {
+	ctx.KVStore(k.storeKey).Set(GetPendingCommissionKey(pending.ValidatorAddr), k.cdc.MustMarshalBinaryLengthPrefixed(pending))
+}
+
+func (k Keeper) RemovePendingCommission(ctx sdk.Context, operatorAddr sdk.ValAddress) {
+	ctx.KVStore(k.storeKey).Delete(GetPendingCommissionKey(operatorAddr))
+}
+
+// GetAllPendingCommissions returns the pending commission raises of all validators.
+func (k Keeper) GetAllPendingCommissions(ctx sdk.Context) []types.PendingCommission {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), PendingCommissionKey)
+	defer iterator.Close()
+
+	pendings := make([]types.PendingCommission, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		var pending types.PendingCommission
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &pending)
+		pendings = append(pendings, pending)
+	}
+	return pendings
+}
+
+// ChangeValidatorCommission changes the commission rate of a validator. Once the notice period is set, a raise is
+// scheduled to apply after CommissionNoticeBreatheBlocks breathe blocks instead, which replaces the raise scheduled
+// before, so that the delegators have time to redelegate. A cut applies at once and cancels the scheduled raise.
+// It tells if the change is scheduled, the commission of the validator is unchanged then.
+func (k Keeper) ChangeValidatorCommission(ctx sdk.Context, validator types.Validator, newRate sdk.Dec, chainId string) (types.Commission, bool, sdk.Error) {
+	if !sdk.IsUpgrade(sdk.CommissionChangeNotice) {
+		commission, err := k.UpdateValidatorCommission(ctx, validator, newRate)
+		return commission, false, err
+	}
+
+	notice := k.CommissionNoticeBreatheBlocks(ctx)
+	if notice == 0 || !newRate.GT(validator.Commission.Rate) {
+		commission, err := k.UpdateValidatorCommission(ctx, validator, newRate)
+		if err != nil {
+			return commission, false, err
+		}
+		k.RemovePendingCommission(ctx, validator.OperatorAddr)
+		return commission, false, nil
+	}
+
+	if err := validator.Commission.ValidateNewRate(newRate, ctx.BlockHeader().Time); err != nil {
+		return validator.Commission, false, err
+	}
+	k.SetPendingCommission(ctx, types.PendingCommission{
+		ValidatorAddr:     validator.OperatorAddr,
+		Rate:              newRate,
+		RequestHeight:     ctx.BlockHeight(),
+		RequestTime:       ctx.BlockHeader().Time,
+		BreatheBlocksLeft: notice,
+	})
+
+	if ctx.IsDeliverTx() && k.PbsbServer != nil {
+		k.PbsbServer.Publish(types.CommissionChangeEvent{
+			StakeEvent:        types.StakeEvent{IsFromTx: true},
+			Validator:         validator.OperatorAddr,
+			ChainId:           chainId,
+			OldRate:           validator.Commission.Rate,
+			NewRate:           newRate,
+			BreatheBlocksLeft: notice,
+		})
+	}
+	return validator.Commission, true, nil
+}
+
+// ApplyPendingCommissions counts down the pending commission raises at a breathe block, and applies the ones whose
+// notice period ends.
+func (k Keeper) ApplyPendingCommissions(ctx sdk.Context, chainId string) sdk.Events {
+	if !sdk.IsUpgrade(sdk.CommissionChangeNotice) {
+		return nil
+	}
+
+	var events sdk.Events
+	for _, pending := range k.GetAllPendingCommissions(ctx) {
+		pending.BreatheBlocksLeft--
+		if pending.BreatheBlocksLeft > 0 {
+			k.SetPendingCommission(ctx, pending)
+			continue
+		}
+
+		k.RemovePendingCommission(ctx, pending.ValidatorAddr)
+		validator, found := k.GetValidator(ctx, pending.ValidatorAddr)
+		if !found {
+			continue
+		}
+		oldRate := validator.Commission.Rate
+		validator.Commission.Rate = pending.Rate
+		validator.Commission.UpdateTime = ctx.BlockHeader().Time
+		k.SetValidator(ctx, validator)
+		k.OnValidatorModified(ctx, validator.OperatorAddr)
+
+		events = events.AppendEvent(sdk.NewEvent(
+			types.EventTypeApplyCommission,
+			sdk.NewAttribute(types.AttributeKeyValidator, validator.OperatorAddr.String()),
+			sdk.NewAttribute(types.AttributeKeyCommissionRate, pending.Rate.String()),
+			sdk.NewAttribute(types.AttributeKeySideChainId, chainId),
+		))
+		if ctx.IsDeliverTx() && k.PbsbServer != nil {
+			k.PbsbServer.Publish(types.CommissionChangeEvent{
+				Validator: validator.OperatorAddr,
+				ChainId:   chainId,
+				OldRate:   oldRate,
+				NewRate:   pending.Rate,
+				Applied:   true,
+			})
+		}
+	}
+	return events
+}
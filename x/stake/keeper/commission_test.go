@@ -0,0 +1,68 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+func TestScheduleCommissionRaise(t *testing.T) {
+	ctx, _, k := CreateTestInput(t, false, 1000)
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.CommissionChangeNotice, 199)
+	sdk.UpgradeMgr.SetHeight(200)
+	params := k.GetParams(ctx)
+	params.CommissionNoticeBreatheBlocks = 2
+	k.SetParams(ctx, params)
+	require.EqualValues(t, 2, k.CommissionNoticeBreatheBlocks(ctx))
+
+	now := time.Now().UTC()
+	ctx = ctx.WithBlockHeader(abci.Header{Height: 200, Time: now})
+	commission := types.NewCommissionWithTime(sdk.NewDecWithPrec(1, 1), sdk.NewDecWithPrec(5, 1), sdk.NewDecWithPrec(1, 1), now.Add(-48*time.Hour))
+	validator, _ := types.NewValidator(addrVals[0], PKs[0], types.Description{}).SetInitialCommission(commission)
+	k.SetValidator(ctx, validator)
+
+	// a raise is scheduled
+	_, scheduled, err := k.ChangeValidatorCommission(ctx, validator, sdk.NewDecWithPrec(3, 1), types.ChainIDForBeaconChain)
+	require.Error(t, err)
+	require.False(t, scheduled)
+	newCommission, scheduled, err := k.ChangeValidatorCommission(ctx, validator, sdk.NewDecWithPrec(2, 1), types.ChainIDForBeaconChain)
+	require.Nil(t, err)
+	require.True(t, scheduled)
+	require.Equal(t, commission, newCommission)
+	pending, found := k.GetPendingCommission(ctx, validator.OperatorAddr)
+	require.True(t, found)
+	require.Equal(t, sdk.NewDecWithPrec(2, 1), pending.Rate)
+	require.EqualValues(t, 2, pending.BreatheBlocksLeft)
+
+	// it applies at the second breathe block
+	require.Len(t, k.ApplyPendingCommissions(ctx, types.ChainIDForBeaconChain), 0)
+	validator, _ = k.GetValidator(ctx, validator.OperatorAddr)
+	require.Equal(t, sdk.NewDecWithPrec(1, 1), validator.Commission.Rate)
+	require.Len(t, k.GetAllPendingCommissions(ctx), 1)
+
+	ctx = ctx.WithBlockHeader(abci.Header{Height: 300, Time: now.Add(48 * time.Hour)})
+	events := k.ApplyPendingCommissions(ctx, types.ChainIDForBeaconChain)
+	require.Len(t, events, 1)
+	require.Equal(t, types.EventTypeApplyCommission, events[0].Type)
+	validator, _ = k.GetValidator(ctx, validator.OperatorAddr)
+	require.Equal(t, sdk.NewDecWithPrec(2, 1), validator.Commission.Rate)
+	require.Equal(t, now.Add(48*time.Hour), validator.Commission.UpdateTime)
+	require.Len(t, k.GetAllPendingCommissions(ctx), 0)
+
+	// a cut applies at once and cancels the scheduled raise
+	ctx = ctx.WithBlockHeader(abci.Header{Height: 400, Time: now.Add(96 * time.Hour)})
+	_, scheduled, err = k.ChangeValidatorCommission(ctx, validator, sdk.NewDecWithPrec(3, 1), types.ChainIDForBeaconChain)
+	require.Nil(t, err)
+	require.True(t, scheduled)
+	newCommission, scheduled, err = k.ChangeValidatorCommission(ctx, validator, sdk.NewDecWithPrec(15, 2), types.ChainIDForBeaconChain)
+	require.Nil(t, err)
+	require.False(t, scheduled)
+	require.Equal(t, sdk.NewDecWithPrec(15, 2), newCommission.Rate)
+	_, found = k.GetPendingCommission(ctx, validator.OperatorAddr)
+	require.False(t, found)
+}
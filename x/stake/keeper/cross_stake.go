@@ -0,0 +1,208 @@
+package keeper
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/bsc"
+	"github.com/cosmos/cosmos-sdk/pubsub"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/fees"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// The cross stake records are kept out of the side chain stores, as the cross stake packages are.
+
+// GetCrossStakeRecord returns the cross stake record of the package of a sequence from or to a bsc address.
+func (k Keeper) GetCrossStakeRecord(ctx sdk.Context, bscAddr sdk.SmartChainAddress, sequence uint64) (record types.CrossStakeRecord, found bool) {
+	bz := ctx.DepriveSideChainKeyPrefix().KVStore(k.storeKey).Get(GetCrossStakeRecordKey(bscAddr, sequence))
+	if bz == nil {
+		return record, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &record)
+	return record, true
+}
+
+// SetCrossStakeRecord sets a cross stake record, and keeps it in the pending refunds while its refund is pending.
+func (k Keeper) SetCrossStakeRecord(ctx sdk.Context, record types.CrossStakeRecord) {
+	store := ctx.DepriveSideChainKeyPrefix().KVStore(k.storeKey)
+	store.Set(GetCrossStakeRecordKey(record.BscAddress, record.Sequence), k.cdc.MustMarshalBinaryLengthPrefixed(record))
+	if record.RefundStatus == types.CrossStakeRefundPending {
+		store.Set(GetCrossStakePendingRefundKey(record.BscAddress, record.Sequence), []byte{})
+	} else {
+		store.Delete(GetCrossStakePendingRefundKey(record.BscAddress, record.Sequence))
+	}
+}
+
+// GetCrossStakeRecords returns the cross stake records of a bsc address, in the order of their sequences.
+func (k Keeper) GetCrossStakeRecords(ctx sdk.Context, bscAddr sdk.SmartChainAddress) []types.CrossStakeRecord {
+	iterator := sdk.KVStorePrefixIterator(ctx.DepriveSideChainKeyPrefix().KVStore(k.storeKey), GetCrossStakeRecordsKey(bscAddr))
+	defer iterator.Close()
+
+	records := make([]types.CrossStakeRecord, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		var record types.CrossStakeRecord
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &record)
+		records = append(records, record)
+	}
+	return records
+}
+
+// GetPendingCrossStakeRefunds returns the cross stake records whose refunds are pending.
+func (k Keeper) GetPendingCrossStakeRefunds(ctx sdk.Context) []types.CrossStakeRecord {
+	iterator := sdk.KVStorePrefixIterator(ctx.DepriveSideChainKeyPrefix().KVStore(k.storeKey), CrossStakePendingRefundKey)
+	defer iterator.Close()
+
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+
+	records := make([]types.CrossStakeRecord, 0, len(keys))
+	for _, key := range keys {
+		var bscAddr sdk.SmartChainAddress
+		copy(bscAddr[:], key[len(CrossStakePendingRefundKey):])
+		sequence := getSequenceFromBytes(key[len(CrossStakePendingRefundKey)+sdk.SmartChainAddressLength:])
+		if record, found := k.GetCrossStakeRecord(ctx, bscAddr, sequence); found {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// RetryCrossStakeRefunds retries the pending refunds of the cross stake records at a breathe block. A refund which
+// still fails after MaxCrossStakeRefundRetries retries is abandoned, its tokens stay in the peg account until
+// MsgRetryCrossStakeRefund pays it.
+func (k Keeper) RetryCrossStakeRefunds(ctx sdk.Context) sdk.Events {
+	if !sdk.IsUpgrade(sdk.CrossStakeRecord) {
+		return nil
+	}
+
+	var events sdk.Events
+	denom := k.BondDenom(ctx)
+	for _, record := range k.GetPendingCrossStakeRefunds(ctx) {
+		record.RefundRetries++
+		tags := sdk.NewTags(
+			types.AttributeKeyBscAddress, []byte(record.BscAddress.String()),
+			types.AttributeKeySequence, []byte(strconv.FormatUint(record.Sequence, 10)),
+		)
+
+		if err := k.payCrossStakeRefund(ctx, &record, denom); err != nil {
+			k.Logger(ctx).Error("retry cross stake refund error", "bscAddress", record.BscAddress.String(),
+				"sequence", record.Sequence, "retries", record.RefundRetries, "err", err.Error())
+			if record.RefundRetries >= types.MaxCrossStakeRefundRetries {
+				record.RefundStatus = types.CrossStakeRefundAbandoned
+			}
+		} else {
+			tags = append(tags, sdk.GetPegOutTag(denom, record.Amount))
+		}
+		k.SetCrossStakeRecord(ctx, record)
+
+		tags = append(tags, sdk.MakeTag(types.AttributeKeyRefundStatus, []byte(record.RefundStatus.String())))
+		events = append(events, sdk.Event{
+			Type:       types.EventTypeRetryRefund,
+			Attributes: tags,
+		})
+	}
+	return events
+}
+
+// RetryAbandonedCrossStakeRefund pays an abandoned refund of a cross stake record from the peg account, the record
+// stays abandoned if the refund fails again.
+func (k Keeper) RetryAbandonedCrossStakeRefund(ctx sdk.Context, bscAddr sdk.SmartChainAddress, sequence uint64) (types.CrossStakeRecord, sdk.Error) {
+	record, found := k.GetCrossStakeRecord(ctx, bscAddr, sequence)
+	if !found {
+		return types.CrossStakeRecord{}, types.ErrNoCrossStakeRecord(k.Codespace())
+	}
+	if record.RefundStatus != types.CrossStakeRefundAbandoned {
+		return types.CrossStakeRecord{}, types.ErrRefundNotAbandoned(k.Codespace())
+	}
+
+	record.RefundRetries++
+	if err := k.payCrossStakeRefund(ctx, &record, k.BondDenom(ctx)); err != nil {
+		return types.CrossStakeRecord{}, err
+	}
+	k.SetCrossStakeRecord(ctx, record)
+	return record, nil
+}
+
+// payCrossStakeRefund sends the refund of a record from the peg account to its refund address, and marks the record
+// refunded if it succeeds.
+func (k Keeper) payCrossStakeRefund(ctx sdk.Context, record *types.CrossStakeRecord, denom string) sdk.Error {
+	coins := sdk.Coins{sdk.NewCoin(denom, record.Amount)}
+	if _, err := k.BankKeeper.SendCoins(ctx, sdk.PegAccount, record.RefundAddr, coins); err != nil {
+		return err
+	}
+	record.RefundStatus = types.CrossStakeRefunded
+
+	if ctx.IsDeliverTx() && k.PbsbServer != nil {
+		k.AddrPool.AddAddrs([]sdk.AccAddress{sdk.PegAccount, record.RefundAddr})
+		k.PbsbServer.Publish(pubsub.CrossTransferEvent{
+			ChainId: k.DestChainName,
+			Type:    types.TransferInType,
+			From:    sdk.PegAccount.String(),
+			Denom:   denom,
+			To:      []pubsub.CrossReceiver{{record.RefundAddr.String(), record.Amount}},
+		})
+	}
+	return nil
+}
+
+// transferOutCrossStake creates the cross stake package built by encode, which transfers amount of payer less the
+// relay fee to BSC, and locks the tokens in the peg account. Before the IBCRelayerFeeSchedule upgrade the relay fee is
+// given by the fee calculator feeName. Since the upgrade it is quoted from the relayer fee of the cross stake channel
+// for the package of the whole amount, whose payload is not shorter than the one of the package, and the ibc keeper
+// debits the fee of the package from payer, payer keeps the difference. It returns the send sequence, the relay fee
+// and the amount sent to the peg account.
+func (k Keeper) transferOutCrossStake(ctx sdk.Context, payer sdk.AccAddress, amount int64, feeName string,
+	encode func(bscAmount *big.Int) ([]byte, error)) (uint64, int64, int64, sdk.Error) {
+	denom := k.BondDenom(ctx)
+	ibcCtx := ctx.DepriveSideChainKeyPrefix()
+	feeSchedule := sdk.IsUpgrade(sdk.IBCRelayerFeeSchedule)
+
+	var relayFee int64
+	if feeSchedule {
+		payload, err := encode(bsc.ConvertBCAmountToBSCAmount(amount))
+		if err != nil {
+			return 0, 0, 0, sdk.ErrInternal(err.Error())
+		}
+		fee, sdkErr := k.ibcKeeper.GetRelayerFeeById(ibcCtx, k.DestChainId, types.CrossStakeChannelID, len(payload))
+		if sdkErr != nil {
+			return 0, 0, 0, sdkErr
+		}
+		relayFee = fee
+	} else {
+		relayFeeCalc := fees.GetCalculator(feeName)
+		if relayFeeCalc == nil {
+			return 0, 0, 0, sdk.ErrInternal(fmt.Sprintf("no fee calculator of %s", feeName))
+		}
+		relayFee = relayFeeCalc(nil).Tokens.AmountOf(denom)
+	}
+	if relayFee >= amount {
+		return 0, 0, 0, sdk.ErrInternal("not enough funds to cover relay fee")
+	}
+
+	payload, err := encode(bsc.ConvertBCAmountToBSCAmount(amount - relayFee))
+	if err != nil {
+		return 0, 0, 0, sdk.ErrInternal(err.Error())
+	}
+	sendSeq, paidFee, sdkErr := k.ibcKeeper.CreatePaidIBCPackageById(ibcCtx, k.DestChainId, types.CrossStakeChannelID,
+		payer, payload, relayFee)
+	if sdkErr != nil {
+		return 0, 0, 0, sdkErr
+	}
+
+	// the ibc keeper already sent the fee to the peg account since the upgrade
+	lockedAmount := amount
+	if feeSchedule {
+		lockedAmount = amount - relayFee
+	}
+	if _, sdkErr := k.BankKeeper.SendCoins(ctx, payer, sdk.PegAccount, sdk.Coins{sdk.NewCoin(denom, lockedAmount)}); sdkErr != nil {
+		return 0, 0, 0, sdkErr
+	}
+	if feeSchedule {
+		lockedAmount += paidFee
+	}
+	return sendSeq, paidFee, lockedAmount, nil
+}
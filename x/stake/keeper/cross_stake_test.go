@@ -0,0 +1,135 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+func TestRetryCrossStakeRefunds(t *testing.T) {
+	ctx, _, k := CreateTestInput(t, false, 1000)
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.CrossStakeRecord, 199)
+	sdk.UpgradeMgr.SetHeight(200)
+	bondDenom := k.BondDenom(ctx)
+
+	bscAddr, err := sdk.NewSmartChainAddress("0x0000000000000000000000000000000000001000")
+	require.Nil(t, err)
+	request := types.CrossStakeRecord{
+		BscAddress: bscAddr,
+		Sequence:   1,
+		EventType:  types.CrossStakeTypeDelegate,
+		Amount:     10e8,
+	}
+	refund := types.CrossStakeRecord{
+		BscAddress:   bscAddr,
+		Sequence:     2,
+		EventType:    types.CrossStakeTypeDistributeReward,
+		Status:       types.CrossStakeRecordCrashed,
+		Amount:       5e8,
+		RefundAddr:   Addrs[0],
+		RefundStatus: types.CrossStakeRefundPending,
+	}
+	k.SetCrossStakeRecord(ctx, refund)
+	k.SetCrossStakeRecord(ctx, request)
+	require.Equal(t, []types.CrossStakeRecord{request, refund}, k.GetCrossStakeRecords(ctx, bscAddr))
+	require.Equal(t, []types.CrossStakeRecord{refund}, k.GetPendingCrossStakeRefunds(ctx))
+
+	// the refund stays pending while the peg account can not pay it
+	events := k.RetryCrossStakeRefunds(ctx)
+	require.Len(t, events, 1)
+	refund, _ = k.GetCrossStakeRecord(ctx, bscAddr, 2)
+	require.Equal(t, types.CrossStakeRefundPending, refund.RefundStatus)
+	require.EqualValues(t, 1, refund.RefundRetries)
+
+	balance := k.BankKeeper.GetCoins(ctx, Addrs[0]).AmountOf(bondDenom)
+	_, _, err = k.BankKeeper.AddCoins(ctx, sdk.PegAccount, sdk.Coins{sdk.NewCoin(bondDenom, 5e8)})
+	require.Nil(t, err)
+	events = k.RetryCrossStakeRefunds(ctx)
+	require.Len(t, events, 1)
+	refund, _ = k.GetCrossStakeRecord(ctx, bscAddr, 2)
+	require.Equal(t, types.CrossStakeRefunded, refund.RefundStatus)
+	require.EqualValues(t, 2, refund.RefundRetries)
+	require.Equal(t, balance+5e8, k.BankKeeper.GetCoins(ctx, Addrs[0]).AmountOf(bondDenom))
+	require.Len(t, k.GetPendingCrossStakeRefunds(ctx), 0)
+
+	// the refund is abandoned after the most retries
+	refund.Sequence = 3
+	refund.RefundStatus = types.CrossStakeRefundPending
+	refund.RefundRetries = 0
+	k.SetCrossStakeRecord(ctx, refund)
+	for i := int64(1); i <= types.MaxCrossStakeRefundRetries; i++ {
+		require.Len(t, k.RetryCrossStakeRefunds(ctx), 1)
+	}
+	refund, _ = k.GetCrossStakeRecord(ctx, bscAddr, 3)
+	require.Equal(t, types.CrossStakeRefundAbandoned, refund.RefundStatus)
+	require.Equal(t, types.MaxCrossStakeRefundRetries, refund.RefundRetries)
+	require.Len(t, k.GetPendingCrossStakeRefunds(ctx), 0)
+	require.Len(t, k.RetryCrossStakeRefunds(ctx), 0)
+
+	// an abandoned refund is only paid by an explicit retry
+	_, sdkErr := k.RetryAbandonedCrossStakeRefund(ctx, bscAddr, 4)
+	require.Equal(t, types.ErrNoCrossStakeRecord(k.Codespace()).Code(), sdkErr.Code())
+	_, sdkErr = k.RetryAbandonedCrossStakeRefund(ctx, bscAddr, 2)
+	require.Equal(t, types.ErrRefundNotAbandoned(k.Codespace()).Code(), sdkErr.Code())
+	_, sdkErr = k.RetryAbandonedCrossStakeRefund(ctx, bscAddr, 3)
+	require.NotNil(t, sdkErr)
+	refund, _ = k.GetCrossStakeRecord(ctx, bscAddr, 3)
+	require.Equal(t, types.CrossStakeRefundAbandoned, refund.RefundStatus)
+
+	balance = k.BankKeeper.GetCoins(ctx, Addrs[0]).AmountOf(bondDenom)
+	_, _, err = k.BankKeeper.AddCoins(ctx, sdk.PegAccount, sdk.Coins{sdk.NewCoin(bondDenom, 5e8)})
+	require.Nil(t, err)
+	refund, sdkErr = k.RetryAbandonedCrossStakeRefund(ctx, bscAddr, 3)
+	require.Nil(t, sdkErr)
+	require.Equal(t, types.CrossStakeRefunded, refund.RefundStatus)
+	require.Equal(t, balance+5e8, k.BankKeeper.GetCoins(ctx, Addrs[0]).AmountOf(bondDenom))
+	stored, _ := k.GetCrossStakeRecord(ctx, bscAddr, 3)
+	require.Equal(t, refund, stored)
+}
+
+func TestCrossDistributeRewardRelayFee(t *testing.T) {
+	ctx, _, k := CreateTestInput(t, false, 1000)
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.IBCRelayerFeeSchedule, 99)
+	// the relayer fees are paid in the native token
+	params := k.GetParams(ctx)
+	params.BondDenom = sdk.NativeTokenSymbol
+	k.SetParams(ctx, params)
+	bondDenom := k.BondDenom(ctx)
+
+	k.DestChainId = sdk.ChainID(0x000f)
+	k.DestChainName = "bsc"
+	k.ScKeeper.SetSrcChainID(sdk.ChainID(0x0001))
+	require.Nil(t, k.ScKeeper.RegisterDestChain(k.DestChainName, k.DestChainId))
+	require.Nil(t, k.ScKeeper.RegisterChannel(types.CrossStakeChannel, types.CrossStakeChannelID, nil))
+	k.ScKeeper.SetChannelSendPermission(ctx, k.DestChainId, types.CrossStakeChannelID, sdk.ChannelAllow)
+	storePrefix := []byte{0x99}
+	k.ScKeeper.SetSideChainIdAndStorePrefix(ctx, k.DestChainName, storePrefix)
+	k.ibcKeeper.SetParams(ctx.WithSideChainKeyPrefix(storePrefix), ibc.Params{
+		RelayerFee:         ibc.DefaultRelayerFeeParam,
+		ChannelRelayerFees: []ibc.ChannelRelayerFee{{ChannelId: types.CrossStakeChannelID, Fee: 2e6, FeePerByte: 10}},
+	})
+	k.ibcKeeper.SetBankKeeper(k.BankKeeper)
+
+	// the relayer fee of the cross stake channel is debited from the reward account
+	payer := Addrs[0]
+	_, _, sdkErr := k.BankKeeper.AddCoins(ctx, payer, sdk.Coins{sdk.NewCoin(bondDenom, 100e8)})
+	require.Nil(t, sdkErr)
+	balance := k.BankKeeper.GetCoins(ctx, payer).AmountOf(bondDenom)
+	_, err := crossDistributeReward(k, ctx, payer, 10e8)
+	require.Nil(t, err)
+	packageFee, found := k.ibcKeeper.GetPackageFee(ctx, k.DestChainId, types.CrossStakeChannelID, 0)
+	require.True(t, found)
+	require.Equal(t, payer, packageFee.Payer)
+	require.True(t, packageFee.Fee > 2e6)
+	paid := balance - k.BankKeeper.GetCoins(ctx, payer).AmountOf(bondDenom)
+	require.True(t, paid > 10e8-packageFee.Fee-10 && paid <= 10e8)
+	require.Equal(t, paid, k.BankKeeper.GetCoins(ctx, sdk.PegAccount).AmountOf(bondDenom))
+
+	// the reward can not be less than the relayer fee
+	_, err = crossDistributeReward(k, ctx, payer, 2e6)
+	require.NotNil(t, err)
+}
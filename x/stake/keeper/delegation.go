@@ -1,17 +1,16 @@
 package keeper
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
 	"math/big"
 	"strconv"
 	"time"
 
-	"github.com/cosmos/cosmos-sdk/bsc"
 	"github.com/cosmos/cosmos-sdk/bsc/rlp"
 	"github.com/cosmos/cosmos-sdk/pubsub"
 	sdk "github.com/cosmos/cosmos-sdk/types"
-	"github.com/cosmos/cosmos-sdk/types/fees"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 	abci "github.com/tendermint/tendermint/abci/types"
 )
@@ -640,6 +639,12 @@ func (k Keeper) getBeginInfo(ctx sdk.Context, valSrcAddr sdk.ValAddress) (
 // begin unbonding an unbonding record
 func (k Keeper) BeginUnbonding(ctx sdk.Context,
 	delAddr sdk.AccAddress, valAddr sdk.ValAddress, sharesAmount sdk.Dec) (types.UnbondingDelegation, sdk.Error) {
+	return k.beginUnbonding(ctx, delAddr, delAddr, valAddr, sharesAmount)
+}
+
+// begin unbonding the shares of the delegation of srcAddr into an unbonding record of delAddr
+func (k Keeper) beginUnbonding(ctx sdk.Context, srcAddr sdk.AccAddress,
+	delAddr sdk.AccAddress, valAddr sdk.ValAddress, sharesAmount sdk.Dec) (types.UnbondingDelegation, sdk.Error) {
 
 	// TODO quick fix, instead we should use an index, see https://github.com/cosmos/cosmos-sdk/issues/1402
 	_, found := k.GetUnbondingDelegation(ctx, delAddr, valAddr)
@@ -648,7 +653,7 @@ func (k Keeper) BeginUnbonding(ctx sdk.Context,
 	}
 
 	// TODO need to handle it if the DelegatorShareExRate is not 1
-	returnAmount, err := k.unbond(ctx, delAddr, valAddr, sharesAmount)
+	returnAmount, err := k.unbond(ctx, srcAddr, valAddr, sharesAmount)
 	if err != nil {
 		return types.UnbondingDelegation{}, err
 	}
@@ -820,16 +825,6 @@ func (k Keeper) ValidateUnbondAmount(
 func (k Keeper) crossDistributeUndelegated(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) (sdk.Events, sdk.Error) {
 	denom := k.BondDenom(ctx)
 	amount := k.BankKeeper.GetCoins(ctx, delAddr).AmountOf(denom)
-	relayFeeCalc := fees.GetCalculator(types.CrossDistributeUndelegatedRelayFee)
-	if relayFeeCalc == nil {
-		return sdk.Events{}, sdk.ErrInternal("no fee calculator of distributeUndelegated")
-	}
-	relayFee := relayFeeCalc(nil)
-	if relayFee.Tokens.AmountOf(denom) >= amount {
-		return sdk.Events{}, sdk.ErrInternal("not enough funds to cover relay fee")
-	}
-	bscRelayFee := bsc.ConvertBCAmountToBSCAmount(relayFee.Tokens.AmountOf(denom))
-	bscTransferAmount := new(big.Int).Sub(bsc.ConvertBCAmountToBSCAmount(amount), bscRelayFee)
 
 	delBscAddrAcc := types.GetStakeCAoB(delAddr.Bytes(), types.DelegateCAoBSalt)
 	delBscAddr := hex.EncodeToString(delBscAddrAcc.Bytes())
@@ -838,36 +833,28 @@ func (k Keeper) crossDistributeUndelegated(ctx sdk.Context, delAddr sdk.AccAddre
 		return sdk.Events{}, sdk.ErrInternal(err.Error())
 	}
 
-	transferPackage := types.CrossStakeDistributeUndelegatedSynPackage{
-		EventType: types.CrossStakeTypeDistributeUndelegated,
-		Amount:    bscTransferAmount,
-		Recipient: recipient,
-		Validator: valAddr,
-	}
-	encodedPackage, err := rlp.EncodeToBytes(transferPackage)
-	if err != nil {
-		return sdk.Events{}, sdk.ErrInternal(err.Error())
-	}
-
-	sendSeq, sdkErr := k.ibcKeeper.CreateRawIBCPackageByIdWithFee(ctx.DepriveSideChainKeyPrefix(), k.DestChainId, types.CrossStakeChannelID,
-		sdk.SynCrossChainPackageType, encodedPackage, *bscRelayFee)
+	sendSeq, relayFee, pegAmount, sdkErr := k.transferOutCrossStake(ctx, delAddr, amount, types.CrossDistributeUndelegatedRelayFee,
+		func(bscAmount *big.Int) ([]byte, error) {
+			return rlp.EncodeToBytes(types.CrossStakeDistributeUndelegatedSynPackage{
+				EventType: types.CrossStakeTypeDistributeUndelegated,
+				Amount:    bscAmount,
+				Recipient: recipient,
+				Validator: valAddr,
+			})
+		})
 	if sdkErr != nil {
 		return sdk.Events{}, sdkErr
 	}
 
-	if _, sdkErr := k.BankKeeper.SendCoins(ctx, delAddr, sdk.PegAccount, sdk.Coins{sdk.NewCoin(denom, amount)}); sdkErr != nil {
-		return sdk.Events{}, sdkErr
-	}
-
 	// publish data if needed
 	if ctx.IsDeliverTx() && k.PbsbServer != nil {
 		event := pubsub.CrossTransferEvent{
 			ChainId:    k.DestChainName,
-			RelayerFee: relayFee.Tokens.AmountOf(denom),
+			RelayerFee: relayFee,
 			Type:       types.TransferOutType,
 			From:       delAddr.String(),
 			Denom:      denom,
-			To:         []pubsub.CrossReceiver{{sdk.PegAccount.String(), amount}},
+			To:         []pubsub.CrossReceiver{{sdk.PegAccount.String(), pegAmount}},
 		}
 		k.PbsbServer.Publish(event)
 	}
@@ -877,7 +864,7 @@ func (k Keeper) crossDistributeUndelegated(ctx sdk.Context, delAddr sdk.AccAddre
 		types.TagCrossStakeChannel, []byte{uint8(types.CrossStakeChannelID)},
 		types.TagCrossStakeSendSequence, []byte(strconv.FormatUint(sendSeq, 10)),
 	)
-	resultTags = append(resultTags, sdk.GetPegInTag(denom, amount))
+	resultTags = append(resultTags, sdk.GetPegInTag(denom, pegAmount))
 
 	events := sdk.Events{sdk.Event{
 		Type:       types.EventTypeCrossStake,
@@ -886,6 +873,18 @@ func (k Keeper) crossDistributeUndelegated(ctx sdk.Context, delAddr sdk.AccAddre
 	return events, nil
 }
 
+// CheckOperatorAsDelegator allows the self-delegator delegating/redelegating to its validator,
+// but the operator is not allowed if it is not a self-delegator.
+func (k Keeper) CheckOperatorAsDelegator(delegator sdk.AccAddress, validator types.Validator) sdk.Error {
+	delegatorIsOperator := bytes.Equal(delegator.Bytes(), validator.OperatorAddr.Bytes())
+	operatorIsSelfDelegator := validator.IsSelfDelegator(sdk.AccAddress(validator.OperatorAddr))
+
+	if delegatorIsOperator && !operatorIsSelfDelegator {
+		return types.ErrInvalidDelegator(k.Codespace())
+	}
+	return nil
+}
+
 func (k Keeper) IsSelfDelegator(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) (bool, sdk.Error) {
 	// get validator
 	validator, found := k.GetValidator(ctx, valAddr)
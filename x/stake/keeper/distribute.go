@@ -6,11 +6,9 @@ import (
 	"math/big"
 	"strconv"
 
-	"github.com/cosmos/cosmos-sdk/bsc"
 	"github.com/cosmos/cosmos-sdk/bsc/rlp"
 	"github.com/cosmos/cosmos-sdk/pubsub"
 	sdk "github.com/cosmos/cosmos-sdk/types"
-	"github.com/cosmos/cosmos-sdk/types/fees"
 	"github.com/cosmos/cosmos-sdk/x/stake/types"
 )
 
@@ -329,6 +327,7 @@ func (k Keeper) distributeSingleBatch(ctx sdk.Context, sideChainId string) sdk.E
 	var toPublishRewards []types.Reward            // rewards to be published in blocks
 
 	var changedAddrs []sdk.AccAddress //changed addresses
+	var restakeSum int64
 
 	bondDenom := k.BondDenom(ctx)
 	var events sdk.Events
@@ -349,6 +348,10 @@ func (k Keeper) distributeSingleBatch(ctx sdk.Context, sideChainId string) sdk.E
 		if _, _, err := k.BankKeeper.AddCoins(ctx, reward.AccAddr, sdk.Coins{sdk.NewCoin(bondDenom, reward.Amount)}); err != nil {
 			panic(err)
 		}
+		if k.restakeReward(ctx, reward) {
+			reward.Restaked = true
+			restakeSum += reward.Amount
+		}
 
 		toPublishRewards = append(toPublishRewards, reward)
 		changedAddrs = append(changedAddrs, reward.AccAddr)
@@ -378,6 +381,13 @@ func (k Keeper) distributeSingleBatch(ctx sdk.Context, sideChainId string) sdk.E
 		}
 	}
 
+	if restakeSum > 0 {
+		events = events.AppendEvent(sdk.Event{
+			Type:       types.EventTypeTotalRestake,
+			Attributes: sdk.NewTags(types.AttributeKeyRestakeSum, []byte(strconv.FormatInt(restakeSum, 10))),
+		})
+	}
+
 	// delete the batch in store
 	k.removeBatchRewards(ctx, key)
 
@@ -391,6 +401,8 @@ func (k Keeper) distributeSingleBatch(ctx sdk.Context, sideChainId string) sdk.E
 		k.AddrPool.AddAddrs(changedAddrs[:])
 	}
 
+	k.recordRewards(ctx, sideChainId, toPublishRewards)
+
 	// publish data if needed
 	if ctx.IsDeliverTx() && len(toPublishRewards) > 0 && k.PbsbServer != nil {
 		toPublish = append(toPublish, types.DistributionData{
@@ -438,17 +450,6 @@ func removeValidatorsAndDelegationsAtHeight(height int64, k Keeper, ctx sdk.Cont
 
 func crossDistributeReward(k Keeper, ctx sdk.Context, rewardCAoB sdk.AccAddress, amount int64) (sdk.Events, error) {
 	denom := k.BondDenom(ctx)
-	relayFeeCalc := fees.GetCalculator(types.CrossDistributeRewardRelayFee)
-	if relayFeeCalc == nil {
-		return sdk.Events{}, fmt.Errorf("no fee calculator of transferOutRewards")
-	}
-	relayFee := relayFeeCalc(nil)
-	if relayFee.Tokens.AmountOf(denom) >= amount {
-		return sdk.Events{}, sdk.ErrInternal("not enough funds to cover relay fee")
-	}
-	bscRelayFee := bsc.ConvertBCAmountToBSCAmount(relayFee.Tokens.AmountOf(denom))
-
-	bscTransferAmount := new(big.Int).Sub(bsc.ConvertBCAmountToBSCAmount(amount), bscRelayFee)
 	delAddr := types.GetStakeCAoB(rewardCAoB.Bytes(), types.RewardCAoBSalt)
 	delBscAddrAcc := types.GetStakeCAoB(delAddr.Bytes(), types.DelegateCAoBSalt)
 	delBscAddr := hex.EncodeToString(delBscAddrAcc.Bytes())
@@ -457,35 +458,27 @@ func crossDistributeReward(k Keeper, ctx sdk.Context, rewardCAoB sdk.AccAddress,
 		return sdk.Events{}, err
 	}
 
-	transferPackage := types.CrossStakeDistributeRewardSynPackage{
-		EventType: types.CrossStakeTypeDistributeReward,
-		Amount:    bscTransferAmount,
-		Recipient: recipient,
-	}
-	encodedPackage, err := rlp.EncodeToBytes(transferPackage)
-	if err != nil {
-		return sdk.Events{}, err
-	}
-
-	sendSeq, sdkErr := k.ibcKeeper.CreateRawIBCPackageByIdWithFee(ctx.DepriveSideChainKeyPrefix(), k.DestChainId, types.CrossStakeChannelID, sdk.SynCrossChainPackageType,
-		encodedPackage, *bscRelayFee)
+	sendSeq, relayFee, pegAmount, sdkErr := k.transferOutCrossStake(ctx, rewardCAoB, amount, types.CrossDistributeRewardRelayFee,
+		func(bscAmount *big.Int) ([]byte, error) {
+			return rlp.EncodeToBytes(types.CrossStakeDistributeRewardSynPackage{
+				EventType: types.CrossStakeTypeDistributeReward,
+				Amount:    bscAmount,
+				Recipient: recipient,
+			})
+		})
 	if sdkErr != nil {
 		return sdk.Events{}, sdkErr
 	}
 
-	if _, sdkErr := k.BankKeeper.SendCoins(ctx, rewardCAoB, sdk.PegAccount, sdk.Coins{sdk.NewCoin(denom, amount)}); sdkErr != nil {
-		return sdk.Events{}, sdkErr
-	}
-
 	// publish data if needed
 	if ctx.IsDeliverTx() && k.PbsbServer != nil {
 		event := pubsub.CrossTransferEvent{
 			ChainId:    k.DestChainName,
-			RelayerFee: relayFee.Tokens.AmountOf(denom),
+			RelayerFee: relayFee,
 			Type:       types.TransferOutType,
 			From:       rewardCAoB.String(),
 			Denom:      denom,
-			To:         []pubsub.CrossReceiver{{sdk.PegAccount.String(), amount}},
+			To:         []pubsub.CrossReceiver{{sdk.PegAccount.String(), pegAmount}},
 		}
 		k.PbsbServer.Publish(event)
 	}
@@ -495,7 +488,7 @@ func crossDistributeReward(k Keeper, ctx sdk.Context, rewardCAoB sdk.AccAddress,
 		types.TagCrossStakeChannel, []byte{uint8(types.CrossStakeChannelID)},
 		types.TagCrossStakeSendSequence, []byte(strconv.FormatUint(sendSeq, 10)),
 	)
-	resultTags = append(resultTags, sdk.GetPegInTag(denom, amount))
+	resultTags = append(resultTags, sdk.GetPegInTag(denom, pegAmount))
 
 	events := sdk.Events{sdk.Event{
 		Type:       types.EventTypeCrossStake,
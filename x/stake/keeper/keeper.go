@@ -40,6 +40,9 @@ type Keeper struct {
 	DestChainName string
 
 	PbsbServer *pubsub.Server
+
+	// optional, the node keeps the reward history if it is set
+	rewardHistory *RewardHistory
 }
 
 func NewKeeper(cdc *codec.Codec, key, rewardKey, tkey sdk.StoreKey, ck bank.Keeper, addrPool *sdk.Pool,
@@ -47,6 +47,14 @@ var (
 
 	SideChainStorePrefixByIdKey = []byte{0x51} // prefix for each key to a side chain store prefix, by side chain id
 
+	RewardRestakeKey           = []byte{0x61} // prefix for each key to the reward restake setting, by delegator
+	PendingCommissionKey       = []byte{0x62} // prefix for each key to a pending commission raise, by validator operator
+	LiquidStakeReceiptKey      = []byte{0x63} // prefix for each key to a liquid stake receipt, by denom
+	LiquidStakeReceiptByValKey = []byte{0x64} // prefix for each key to a liquid stake receipt denom, by side chain id and validator operator
+	LiquidStakeReceiptSeqKey   = []byte{0x65} // key for the sequence of the liquid stake receipts
+	CrossStakeRecordKey        = []byte{0x66} // prefix for each key to a cross stake record, by bsc address and sequence
+	CrossStakePendingRefundKey = []byte{0x67} // prefix for each key to a cross stake record whose refund is pending, by bsc address and sequence
+
 	// Keys for reward store prefix
 	RewardBatchKey       = []byte{0x01} // key for batch of rewards
 	RewardValDistAddrKey = []byte{0x02} // key for rewards' validator <-> distribution address mapping
@@ -340,3 +348,57 @@ func GetREDsByDelToValDstIndexKey(delAddr sdk.AccAddress, valDstAddr sdk.ValAddr
 func GetValLatestUpdateConsAddrTimeKey(valAddr sdk.ValAddress) []byte {
 	return append(ValLatestUpdateConsAddrTimeKey, valAddr.Bytes()...)
 }
+
+// gets the key for the reward restake setting of a delegator
+// VALUE: none, the key exists for the delegators opted in
+func GetRewardRestakeKey(delAddr sdk.AccAddress) []byte {
+	return append(RewardRestakeKey, delAddr.Bytes()...)
+}
+
+// gets the key for the pending commission raise of a validator
+// VALUE: stake/types.PendingCommission
+func GetPendingCommissionKey(operatorAddr sdk.ValAddress) []byte {
+	return append(PendingCommissionKey, operatorAddr.Bytes()...)
+}
+
+// gets the key for the liquid stake receipt of a denom
+// VALUE: stake/types.LiquidStakeReceipt
+func GetLiquidStakeReceiptKey(denom string) []byte {
+	return append(LiquidStakeReceiptKey, []byte(denom)...)
+}
+
+// gets the key for the liquid stake receipt denom of a validator of a side chain
+// VALUE: the receipt denom
+func GetLiquidStakeReceiptByValKey(sideChainId string, operatorAddr sdk.ValAddress) []byte {
+	key := append(append([]byte{}, LiquidStakeReceiptByValKey...), byte(len(sideChainId)))
+	key = append(key, []byte(sideChainId)...)
+	return append(key, operatorAddr.Bytes()...)
+}
+
+// gets the key for the cross stake record of a package from or to a bsc address
+// VALUE: stake/types.CrossStakeRecord
+func GetCrossStakeRecordKey(bscAddr sdk.SmartChainAddress, sequence uint64) []byte {
+	return append(GetCrossStakeRecordsKey(bscAddr), getSequenceBytes(sequence)...)
+}
+
+// gets the prefix for the cross stake records of a bsc address
+func GetCrossStakeRecordsKey(bscAddr sdk.SmartChainAddress) []byte {
+	return append(append([]byte{}, CrossStakeRecordKey...), bscAddr[:]...)
+}
+
+// gets the key for the pending refund of a cross stake record
+// VALUE: none
+func GetCrossStakePendingRefundKey(bscAddr sdk.SmartChainAddress, sequence uint64) []byte {
+	key := append(append([]byte{}, CrossStakePendingRefundKey...), bscAddr[:]...)
+	return append(key, getSequenceBytes(sequence)...)
+}
+
+func getSequenceBytes(sequence uint64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, sequence)
+	return bz
+}
+
+func getSequenceFromBytes(bz []byte) uint64 {
+	return binary.BigEndian.Uint64(bz)
+}
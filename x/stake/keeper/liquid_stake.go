@@ -0,0 +1,183 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// the most receipts whose denom suffix fits in six hex digits
+const maxLiquidStakeReceiptSeq = 0xFFFFFF
+
+// The liquid stake receipts of all side chains are kept out of the side chain stores, so that a receipt denom is
+// unique over the chains.
+
+// GetLiquidStakeReceipt returns the liquid stake receipt of a denom.
+func (k Keeper) GetLiquidStakeReceipt(ctx sdk.Context, denom string) (receipt types.LiquidStakeReceipt, found bool) {
+	bz := ctx.DepriveSideChainKeyPrefix().KVStore(k.storeKey).Get(GetLiquidStakeReceiptKey(denom))
+	if bz == nil {
+		return receipt, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &receipt)
+	return receipt, true
+}
+
+// GetLiquidStakeReceiptByValidator returns the liquid stake receipt of a validator of a side chain.
+func (k Keeper) GetLiquidStakeReceiptByValidator(ctx sdk.Context, sideChainId string, operatorAddr sdk.ValAddress) (receipt types.LiquidStakeReceipt, found bool) {
+	denom := ctx.DepriveSideChainKeyPrefix().KVStore(k.storeKey).Get(GetLiquidStakeReceiptByValKey(sideChainId, operatorAddr))
+	if denom == nil {
+		return receipt, false
+	}
+	return k.GetLiquidStakeReceipt(ctx, string(denom))
+}
+
+func (k Keeper) SetLiquidStakeReceipt(ctx sdk.Context, receipt types.LiquidStakeReceipt) {
+	store := ctx.DepriveSideChainKeyPrefix().KVStore(k.storeKey)
+	store.Set(GetLiquidStakeReceiptKey(receipt.Denom), k.cdc.MustMarshalBinaryLengthPrefixed(receipt))
+	store.Set(GetLiquidStakeReceiptByValKey(receipt.ChainId, receipt.ValidatorAddr), []byte(receipt.Denom))
+}
+
+// GetAllLiquidStakeReceipts returns the liquid stake receipts of the validators of all side chains.
+func (k Keeper) GetAllLiquidStakeReceipts(ctx sdk.Context) []types.LiquidStakeReceipt {
+	iterator := sdk.KVStorePrefixIterator(ctx.DepriveSideChainKeyPrefix().KVStore(k.storeKey), LiquidStakeReceiptKey)
+	defer iterator.Close()
+
+	receipts := make([]types.LiquidStakeReceipt, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		var receipt types.LiquidStakeReceipt
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &receipt)
+		receipts = append(receipts, receipt)
+	}
+	return receipts
+}
+
+func (k Keeper) newLiquidStakeReceipt(ctx sdk.Context, sideChainId string, operatorAddr sdk.ValAddress) (types.LiquidStakeReceipt, sdk.Error) {
+	store := ctx.DepriveSideChainKeyPrefix().KVStore(k.storeKey)
+	var sequence int64
+	if bz := store.Get(LiquidStakeReceiptSeqKey); bz != nil {
+		sequence = int64(binary.BigEndian.Uint64(bz))
+	}
+	sequence++
+	if sequence > maxLiquidStakeReceiptSeq {
+		return types.LiquidStakeReceipt{}, sdk.ErrInternal("liquid stake receipts are used up")
+	}
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(sequence))
+	store.Set(LiquidStakeReceiptSeqKey, bz)
+
+	return types.LiquidStakeReceipt{
+		Denom:         types.GetLiquidStakeDenom(sequence),
+		ChainId:       sideChainId,
+		ValidatorAddr: operatorAddr,
+	}, nil
+}
+
+// GetLiquidStakeShares returns the delegation shares of a validator represented by its receipt tokens.
+func (k Keeper) GetLiquidStakeShares(ctx sdk.Context, operatorAddr sdk.ValAddress) sdk.Dec {
+	delegation, found := k.GetDelegation(ctx, LiquidStakeAccAddr, operatorAddr)
+	if !found {
+		return sdk.ZeroDec()
+	}
+	return delegation.Shares
+}
+
+// LiquidDelegate delegates bondAmt of a delegator to a validator of the side chain of ctx through the liquid stake
+// account, and mints the receipt tokens of the validator to the delegator, in proportion to the new delegation
+// shares. The rewards of the liquid delegations are always delegated back, so they raise the worth of the receipt
+// tokens.
+func (k Keeper) LiquidDelegate(ctx sdk.Context, delAddr sdk.AccAddress, bondAmt sdk.Coin, validator types.Validator) (sdk.Coin, sdk.Error) {
+	sideChainId := ctx.SideChainId()
+	receipt, found := k.GetLiquidStakeReceiptByValidator(ctx, sideChainId, validator.OperatorAddr)
+	if !found {
+		var err sdk.Error
+		if receipt, err = k.newLiquidStakeReceipt(ctx, sideChainId, validator.OperatorAddr); err != nil {
+			return sdk.Coin{}, err
+		}
+	}
+	shares := k.GetLiquidStakeShares(ctx, validator.OperatorAddr)
+
+	if err := k.transferBondTokens(ctx, delAddr, DelegationAccAddr, bondAmt); err != nil {
+		return sdk.Coin{}, err
+	}
+	newShares, err := k.Delegate(ctx, LiquidStakeAccAddr, bondAmt, validator, false)
+	if err != nil {
+		return sdk.Coin{}, err
+	}
+
+	mintAmount := newShares.RawInt()
+	if receipt.Supply > 0 && shares.RawInt() > 0 {
+		minted, e := sdk.MulQuoDec(newShares, sdk.NewDec(receipt.Supply), shares)
+		if e != nil {
+			return sdk.Coin{}, types.ErrBadLiquidStakeAmount(k.Codespace(), e.Error())
+		}
+		mintAmount = minted.RawInt()
+	}
+	if mintAmount <= 0 {
+		return sdk.Coin{}, types.ErrBadLiquidStakeAmount(k.Codespace(), "delegation is too small to mint receipt tokens")
+	}
+
+	receiptCoin := sdk.NewCoin(receipt.Denom, mintAmount)
+	if _, _, err := k.BankKeeper.AddCoins(ctx, delAddr, sdk.Coins{receiptCoin}); err != nil {
+		return sdk.Coin{}, err
+	}
+	receipt.Supply += mintAmount
+	k.SetLiquidStakeReceipt(ctx, receipt)
+
+	if ctx.IsDeliverTx() && k.AddrPool != nil {
+		k.AddrPool.AddAddrs([]sdk.AccAddress{delAddr, DelegationAccAddr})
+	}
+	return receiptCoin, nil
+}
+
+// LiquidUndelegate burns the receipt tokens of a delegator, and begins unbonding the delegation shares they represent
+// to the delegator, which completes as a common unbonding delegation.
+func (k Keeper) LiquidUndelegate(ctx sdk.Context, delAddr sdk.AccAddress, operatorAddr sdk.ValAddress, receiptAmt sdk.Coin) (types.UnbondingDelegation, sdk.Error) {
+	receipt, found := k.GetLiquidStakeReceiptByValidator(ctx, ctx.SideChainId(), operatorAddr)
+	if !found {
+		return types.UnbondingDelegation{}, types.ErrNoLiquidStakeReceipt(k.Codespace())
+	}
+	if receiptAmt.Denom != receipt.Denom {
+		return types.UnbondingDelegation{}, types.ErrBadDenom(k.Codespace())
+	}
+	if !k.BankKeeper.HasCoins(ctx, delAddr, sdk.Coins{receiptAmt}) || receiptAmt.Amount > receipt.Supply {
+		return types.UnbondingDelegation{}, sdk.ErrInsufficientCoins(fmt.Sprintf("not enough receipt tokens, amount: %d", receiptAmt.Amount))
+	}
+
+	shares := k.GetLiquidStakeShares(ctx, operatorAddr)
+	if receiptAmt.Amount < receipt.Supply {
+		var e error
+		if shares, e = sdk.MulQuoDec(shares, sdk.NewDec(receiptAmt.Amount), sdk.NewDec(receipt.Supply)); e != nil {
+			return types.UnbondingDelegation{}, types.ErrBadLiquidStakeAmount(k.Codespace(), e.Error())
+		}
+	}
+	if shares.RawInt() <= 0 {
+		return types.UnbondingDelegation{}, types.ErrBadLiquidStakeAmount(k.Codespace(), "receipt tokens are too few to undelegate")
+	}
+
+	ubd, err := k.beginUnbonding(ctx, LiquidStakeAccAddr, delAddr, operatorAddr, shares)
+	if err != nil {
+		return types.UnbondingDelegation{}, err
+	}
+	if _, _, err := k.BankKeeper.SubtractCoins(ctx, delAddr, sdk.Coins{receiptAmt}); err != nil {
+		return types.UnbondingDelegation{}, err
+	}
+	receipt.Supply -= receiptAmt.Amount
+	k.SetLiquidStakeReceipt(ctx, receipt)
+
+	if ctx.IsDeliverTx() && k.AddrPool != nil {
+		k.AddrPool.AddAddrs([]sdk.AccAddress{delAddr})
+	}
+	return ubd, nil
+}
+
+// GetLiquidStakeTokens returns the bonded tokens represented by the receipt tokens of a validator, which follow the
+// exchange rate of the validator shares, so the slashes of the validator pass on to the receipt tokens.
+func (k Keeper) GetLiquidStakeTokens(ctx sdk.Context, operatorAddr sdk.ValAddress) sdk.Dec {
+	validator, found := k.GetValidator(ctx, operatorAddr)
+	if !found {
+		return sdk.ZeroDec()
+	}
+	return validator.TokensFromShares(k.GetLiquidStakeShares(ctx, operatorAddr))
+}
@@ -0,0 +1,100 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+func TestLiquidStake(t *testing.T) {
+	ctx, _, k := CreateTestInput(t, false, 1000)
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.LiquidStaking, 199)
+	sdk.UpgradeMgr.SetHeight(200)
+	bondDenom := k.BondDenom(ctx)
+
+	k.ScKeeper.SetSideChainIdAndStorePrefix(ctx, "bsc", []byte{0x99})
+	sideCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, "bsc")
+	require.Nil(t, err)
+	k.SetParams(sideCtx, k.GetParams(ctx))
+	k.SetPool(sideCtx, types.Pool{LooseTokens: sdk.NewDec(5e15)})
+
+	selfDelegator, alice, bob := Addrs[0], Addrs[1], Addrs[2]
+	validator := types.NewSideChainValidator(selfDelegator, addrVals[0], types.Description{}, "bsc", []byte("cons-addr"), []byte("fee-addr"), nil)
+	k.SetValidator(sideCtx, validator)
+	k.SetValidatorByConsAddr(sideCtx, validator)
+	_, sdkErr := k.Delegate(sideCtx, selfDelegator, sdk.NewCoin(bondDenom, 100e8), validator, true)
+	require.Nil(t, sdkErr)
+
+	balance := func(addr sdk.AccAddress, denom string) int64 {
+		return k.BankKeeper.GetCoins(ctx, addr).AmountOf(denom)
+	}
+	aliceBalance := balance(alice, bondDenom)
+
+	// receipt tokens are minted one for one share at first
+	validator, _ = k.GetValidator(sideCtx, validator.OperatorAddr)
+	receiptCoin, sdkErr := k.LiquidDelegate(sideCtx, alice, sdk.NewCoin(bondDenom, 10e8), validator)
+	require.Nil(t, sdkErr)
+	require.Equal(t, sdk.NewCoin("LST-0001", 10e8), receiptCoin)
+	require.Equal(t, aliceBalance-10e8, balance(alice, bondDenom))
+	require.EqualValues(t, 10e8, balance(alice, receiptCoin.Denom))
+	receipt, found := k.GetLiquidStakeReceiptByValidator(ctx, "bsc", validator.OperatorAddr)
+	require.True(t, found)
+	require.EqualValues(t, 10e8, receipt.Supply)
+	require.Len(t, k.GetAllLiquidStakeReceipts(ctx), 1)
+
+	// the receipt tokens are transferable
+	_, err = k.BankKeeper.SendCoins(ctx, alice, bob, sdk.Coins{sdk.NewCoin(receiptCoin.Denom, 4e8)})
+	require.Nil(t, err)
+
+	// the rewards are delegated back without opting in to reward restake, so they raise the worth of a receipt
+	// token and fewer receipt tokens are minted
+	distAddr := sdk.AccAddress([]byte("liquid-distribute-addr"))
+	_, _, err = k.BankKeeper.AddCoins(ctx, distAddr, sdk.Coins{sdk.NewCoin(bondDenom, 5e8)})
+	require.Nil(t, err)
+	k.setBatchRewards(sideCtx, 0, []types.Reward{{ValAddr: validator.OperatorAddr, AccAddr: LiquidStakeAccAddr, Amount: 5e8}})
+	k.setRewardValDistAddrs(sideCtx, []types.StoredValDistAddr{{Validator: validator.OperatorAddr, DistributeAddr: distAddr}})
+	k.DistributeInBlock(sideCtx, "bsc")
+	require.EqualValues(t, 0, balance(LiquidStakeAccAddr, bondDenom))
+	require.EqualValues(t, 15e8, k.GetLiquidStakeTokens(sideCtx, validator.OperatorAddr).RawInt())
+	validator, _ = k.GetValidator(sideCtx, validator.OperatorAddr)
+	receiptCoin, sdkErr = k.LiquidDelegate(sideCtx, alice, sdk.NewCoin(bondDenom, 3e8), validator)
+	require.Nil(t, sdkErr)
+	require.EqualValues(t, 2e8, receiptCoin.Amount)
+
+	// the slash of the validator passes on to the receipt tokens by the rate of its shares
+	validator, _ = k.GetValidator(sideCtx, validator.OperatorAddr)
+	validator.Status = sdk.Unbonding
+	k.SetValidator(sideCtx, validator)
+	_, slashed, err := k.SlashSideChain(ctx, "bsc", []byte("cons-addr"), sdk.NewDec(20e8))
+	require.Nil(t, err)
+	require.EqualValues(t, 20e8, slashed.RawInt())
+	validator, _ = k.GetValidator(sideCtx, validator.OperatorAddr)
+	require.True(t, validator.Jailed)
+	require.Equal(t, validator.TokensFromShares(k.GetLiquidStakeShares(sideCtx, validator.OperatorAddr)), k.GetLiquidStakeTokens(sideCtx, validator.OperatorAddr))
+	require.EqualValues(t, 18e8, k.GetLiquidStakeTokens(sideCtx, validator.OperatorAddr).RawInt())
+
+	// burning the receipt tokens unbonds the tokens they represent to the holder
+	_, sdkErr = k.LiquidUndelegate(sideCtx, bob, validator.OperatorAddr, sdk.NewCoin(receiptCoin.Denom, 5e8))
+	require.NotNil(t, sdkErr)
+	_, sdkErr = k.LiquidUndelegate(sideCtx, bob, validator.OperatorAddr, sdk.NewCoin(bondDenom, 4e8))
+	require.NotNil(t, sdkErr)
+	ubd, sdkErr := k.LiquidUndelegate(sideCtx, bob, validator.OperatorAddr, sdk.NewCoin(receiptCoin.Denom, 4e8))
+	require.Nil(t, sdkErr)
+	require.Equal(t, bob, ubd.DelegatorAddr)
+	require.EqualValues(t, 6e8, ubd.Balance.Amount)
+	require.EqualValues(t, 0, balance(bob, receiptCoin.Denom))
+	receipt, _ = k.GetLiquidStakeReceipt(ctx, receiptCoin.Denom)
+	require.EqualValues(t, 8e8, receipt.Supply)
+
+	// the last receipt tokens unbond all the liquid delegation
+	ubd, sdkErr = k.LiquidUndelegate(sideCtx, alice, validator.OperatorAddr, sdk.NewCoin(receiptCoin.Denom, 8e8))
+	require.Nil(t, sdkErr)
+	require.EqualValues(t, 12e8, ubd.Balance.Amount)
+	_, found = k.GetDelegation(sideCtx, LiquidStakeAccAddr, validator.OperatorAddr)
+	require.False(t, found)
+	receipt, _ = k.GetLiquidStakeReceipt(ctx, receiptCoin.Denom)
+	require.EqualValues(t, 0, receipt.Supply)
+}
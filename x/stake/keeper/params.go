@@ -19,6 +19,7 @@ var (
 	FeeCollectorAddr       = sdk.AccAddress(crypto.AddressHash([]byte("FeeCollector")))
 	DelegationAccAddr      = sdk.AccAddress(crypto.AddressHash([]byte("BinanceChainStakeDelegation")))
 	FeeForAllBcValsAccAddr = sdk.AccAddress(crypto.AddressHash([]byte("BinanceChainStakeFeeForAllBcVals")))
+	LiquidStakeAccAddr     = sdk.AccAddress(crypto.AddressHash([]byte("BinanceChainLiquidStake")))
 )
 
 // ParamTable for stake module
@@ -79,6 +80,11 @@ func (k Keeper) FeeFromBscToBcRatio(ctx sdk.Context) (res sdk.Dec) {
 	return
 }
 
+func (k Keeper) CommissionNoticeBreatheBlocks(ctx sdk.Context) (res int64) {
+	k.paramstore.GetIfExists(ctx, types.KeyCommissionNoticeBreatheBlocks, &res)
+	return
+}
+
 // Get all parameters as types.Params
 func (k Keeper) GetParams(ctx sdk.Context) (res types.Params) {
 	res.UnbondingTime = k.UnbondingTime(ctx)
@@ -91,6 +97,7 @@ func (k Keeper) GetParams(ctx sdk.Context) (res types.Params) {
 	res.BonusProposerRewardRatio = k.BonusProposerRewardRatio(ctx)
 	res.MaxStakeSnapshots = k.MaxStakeSnapshots(ctx)
 	res.FeeFromBscToBcRatio = k.FeeFromBscToBcRatio(ctx)
+	res.CommissionNoticeBreatheBlocks = k.CommissionNoticeBreatheBlocks(ctx)
 	return
 }
 
@@ -185,4 +192,7 @@ func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
 		k.paramstore.Set(ctx, types.KeyBonusProposerRewardRatio, params.BonusProposerRewardRatio)
 		k.paramstore.Set(ctx, types.KeyFeeFromBscToBcRatio, params.FeeFromBscToBcRatio)
 	}
+	if sdk.IsUpgrade(sdk.CommissionChangeNotice) {
+		k.paramstore.Set(ctx, types.KeyCommissionNoticeBreatheBlocks, params.CommissionNoticeBreatheBlocks)
+	}
 }
@@ -0,0 +1,82 @@
+package keeper
+
+import (
+	"bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+// IsRewardRestake tells if the rewards of a delegator are delegated back to their validators.
+func (k Keeper) IsRewardRestake(ctx sdk.Context, delAddr sdk.AccAddress) bool {
+	return ctx.KVStore(k.storeKey).Has(GetRewardRestakeKey(delAddr))
+}
+
+// SetRewardRestake opts a delegator in or out of delegating its rewards back to their validators.
+func (k Keeper) SetRewardRestake(ctx sdk.Context, delAddr sdk.AccAddress, restake bool) {
+	store := ctx.KVStore(k.storeKey)
+	if restake {
+		store.Set(GetRewardRestakeKey(delAddr), []byte{})
+	} else {
+		store.Delete(GetRewardRestakeKey(delAddr))
+	}
+}
+
+// restakeReward delegates a reward paid to an opted in delegator back to its validator, and tells if it does.
+// The rewards less than MinDelegationChange and the ones which can not be delegated, including the ones which
+// MsgSideChainDelegate would refuse, are left liquid.
+// The rewards of the liquid delegations are always delegated back, see compoundLiquidStakeReward.
+func (k Keeper) restakeReward(ctx sdk.Context, reward types.Reward) bool {
+	if reward.AccAddr.Equals(LiquidStakeAccAddr) {
+		return k.compoundLiquidStakeReward(ctx, reward)
+	}
+	if !sdk.IsUpgrade(sdk.RewardRestake) || reward.CrossStake || !k.IsRewardRestake(ctx, reward.AccAddr) {
+		return false
+	}
+	if reward.Amount < k.MinDelegationChange(ctx) {
+		return false
+	}
+	validator, found := k.GetValidator(ctx, reward.ValAddr)
+	if !found || (validator.Jailed && !bytes.Equal(validator.FeeAddr, reward.AccAddr)) {
+		return false
+	}
+	if err := k.CheckOperatorAsDelegator(reward.AccAddr, validator); err != nil {
+		ctx.Logger().Info("failed to restake reward", "delegator", reward.AccAddr, "validator", reward.ValAddr, "err", err.Error())
+		return false
+	}
+
+	cacheCtx, write := ctx.CacheContext()
+	if _, err := k.Delegate(cacheCtx, reward.AccAddr, sdk.NewCoin(k.BondDenom(ctx), reward.Amount), validator, true); err != nil {
+		ctx.Logger().Info("failed to restake reward", "delegator", reward.AccAddr, "validator", reward.ValAddr, "err", err.Error())
+		return false
+	}
+	write()
+	return true
+}
+
+// compoundLiquidStakeReward delegates a reward of the liquid delegations back to the validator whatever its amount
+// and the validator status, so that it raises the worth of the receipt tokens, as the liquid stake account has no
+// owner to spend it otherwise. A reward which can not be delegated, like one to a validator whose tokens are all
+// slashed, is left liquid in the liquid stake account as the dust of restaked rewards is.
+func (k Keeper) compoundLiquidStakeReward(ctx sdk.Context, reward types.Reward) bool {
+	if reward.Amount <= 0 {
+		return false
+	}
+	validator, found := k.GetValidator(ctx, reward.ValAddr)
+	if !found {
+		ctx.Logger().Error("failed to find validator of liquid stake reward", "validator", reward.ValAddr)
+		return false
+	}
+	// the shares of a validator whose tokens are all slashed have no exchange rate
+	if validator.Tokens.IsZero() && !validator.DelegatorShares.IsZero() {
+		ctx.Logger().Error("failed to compound liquid stake reward", "validator", reward.ValAddr, "err", "no tokens left")
+		return false
+	}
+	cacheCtx, write := ctx.CacheContext()
+	if _, err := k.Delegate(cacheCtx, LiquidStakeAccAddr, sdk.NewCoin(k.BondDenom(ctx), reward.Amount), validator, true); err != nil {
+		ctx.Logger().Error("failed to compound liquid stake reward", "validator", reward.ValAddr, "err", err.Error())
+		return false
+	}
+	write()
+	return true
+}
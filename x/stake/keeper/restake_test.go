@@ -0,0 +1,128 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+func TestDistributeRestake(t *testing.T) {
+	ctx, am, k := CreateTestInput(t, false, 1000)
+	bondDenom := k.BondDenom(ctx)
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.RewardRestake, 199)
+	sdk.UpgradeMgr.SetHeight(200)
+	params := k.GetParams(ctx)
+	params.MinDelegationChange = 1e8
+	k.SetParams(ctx, params)
+
+	pool := k.GetPool(ctx)
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	validator, pool, _ = validator.AddTokensFromDel(pool, 100e8)
+	k.SetPool(ctx, pool)
+	validator = TestingUpdateValidator(k, ctx, validator)
+
+	distAddr := sdk.AccAddress([]byte("restake-distribute-addr"))
+	distAcc := am.NewAccountWithAddress(ctx, distAddr)
+	require.NoError(t, distAcc.SetCoins(sdk.Coins{sdk.NewCoin(bondDenom, 10e8)}))
+	am.SetAccount(ctx, distAcc)
+
+	restaker, duster, payee := Addrs[1], Addrs[2], Addrs[3]
+	k.SetRewardRestake(ctx, restaker, true)
+	k.SetRewardRestake(ctx, duster, true)
+	k.SetRewardRestake(ctx, payee, true)
+	k.SetRewardRestake(ctx, payee, false)
+	require.True(t, k.IsRewardRestake(ctx, restaker))
+	require.False(t, k.IsRewardRestake(ctx, payee))
+
+	balance := func(addr sdk.AccAddress) int64 {
+		return k.BankKeeper.GetCoins(ctx, addr).AmountOf(bondDenom)
+	}
+	balancesBefore := []int64{balance(restaker), balance(duster), balance(payee)}
+
+	k.setBatchRewards(ctx, 0, []types.Reward{
+		{ValAddr: validator.OperatorAddr, AccAddr: restaker, Amount: 3e8},
+		{ValAddr: validator.OperatorAddr, AccAddr: duster, Amount: 5e7},
+		{ValAddr: validator.OperatorAddr, AccAddr: payee, Amount: 2e8},
+	})
+	k.setRewardValDistAddrs(ctx, []types.StoredValDistAddr{{Validator: validator.OperatorAddr, DistributeAddr: distAddr}})
+	events := k.DistributeInBlock(ctx, "")
+
+	// the reward of the opted in delegator is delegated back, the dust and the reward of the others stay liquid
+	require.Equal(t, balancesBefore[0], balance(restaker))
+	delegation, found := k.GetDelegation(ctx, restaker, validator.OperatorAddr)
+	require.True(t, found)
+	require.EqualValues(t, 3e8, delegation.Shares.RawInt())
+	require.Equal(t, balancesBefore[1]+5e7, balance(duster))
+	_, found = k.GetDelegation(ctx, duster, validator.OperatorAddr)
+	require.False(t, found)
+	require.Equal(t, balancesBefore[2]+2e8, balance(payee))
+	require.EqualValues(t, 10e8-3e8-5e7-2e8, balance(distAddr))
+
+	require.Len(t, events, 1)
+	require.Equal(t, types.EventTypeTotalRestake, events[0].Type)
+	require.Equal(t, "300000000", string(events[0].Attributes[0].Value))
+}
+
+func TestCompoundLiquidStakeRewardFailure(t *testing.T) {
+	ctx, _, k := CreateTestInput(t, false, 1000)
+	bondDenom := k.BondDenom(ctx)
+
+	pool := k.GetPool(ctx)
+	validator := types.NewValidator(addrVals[0], PKs[0], types.Description{})
+	validator, pool, _ = validator.AddTokensFromDel(pool, 100e8)
+	k.SetPool(ctx, pool)
+	validator = TestingUpdateValidator(k, ctx, validator)
+
+	// the tokens of the validator are all slashed
+	validator.Tokens = sdk.ZeroDec()
+	k.SetValidator(ctx, validator)
+	_, _, err := k.BankKeeper.AddCoins(ctx, LiquidStakeAccAddr, sdk.Coins{sdk.NewCoin(bondDenom, 5e8)})
+	require.Nil(t, err)
+
+	// the reward is left liquid instead of halting the distribution
+	reward := types.Reward{ValAddr: validator.OperatorAddr, AccAddr: LiquidStakeAccAddr, Amount: 5e8}
+	require.NotPanics(t, func() { require.False(t, k.restakeReward(ctx, reward)) })
+	require.EqualValues(t, 5e8, k.BankKeeper.GetCoins(ctx, LiquidStakeAccAddr).AmountOf(bondDenom))
+	_, found := k.GetDelegation(ctx, LiquidStakeAccAddr, validator.OperatorAddr)
+	require.False(t, found)
+
+	// so is a reward which the liquid stake account can not pay
+	validator.Tokens = sdk.NewDecWithoutFra(100)
+	k.SetValidator(ctx, validator)
+	reward.Amount = 10e8
+	require.NotPanics(t, func() { require.False(t, k.restakeReward(ctx, reward)) })
+	require.EqualValues(t, 5e8, k.BankKeeper.GetCoins(ctx, LiquidStakeAccAddr).AmountOf(bondDenom))
+	_, found = k.GetDelegation(ctx, LiquidStakeAccAddr, validator.OperatorAddr)
+	require.False(t, found)
+}
+
+func TestRestakeRewardOfOperator(t *testing.T) {
+	ctx, _, k := CreateTestInput(t, false, 1000)
+	bondDenom := k.BondDenom(ctx)
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.RewardRestake, 199)
+	sdk.UpgradeMgr.SetHeight(200)
+
+	pool := k.GetPool(ctx)
+	validator := types.NewValidatorWithFeeAddr(Addrs[5], addrVals[0], PKs[0], types.Description{})
+	validator, pool, _ = validator.AddTokensFromDel(pool, 100e8)
+	k.SetPool(ctx, pool)
+	validator = TestingUpdateValidator(k, ctx, validator)
+
+	// the operator which is not the self-delegator can not delegate to its validator, nor restake
+	operator := sdk.AccAddress(validator.OperatorAddr)
+	balance := k.BankKeeper.GetCoins(ctx, operator).AmountOf(bondDenom)
+	k.SetRewardRestake(ctx, operator, true)
+	require.False(t, k.restakeReward(ctx, types.Reward{ValAddr: validator.OperatorAddr, AccAddr: operator, Amount: 3e8}))
+	require.Equal(t, balance, k.BankKeeper.GetCoins(ctx, operator).AmountOf(bondDenom))
+	_, found := k.GetDelegation(ctx, operator, validator.OperatorAddr)
+	require.False(t, found)
+
+	// the self-delegator can
+	validator.FeeAddr = operator
+	k.SetValidator(ctx, validator)
+	require.True(t, k.restakeReward(ctx, types.Reward{ValAddr: validator.OperatorAddr, AccAddr: operator, Amount: 3e8}))
+	require.Equal(t, balance-3e8, k.BankKeeper.GetCoins(ctx, operator).AmountOf(bondDenom))
+}
@@ -0,0 +1,214 @@
+package keeper
+
+import (
+	"bytes"
+	"time"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+var (
+	rewardRecordByDelegatorPrefix = []byte{0x01} // prefix for the reward records, by chain, delegator, time and validator
+	rewardRecordByValidatorPrefix = []byte{0x02} // prefix for the reward record index by chain, validator, time and delegator
+	rewardRecordByTimePrefix      = []byte{0x03} // prefix for the reward record index by time, for pruning
+)
+
+// RewardHistory keeps the rewards distributed to the delegators of all chains, so that they can be queried by
+// delegator and by validator. It is node-local and does not take part in the consensus, a node keeps it only if it
+// is set to the stake keeper.
+type RewardHistory struct {
+	db  dbm.DB
+	cdc *codec.Codec
+
+	// number of recent days of rewards to keep, 0 means keep everything
+	retainDays int64
+}
+
+// NewRewardHistory creates a reward history on top of db, e.g. a goleveldb opened under the node home.
+// retainDays is the number of recent days of rewards kept whenever rewards are added, 0 disables pruning.
+func NewRewardHistory(db dbm.DB, cdc *codec.Codec, retainDays int64) *RewardHistory {
+	return &RewardHistory{
+		db:         db,
+		cdc:        cdc,
+		retainDays: retainDays,
+	}
+}
+
+// RewardHistoryFilter selects the rewards of a delegator, of a validator, or of a delegator from a validator, which are
+// distributed in a time range. The time bounds are inclusive, and a zero bound is unbounded.
+type RewardHistoryFilter struct {
+	ChainId       string
+	DelegatorAddr sdk.AccAddress
+	ValidatorAddr sdk.ValAddress
+	FromTime      time.Time
+	ToTime        time.Time
+}
+
+// SetRewardHistory makes the keeper keep the rewards distributed to the delegators in history.
+func (k *Keeper) SetRewardHistory(history *RewardHistory) {
+	k.rewardHistory = history
+}
+
+// RewardHistoryEnabled tells if the node keeps the reward history.
+func (k Keeper) RewardHistoryEnabled() bool {
+	return k.rewardHistory != nil
+}
+
+// recordRewards adds the rewards distributed in the block to the reward history, and prunes the rewards out of the
+// retained days. Only the rewards distributed in delivered blocks are recorded.
+func (k Keeper) recordRewards(ctx sdk.Context, chainId string, rewards []types.Reward) {
+	if k.rewardHistory == nil || !ctx.IsDeliverTx() || len(rewards) == 0 {
+		return
+	}
+	blockTime := ctx.BlockHeader().Time
+	records := make([]types.RewardRecord, 0, len(rewards))
+	for _, reward := range rewards {
+		records = append(records, types.RewardRecord{
+			ChainId:    chainId,
+			Height:     ctx.BlockHeight(),
+			Time:       blockTime,
+			ValAddr:    reward.ValAddr,
+			AccAddr:    reward.AccAddr,
+			Tokens:     reward.Tokens,
+			Amount:     reward.Amount,
+			CrossStake: reward.CrossStake,
+			Restaked:   reward.Restaked,
+		})
+	}
+	k.rewardHistory.add(records)
+	if k.rewardHistory.retainDays > 0 {
+		k.rewardHistory.prune(blockTime.Add(-time.Duration(k.rewardHistory.retainDays) * 24 * time.Hour))
+	}
+}
+
+// GetRewardHistory returns a page of at most limit rewards selected by the filter, in the order of time, starting from
+// cursor which is the next cursor returned by the previous page. The next cursor is nil on the last page.
+func (k Keeper) GetRewardHistory(filter RewardHistoryFilter, cursor []byte, limit int) ([]types.RewardRecord, []byte, sdk.Error) {
+	if k.rewardHistory == nil {
+		return nil, nil, sdk.ErrUnknownRequest("reward history is not kept by this node")
+	}
+	return k.rewardHistory.records(filter, cursor, limit)
+}
+
+func (h *RewardHistory) add(records []types.RewardRecord) {
+	batch := h.db.NewBatch()
+	defer batch.Close()
+	for _, record := range records {
+		recordKey := getRewardRecordKey(record.ChainId, record.AccAddr, record.Time, record.ValAddr)
+		batch.Set(recordKey, h.cdc.MustMarshalBinaryLengthPrefixed(record))
+		batch.Set(getRewardRecordByValidatorKey(record.ChainId, record.ValAddr, record.Time, record.AccAddr), recordKey)
+		batch.Set(getRewardRecordByTimeKey(record.Time, recordKey), recordKey)
+	}
+	batch.Write()
+}
+
+// prune drops all the rewards distributed before the time.
+func (h *RewardHistory) prune(before time.Time) {
+	iterator := h.db.Iterator(rewardRecordByTimePrefix, append(append([]byte{}, rewardRecordByTimePrefix...), sdk.FormatTimeBytes(before)...))
+	var indexKeys, recordKeys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		indexKeys = append(indexKeys, iterator.Key())
+		recordKeys = append(recordKeys, iterator.Value())
+	}
+	iterator.Close()
+	if len(indexKeys) == 0 {
+		return
+	}
+
+	batch := h.db.NewBatch()
+	defer batch.Close()
+	for i, recordKey := range recordKeys {
+		if bz := h.db.Get(recordKey); bz != nil {
+			var record types.RewardRecord
+			h.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &record)
+			batch.Delete(getRewardRecordByValidatorKey(record.ChainId, record.ValAddr, record.Time, record.AccAddr))
+		}
+		batch.Delete(recordKey)
+		batch.Delete(indexKeys[i])
+	}
+	batch.Write()
+}
+
+func (h *RewardHistory) records(filter RewardHistoryFilter, cursor []byte, limit int) ([]types.RewardRecord, []byte, sdk.Error) {
+	var prefix []byte
+	switch {
+	case len(filter.DelegatorAddr) != 0:
+		prefix = getRewardRecordsByDelegatorKey(filter.ChainId, filter.DelegatorAddr)
+	case len(filter.ValidatorAddr) != 0:
+		prefix = getRewardRecordsByValidatorKey(filter.ChainId, filter.ValidatorAddr)
+	default:
+		return nil, nil, sdk.ErrUnknownRequest("either delegator or validator of the rewards is required")
+	}
+
+	start, end := prefix, sdk.PrefixEndBytes(prefix)
+	if !filter.FromTime.IsZero() {
+		start = append(append([]byte{}, prefix...), sdk.FormatTimeBytes(filter.FromTime)...)
+	}
+	if !filter.ToTime.IsZero() {
+		end = sdk.PrefixEndBytes(append(append([]byte{}, prefix...), sdk.FormatTimeBytes(filter.ToTime)...))
+	}
+	if len(cursor) != 0 {
+		if !bytes.HasPrefix(cursor, prefix) {
+			return nil, nil, sdk.ErrUnknownRequest("invalid cursor of reward history")
+		}
+		if bytes.Compare(cursor, start) > 0 {
+			start = cursor
+		}
+	}
+
+	iterator := h.db.Iterator(start, end)
+	defer iterator.Close()
+
+	records := make([]types.RewardRecord, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		bz := iterator.Value()
+		if len(filter.DelegatorAddr) == 0 {
+			// the validator index points to the record
+			if bz = h.db.Get(bz); bz == nil {
+				continue
+			}
+		}
+		var record types.RewardRecord
+		h.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &record)
+		if len(filter.DelegatorAddr) != 0 && len(filter.ValidatorAddr) != 0 && !record.ValAddr.Equals(filter.ValidatorAddr) {
+			continue
+		}
+		if len(records) == limit {
+			return records, iterator.Key(), nil
+		}
+		records = append(records, record)
+	}
+	return records, nil, nil
+}
+
+func getRewardRecordsChainKey(prefix []byte, chainId string) []byte {
+	key := append(append([]byte{}, prefix...), byte(len(chainId)))
+	return append(key, []byte(chainId)...)
+}
+
+func getRewardRecordsByDelegatorKey(chainId string, delAddr sdk.AccAddress) []byte {
+	return append(getRewardRecordsChainKey(rewardRecordByDelegatorPrefix, chainId), delAddr.Bytes()...)
+}
+
+func getRewardRecordsByValidatorKey(chainId string, valAddr sdk.ValAddress) []byte {
+	return append(getRewardRecordsChainKey(rewardRecordByValidatorPrefix, chainId), valAddr.Bytes()...)
+}
+
+func getRewardRecordKey(chainId string, delAddr sdk.AccAddress, distributeTime time.Time, valAddr sdk.ValAddress) []byte {
+	key := append(getRewardRecordsByDelegatorKey(chainId, delAddr), sdk.FormatTimeBytes(distributeTime)...)
+	return append(key, valAddr.Bytes()...)
+}
+
+func getRewardRecordByValidatorKey(chainId string, valAddr sdk.ValAddress, distributeTime time.Time, delAddr sdk.AccAddress) []byte {
+	key := append(getRewardRecordsByValidatorKey(chainId, valAddr), sdk.FormatTimeBytes(distributeTime)...)
+	return append(key, delAddr.Bytes()...)
+}
+
+func getRewardRecordByTimeKey(distributeTime time.Time, recordKey []byte) []byte {
+	key := append(append([]byte{}, rewardRecordByTimePrefix...), sdk.FormatTimeBytes(distributeTime)...)
+	return append(key, recordKey...)
+}
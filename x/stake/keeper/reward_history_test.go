@@ -0,0 +1,85 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake/types"
+)
+
+func TestRewardHistory(t *testing.T) {
+	ctx, am, k := CreateTestInput(t, false, 0)
+	k.SetRewardHistory(NewRewardHistory(dbm.NewMemDB(), k.cdc, 2))
+	bondDenom := k.BondDenom(ctx)
+
+	distAddr := sdk.AccAddress([]byte("reward-distribute-addr"))
+	distAcc := am.NewAccountWithAddress(ctx, distAddr)
+	require.NoError(t, distAcc.SetCoins(sdk.Coins{sdk.NewCoin(bondDenom, 100e8)}))
+	am.SetAccount(ctx, distAcc)
+
+	delegator, other := Addrs[0], Addrs[1]
+	validator0, validator1 := addrVals[0], addrVals[1]
+	day := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	distribute := func(days int) {
+		ctx = ctx.WithBlockHeight(int64(100 + days)).WithBlockTime(day.Add(time.Duration(days) * 24 * time.Hour))
+		k.setBatchRewards(ctx, 0, []types.Reward{
+			{ValAddr: validator0, AccAddr: delegator, Amount: 1e8, Tokens: sdk.NewDecWithoutFra(10)},
+			{ValAddr: validator1, AccAddr: delegator, Amount: 2e8, Tokens: sdk.NewDecWithoutFra(20)},
+			{ValAddr: validator0, AccAddr: other, Amount: 3e8, Tokens: sdk.NewDecWithoutFra(30)},
+		})
+		k.setRewardValDistAddrs(ctx, []types.StoredValDistAddr{
+			{Validator: validator0, DistributeAddr: distAddr},
+			{Validator: validator1, DistributeAddr: distAddr},
+		})
+		k.DistributeInBlock(ctx, types.ChainIDForBeaconChain)
+	}
+	distribute(0)
+	distribute(1)
+
+	query := func(filter RewardHistoryFilter, cursor []byte, limit int) ([]types.RewardRecord, []byte) {
+		filter.ChainId = types.ChainIDForBeaconChain
+		records, next, err := k.GetRewardHistory(filter, cursor, limit)
+		require.Nil(t, err)
+		return records, next
+	}
+
+	records, next := query(RewardHistoryFilter{DelegatorAddr: delegator}, nil, 10)
+	require.Len(t, records, 4)
+	require.Nil(t, next)
+	require.EqualValues(t, 100, records[0].Height)
+	require.Equal(t, day, records[0].Time)
+	require.EqualValues(t, 101, records[3].Height)
+
+	// the rewards of a day, from a validator
+	records, _ = query(RewardHistoryFilter{DelegatorAddr: delegator, FromTime: day.Add(24 * time.Hour)}, nil, 10)
+	require.Len(t, records, 2)
+	records, _ = query(RewardHistoryFilter{DelegatorAddr: delegator, ValidatorAddr: validator1, ToTime: day}, nil, 10)
+	require.Len(t, records, 1)
+	require.EqualValues(t, 2e8, records[0].Amount)
+	require.Equal(t, validator1, records[0].ValAddr)
+
+	// the rewards of the delegators of a validator, paginated
+	records, next = query(RewardHistoryFilter{ValidatorAddr: validator0}, nil, 3)
+	require.Len(t, records, 3)
+	require.NotNil(t, next)
+	records, next = query(RewardHistoryFilter{ValidatorAddr: validator0}, next, 3)
+	require.Len(t, records, 1)
+	require.Nil(t, next)
+	require.Equal(t, other, records[0].AccAddr)
+
+	_, _, err := k.GetRewardHistory(RewardHistoryFilter{ChainId: types.ChainIDForBeaconChain}, nil, 10)
+	require.NotNil(t, err)
+
+	// the rewards out of the retained days are pruned
+	distribute(3)
+	records, _ = query(RewardHistoryFilter{DelegatorAddr: delegator}, nil, 10)
+	require.Len(t, records, 4)
+	require.EqualValues(t, 101, records[0].Height)
+	records, _ = query(RewardHistoryFilter{ValidatorAddr: validator0}, nil, 10)
+	require.Len(t, records, 4)
+	require.EqualValues(t, 101, records[0].Height)
+}
@@ -2,6 +2,8 @@ package querier
 
 import (
 	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -31,6 +33,17 @@ const (
 	QueryAllValidatorsCount            = "allValidatorsCount"
 	QueryAllUnJailValidatorsCount      = "allUnJailValidatorsCount"
 	QueryCrossStakeInfoByBscAddress    = "crossStakeInfoByBscAddress"
+	QueryRewardRestake                 = "rewardRestake"
+	QueryRewardHistory                 = "rewardHistory"
+	QueryPendingCommissions            = "pendingCommissions"
+	QueryLiquidStakeReceipts           = "liquidStakeReceipts"
+	QueryCrossStakeRecords             = "crossStakeRecordsByBscAddress"
+	QueryCrossStakeRecord              = "crossStakeRecord"
+
+	// DefaultRewardHistoryLimit is the number of rewards of a page if the limit is not set, MaxRewardHistoryLimit is
+	// the most of a page
+	DefaultRewardHistoryLimit = 100
+	MaxRewardHistoryLimit     = 1000
 )
 
 // creates a querier for staking REST endpoints
@@ -163,6 +176,48 @@ func NewQuerier(k keep.Keeper, cdc *codec.Codec) sdk.Querier {
 				return res, err
 			}
 			return queryCrossStakeInfoByBscAddress(ctx, cdc, p, k)
+		case QueryRewardRestake:
+			p := new(QueryDelegatorParams)
+			ctx, err = RequestPrepare(ctx, k, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryRewardRestake(ctx, cdc, p, k)
+		case QueryRewardHistory:
+			p := new(QueryRewardHistoryParams)
+			ctx, err = RequestPrepare(ctx, k, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryRewardHistory(cdc, p, k)
+		case QueryPendingCommissions:
+			p := new(QueryValidatorParams)
+			ctx, err = RequestPrepare(ctx, k, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryPendingCommissions(ctx, cdc, p, k)
+		case QueryLiquidStakeReceipts:
+			p := new(QueryValidatorParams)
+			ctx, err = RequestPrepare(ctx, k, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryLiquidStakeReceipts(ctx, cdc, p, k)
+		case QueryCrossStakeRecords:
+			p := new(QueryCrossStakeInfoParams)
+			ctx, err = RequestPrepare(ctx, k, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryCrossStakeRecords(ctx, cdc, p, k)
+		case QueryCrossStakeRecord:
+			p := new(QueryCrossStakeRecordParams)
+			ctx, err = RequestPrepare(ctx, k, req, p)
+			if err != nil {
+				return res, err
+			}
+			return queryCrossStakeRecord(ctx, cdc, p, k)
 		default:
 			return nil, sdk.ErrUnknownRequest("unknown stake query endpoint")
 		}
@@ -217,6 +272,7 @@ type QueryDelegatorParams struct {
 // - 'custom/stake/validator'
 // - 'custom/stake/validatorUnbondingDelegations'
 // - 'custom/stake/validatorRedelegations'
+// - 'custom/stake/pendingCommissions', all the pending commissions are queried if ValidatorAddr is not set
 type QueryValidatorParams struct {
 	BaseParams
 	ValidatorAddr sdk.ValAddress
@@ -251,6 +307,62 @@ type QueryCrossStakeInfoParams struct {
 	BscAddress sdk.SmartChainAddress
 }
 
+// defines the params for 'custom/stake/crossStakeRecord'
+type QueryCrossStakeRecordParams struct {
+	BaseParams
+	BscAddress sdk.SmartChainAddress
+	Sequence   uint64
+}
+
+// QueryRewardHistoryParams queries a page of the rewards of a delegator, of a validator, or of a delegator from a
+// validator, distributed from FromTime to ToTime, starting from Cursor which is the NextCursor of the previous page.
+type QueryRewardHistoryParams struct {
+	BaseParams
+	DelegatorAddr sdk.AccAddress
+	ValidatorAddr sdk.ValAddress
+	FromTime      time.Time
+	ToTime        time.Time
+	Cursor        []byte
+	Limit         int
+}
+
+// RewardHistoryDateLayout is the layout of the dates which bound the reward history queried by the clients
+const RewardHistoryDateLayout = "2006-01-02"
+
+// ParseRewardHistoryDates parses the inclusive range of UTC dates in RewardHistoryDateLayout to the range of times,
+// an empty date is unbounded.
+func ParseRewardHistoryDates(fromDate, toDate string) (fromTime, toTime time.Time, err error) {
+	if len(fromDate) != 0 {
+		if fromTime, err = time.Parse(RewardHistoryDateLayout, fromDate); err != nil {
+			return fromTime, toTime, err
+		}
+	}
+	if len(toDate) != 0 {
+		if toTime, err = time.Parse(RewardHistoryDateLayout, toDate); err != nil {
+			return fromTime, toTime, err
+		}
+		toTime = toTime.Add(24*time.Hour - time.Nanosecond)
+	}
+	if !fromTime.IsZero() && !toTime.IsZero() && toTime.Before(fromTime) {
+		return fromTime, toTime, fmt.Errorf("the date range from %s to %s is empty", fromDate, toDate)
+	}
+	return fromTime, toTime, nil
+}
+
+// RewardHistoryPage is a page of rewards, NextCursor is the cursor of the next page in hex, empty on the last page.
+type RewardHistoryPage struct {
+	Rewards    []types.RewardRecord `json:"rewards"`
+	NextCursor string               `json:"next_cursor"`
+}
+
+// LiquidStakeReceiptInfo is a liquid stake receipt with the delegation shares and the bonded tokens it represents, a
+// receipt token is worth Tokens divided by Supply.
+type LiquidStakeReceiptInfo struct {
+	types.LiquidStakeReceipt
+	Shares sdk.Dec `json:"shares"`
+	Tokens sdk.Dec `json:"tokens"`
+}
+
 func queryValidators(ctx sdk.Context, cdc *codec.Codec, k keep.Keeper) (res []byte, err sdk.Error) {
 	stakeParams := k.GetParams(ctx)
 	validators := k.GetValidators(ctx, stakeParams.MaxValidators)
@@ -518,3 +630,121 @@ func delegationsToDelegationResponses(
 
 	return resp, nil
 }
+
+func queryRewardRestake(ctx sdk.Context, cdc *codec.Codec, params *QueryDelegatorParams, k keep.Keeper) ([]byte, sdk.Error) {
+	res, errRes := codec.MarshalJSONIndent(cdc, k.IsRewardRestake(ctx, params.DelegatorAddr))
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
+func queryRewardHistory(cdc *codec.Codec, params *QueryRewardHistoryParams, k keep.Keeper) ([]byte, sdk.Error) {
+	chainId := params.SideChainId
+	if len(chainId) == 0 {
+		chainId = types.ChainIDForBeaconChain
+	}
+	limit := params.Limit
+	if limit <= 0 {
+		limit = DefaultRewardHistoryLimit
+	} else if limit > MaxRewardHistoryLimit {
+		limit = MaxRewardHistoryLimit
+	}
+	filter := keep.RewardHistoryFilter{
+		ChainId:       chainId,
+		DelegatorAddr: params.DelegatorAddr,
+		ValidatorAddr: params.ValidatorAddr,
+		FromTime:      params.FromTime,
+		ToTime:        params.ToTime,
+	}
+	rewards, nextCursor, err := k.GetRewardHistory(filter, params.Cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	page := RewardHistoryPage{Rewards: rewards}
+	if len(nextCursor) != 0 {
+		page.NextCursor = sdk.HexEncode(nextCursor)
+	}
+	res, errRes := codec.MarshalJSONIndent(cdc, page)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
+func queryPendingCommissions(ctx sdk.Context, cdc *codec.Codec, params *QueryValidatorParams, k keep.Keeper) ([]byte, sdk.Error) {
+	pendings := make([]types.PendingCommission, 0)
+	if len(params.ValidatorAddr) == 0 {
+		pendings = k.GetAllPendingCommissions(ctx)
+	} else if pending, found := k.GetPendingCommission(ctx, params.ValidatorAddr); found {
+		pendings = append(pendings, pending)
+	}
+
+	res, errRes := codec.MarshalJSONIndent(cdc, pendings)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
+func queryLiquidStakeReceipts(ctx sdk.Context, cdc *codec.Codec, params *QueryValidatorParams, k keep.Keeper) ([]byte, sdk.Error) {
+	if len(ctx.SideChainId()) == 0 {
+		return nil, types.ErrInvalidSideChainId(k.Codespace())
+	}
+
+	receipts := make([]types.LiquidStakeReceipt, 0)
+	if len(params.ValidatorAddr) != 0 {
+		if receipt, found := k.GetLiquidStakeReceiptByValidator(ctx, ctx.SideChainId(), params.ValidatorAddr); found {
+			receipts = append(receipts, receipt)
+		}
+	} else {
+		for _, receipt := range k.GetAllLiquidStakeReceipts(ctx) {
+			if receipt.ChainId == ctx.SideChainId() {
+				receipts = append(receipts, receipt)
+			}
+		}
+	}
+
+	infos := make([]LiquidStakeReceiptInfo, 0, len(receipts))
+	for _, receipt := range receipts {
+		infos = append(infos, LiquidStakeReceiptInfo{
+			LiquidStakeReceipt: receipt,
+			Shares:             k.GetLiquidStakeShares(ctx, receipt.ValidatorAddr),
+			Tokens:             k.GetLiquidStakeTokens(ctx, receipt.ValidatorAddr),
+		})
+	}
+
+	res, errRes := codec.MarshalJSONIndent(cdc, infos)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
+func queryCrossStakeRecords(ctx sdk.Context, cdc *codec.Codec, params *QueryCrossStakeInfoParams, k keep.Keeper) ([]byte, sdk.Error) {
+	if params.BscAddress.IsEmpty() {
+		return []byte{}, sdk.ErrInternal("invalid side chain address")
+	}
+	records := k.GetCrossStakeRecords(ctx, params.BscAddress)
+	res, errRes := codec.MarshalJSONIndent(cdc, records)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
+
+func queryCrossStakeRecord(ctx sdk.Context, cdc *codec.Codec, params *QueryCrossStakeRecordParams, k keep.Keeper) ([]byte, sdk.Error) {
+	if params.BscAddress.IsEmpty() {
+		return []byte{}, sdk.ErrInternal("invalid side chain address")
+	}
+	record, found := k.GetCrossStakeRecord(ctx, params.BscAddress, params.Sequence)
+	if !found {
+		return nil, types.ErrNoCrossStakeRecord(k.Codespace())
+	}
+	res, errRes := codec.MarshalJSONIndent(cdc, record)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", errRes.Error()))
+	}
+	return res, nil
+}
@@ -2,6 +2,7 @@ package simulation
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -104,6 +105,46 @@ func PositivePowerInvariant(k stake.Keeper) simulation.Invariant {
 	}
 }
 
+// LiquidStakeInvariant checks that the receipt tokens held by the accounts sum up to the supply of their receipts, and
+// that the receipts in circulation are backed by the shares of the liquid stake account
+func LiquidStakeInvariant(k stake.Keeper, am auth.AccountKeeper, accs *[]simulation.Account) simulation.Invariant {
+	return func(app *baseapp.BaseApp) error {
+		ctx := app.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+		// the accounts are not in the store until the block is committed, so read them from the account cache
+		held := make(map[string]int64)
+		for _, simAcc := range *accs {
+			acc := am.GetAccount(ctx, simAcc.Address)
+			if acc == nil {
+				continue
+			}
+			for _, coin := range acc.GetCoins() {
+				if strings.HasPrefix(coin.Denom, stake.LiquidStakeDenomPrefix) {
+					held[coin.Denom] += coin.Amount
+				}
+			}
+		}
+
+		for _, receipt := range k.GetAllLiquidStakeReceipts(ctx) {
+			if held[receipt.Denom] != receipt.Supply {
+				return fmt.Errorf("expected receipt tokens held by accounts to equal the supply - denom: %s, supply: %d, held: %d", receipt.Denom, receipt.Supply, held[receipt.Denom])
+			}
+			sideCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, receipt.ChainId)
+			if err != nil {
+				return err
+			}
+			shares := k.GetLiquidStakeShares(sideCtx, receipt.ValidatorAddr)
+			if (receipt.Supply > 0) != shares.GT(sdk.ZeroDec()) {
+				return fmt.Errorf("expected receipt tokens to be backed by shares - denom: %s, supply: %d, shares: %v", receipt.Denom, receipt.Supply, shares)
+			}
+			delete(held, receipt.Denom)
+		}
+		for denom := range held {
+			return fmt.Errorf("receipt tokens %s held without a receipt", denom)
+		}
+		return nil
+	}
+}
+
 // ValidatorSetInvariant checks equivalence of Tendermint validator set and SDK validator set
 func ValidatorSetInvariant(k stake.Keeper) simulation.Invariant {
 	return func(app *baseapp.BaseApp) error {
@@ -3,10 +3,13 @@ package simulation
 import (
 	"fmt"
 	"math/rand"
+	"strings"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/mock"
 	"github.com/cosmos/cosmos-sdk/x/mock/simulation"
 	"github.com/cosmos/cosmos-sdk/x/stake"
@@ -229,6 +232,102 @@ func SimulateMsgBeginRedelegate(m auth.AccountKeeper, k stake.Keeper) simulation
 	}
 }
 
+// SimulateMsgSideChainLiquidDelegate
+func SimulateMsgSideChainLiquidDelegate(m auth.AccountKeeper, k stake.Keeper, sideChainId string) simulation.Operation {
+	handler := stake.NewHandler(k, gov.Keeper{})
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, event func(string)) (
+		action string, fOp []simulation.FutureOperation, err error) {
+
+		sideCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, sideChainId)
+		if err != nil {
+			return "", nil, err
+		}
+		validators := k.GetAllValidators(sideCtx)
+		if len(validators) == 0 {
+			return "no-operation", nil, nil
+		}
+		validator := validators[r.Intn(len(validators))]
+
+		denom := k.BondDenom(sideCtx)
+		delegatorAcc := simulation.RandomAcc(r, accs)
+		amount := m.GetAccount(ctx, delegatorAcc.Address).GetCoins().AmountOf(denom)
+		if amount > 0 {
+			amount = simulation.RandomAmount(r, amount)
+		}
+		if amount == 0 {
+			return "no-operation", nil, nil
+		}
+		msg := stake.NewMsgSideChainLiquidDelegate(sideChainId, delegatorAcc.Address, validator.OperatorAddr, sdk.NewCoin(denom, amount))
+		if msg.ValidateBasic() != nil {
+			return "", nil, fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
+		}
+		ctx, write := ctx.CacheContext()
+		result := handler(ctx, msg)
+		if result.IsOK() {
+			write()
+		}
+		event(fmt.Sprintf("stake/MsgSideChainLiquidDelegate/%v", result.IsOK()))
+		action = fmt.Sprintf("TestMsgSideChainLiquidDelegate: ok %v, msg %s", result.IsOK(), msg.GetSignBytes())
+		return action, nil, nil
+	}
+}
+
+// the first time which can not be marshaled, 10000-01-01T00:00:00Z
+var maxUnbondingCompletionTime = time.Unix(253402300800, 0).UTC()
+
+// SimulateMsgSideChainLiquidUndelegate
+func SimulateMsgSideChainLiquidUndelegate(m auth.AccountKeeper, k stake.Keeper, sideChainId string) simulation.Operation {
+	handler := stake.NewHandler(k, gov.Keeper{})
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context,
+		accs []simulation.Account, event func(string)) (
+		action string, fOp []simulation.FutureOperation, err error) {
+
+		// the random block times may be so late that the completion time of the unbonding can't be marshaled
+		sideCtx, err := k.ScKeeper.PrepareCtxForSideChain(ctx, sideChainId)
+		if err != nil {
+			return "", nil, err
+		}
+		if !ctx.BlockHeader().Time.Add(k.UnbondingTime(sideCtx)).Before(maxUnbondingCompletionTime) {
+			return "no-operation", nil, nil
+		}
+
+		delegatorAcc := simulation.RandomAcc(r, accs)
+		var receipts sdk.Coins
+		for _, coin := range m.GetAccount(ctx, delegatorAcc.Address).GetCoins() {
+			if strings.HasPrefix(coin.Denom, stake.LiquidStakeDenomPrefix) {
+				receipts = append(receipts, coin)
+			}
+		}
+		if len(receipts) == 0 {
+			return "no-operation", nil, nil
+		}
+		receiptCoin := receipts[r.Intn(len(receipts))]
+		receipt, found := k.GetLiquidStakeReceipt(ctx, receiptCoin.Denom)
+		if !found {
+			return "", nil, fmt.Errorf("no liquid stake receipt of %s", receiptCoin.Denom)
+		}
+
+		// undelegate all the receipt tokens at times
+		amount := receiptCoin.Amount
+		if r.Intn(2) == 0 {
+			amount = simulation.RandomAmount(r, amount) + 1
+		}
+		msg := stake.NewMsgSideChainLiquidUndelegate(sideChainId, delegatorAcc.Address, receipt.ValidatorAddr, sdk.NewCoin(receiptCoin.Denom, amount))
+		if msg.ValidateBasic() != nil {
+			return "", nil, fmt.Errorf("expected msg to pass ValidateBasic: %s", msg.GetSignBytes())
+		}
+		ctx, write := ctx.CacheContext()
+		result := handler(ctx, msg)
+		if result.IsOK() {
+			write()
+		}
+		event(fmt.Sprintf("stake/MsgSideChainLiquidUndelegate/%v", result.IsOK()))
+		action = fmt.Sprintf("TestMsgSideChainLiquidUndelegate: ok %v, msg %s", result.IsOK(), msg.GetSignBytes())
+		return action, nil, nil
+	}
+}
+
 // Setup
 // nolint: errcheck
 func Setup(mapp *mock.App, k stake.Keeper) simulation.RandSetup {
@@ -251,3 +350,31 @@ func Setup(mapp *mock.App, k stake.Keeper) simulation.RandSetup {
 		k.SetPool(ctx, pool)
 	}
 }
+
+// SetupSideChain registers a side chain, and creates side chain validators of some accounts with their self-delegation
+// nolint: errcheck
+func SetupSideChain(mapp *mock.App, k stake.Keeper, sideChainId string) simulation.RandSetup {
+	return func(r *rand.Rand, accs []simulation.Account) {
+		ctx := mapp.NewContext(sdk.RunTxModeDeliver, abci.Header{})
+		k.ScKeeper.SetSideChainIdAndStorePrefix(ctx, sideChainId, []byte{0x99})
+		sideCtx, _ := k.ScKeeper.PrepareCtxForSideChain(ctx, sideChainId)
+		params := k.GetParams(ctx)
+		params.MinDelegationChange = 1
+		k.SetParams(sideCtx, params)
+		k.SetPool(sideCtx, stake.Pool{LooseTokens: sdk.NewDec(5e15)})
+
+		for i := 0; i < 3; i++ {
+			acc := simulation.RandomAcc(r, accs)
+			amount := mapp.AccountKeeper.GetAccount(ctx, acc.Address).GetCoins().AmountOf(params.BondDenom)
+			if _, found := k.GetValidator(sideCtx, sdk.ValAddress(acc.Address)); found || amount == 0 {
+				continue
+			}
+			validator := stake.NewSideChainValidator(acc.Address, sdk.ValAddress(acc.Address), stake.Description{
+				Moniker: simulation.RandStringOfLength(r, 10),
+			}, sideChainId, acc.Address.Bytes(), acc.Address.Bytes(), nil)
+			k.SetValidator(sideCtx, validator)
+			k.SetValidatorByConsAddr(sideCtx, validator)
+			k.Delegate(sideCtx, acc.Address, sdk.NewCoin(params.BondDenom, simulation.RandomAmount(r, amount)+1), validator, true)
+		}
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/distribution"
+	"github.com/cosmos/cosmos-sdk/x/gov"
 	"github.com/cosmos/cosmos-sdk/x/mock"
 	"github.com/cosmos/cosmos-sdk/x/mock/simulation"
 	"github.com/cosmos/cosmos-sdk/x/params"
@@ -77,3 +78,56 @@ func TestStakeWithRandomMessages(t *testing.T) {
 		false,
 	)
 }
+
+// TestLiquidStakeWithRandomMessages
+func TestLiquidStakeWithRandomMessages(t *testing.T) {
+	mapp := mock.NewApp()
+
+	bank.RegisterCodec(mapp.Cdc)
+	mapper := mapp.AccountKeeper
+	bankKeeper := bank.NewBaseKeeper(mapper)
+	stakeKey := sdk.NewKVStoreKey("stake")
+	stakeRewardKey := sdk.NewKVStoreKey("stake_reward")
+	stakeTKey := sdk.NewTransientStoreKey("transient_stake")
+	paramsKey := sdk.NewKVStoreKey("params")
+	paramsTKey := sdk.NewTransientStoreKey("transient_params")
+	ibcKey := sdk.NewKVStoreKey("ibc")
+	keySideChain := sdk.NewKVStoreKey("sc")
+
+	paramstore := params.NewKeeper(mapp.Cdc, paramsKey, paramsTKey)
+	scKeeper := sidechain.NewKeeper(keySideChain, paramstore.Subspace(sidechain.DefaultParamspace), mapp.Cdc)
+	ibcKeeper := ibc.NewKeeper(ibcKey, paramstore.Subspace(ibc.DefaultParamspace), ibc.DefaultCodespace, scKeeper)
+	stakeKeeper := stake.NewKeeper(mapp.Cdc, stakeKey, stakeRewardKey, stakeTKey, bankKeeper, nil, paramstore.Subspace(stake.DefaultParamspace), stake.DefaultCodespace, sdk.ChainID(0), "")
+	stakeKeeper.SetupForSideChain(&scKeeper, &ibcKeeper)
+	mapp.Router().AddRoute("stake", stake.NewHandler(stakeKeeper, gov.Keeper{}))
+
+	err := mapp.CompleteSetup(stakeKey, stakeRewardKey, stakeTKey, paramsKey, paramsTKey, ibcKey, keySideChain)
+	if err != nil {
+		panic(err)
+	}
+
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.LiquidStaking, 1)
+	defer sdk.UpgradeMgr.Reset()
+
+	var accounts []simulation.Account
+	appStateFn := func(r *rand.Rand, accs []simulation.Account) json.RawMessage {
+		simulation.RandomSetGenesis(r, mapp, accs, []string{"stake"})
+		accounts = accs
+		return json.RawMessage("{}")
+	}
+
+	sideChainId := "bsc"
+	simulation.Simulate(
+		t, mapp.BaseApp, appStateFn,
+		[]simulation.WeightedOperation{
+			{10, SimulateMsgSideChainLiquidDelegate(mapper, stakeKeeper, sideChainId)},
+			{5, SimulateMsgSideChainLiquidUndelegate(mapper, stakeKeeper, sideChainId)},
+		}, []simulation.RandSetup{
+			Setup(mapp, stakeKeeper),
+			SetupSideChain(mapp, stakeKeeper, sideChainId),
+		}, []simulation.Invariant{
+			LiquidStakeInvariant(stakeKeeper, mapp.AccountKeeper, &accounts),
+		}, 10, 100,
+		false,
+	)
+}
@@ -27,6 +27,7 @@ type (
 	MsgRedelegate              = types.MsgRedelegate
 	MsgUndelegate              = types.MsgUndelegate
 	GenesisState               = types.GenesisState
+	OracleRelayer              = types.OracleRelayer
 	QueryDelegatorParams       = querier.QueryDelegatorParams
 	QueryValidatorParams       = querier.QueryValidatorParams
 	QueryBondsParams           = querier.QueryBondsParams
@@ -34,6 +35,17 @@ type (
 	CreateValidatorJsonMsg     = types.CreateValidatorJsonMsg
 	QueryTopValidatorsParams   = querier.QueryTopValidatorsParams
 	BaseParams                 = querier.BaseParams
+	QueryRewardHistoryParams   = querier.QueryRewardHistoryParams
+	RewardHistoryPage          = querier.RewardHistoryPage
+	RewardHistory              = keeper.RewardHistory
+	RewardRecord               = types.RewardRecord
+	PendingCommission          = types.PendingCommission
+	CommissionChangeEvent      = types.CommissionChangeEvent
+	LiquidStakeReceipt         = types.LiquidStakeReceipt
+	LiquidStakeReceiptInfo     = querier.LiquidStakeReceiptInfo
+
+	CrossStakeRecord            = types.CrossStakeRecord
+	QueryCrossStakeRecordParams = querier.QueryCrossStakeRecordParams
 
 	MsgCreateSideChainValidator             = types.MsgCreateSideChainValidator
 	MsgEditSideChainValidator               = types.MsgEditSideChainValidator
@@ -42,6 +54,10 @@ type (
 	MsgSideChainDelegate                    = types.MsgSideChainDelegate
 	MsgSideChainRedelegate                  = types.MsgSideChainRedelegate
 	MsgSideChainUndelegate                  = types.MsgSideChainUndelegate
+	MsgSetRewardRestake                     = types.MsgSetRewardRestake
+	MsgSideChainLiquidDelegate              = types.MsgSideChainLiquidDelegate
+	MsgSideChainLiquidUndelegate            = types.MsgSideChainLiquidUndelegate
+	MsgRetryCrossStakeRefund                = types.MsgRetryCrossStakeRefund
 
 	DistributionEvent      = types.DistributionEvent
 	DistributionData       = types.DistributionData
@@ -65,7 +81,10 @@ type (
 )
 
 var (
-	NewKeeper = keeper.NewKeeper
+	NewKeeper        = keeper.NewKeeper
+	NewRewardHistory = keeper.NewRewardHistory
+
+	ParseRewardHistoryDates = querier.ParseRewardHistoryDates
 
 	GetValidatorKey                  = keeper.GetValidatorKey
 	GetValidatorByConsAddrKey        = keeper.GetValidatorByConsAddrKey
@@ -130,6 +149,10 @@ var (
 	NewMsgSideChainDelegate                  = types.NewMsgSideChainDelegate
 	NewMsgSideChainRedelegate                = types.NewMsgSideChainRedelegate
 	NewMsgSideChainUndelegate                = types.NewMsgSideChainUndelegate
+	NewMsgSetRewardRestake                   = types.NewMsgSetRewardRestake
+	NewMsgSideChainLiquidDelegate            = types.NewMsgSideChainLiquidDelegate
+	NewMsgSideChainLiquidUndelegate          = types.NewMsgSideChainLiquidUndelegate
+	NewMsgRetryCrossStakeRefund              = types.NewMsgRetryCrossStakeRefund
 
 	NewMsgCreateSideChainValidatorWithVoteAddr           = types.NewMsgCreateSideChainValidatorWithVoteAddr
 	NewMsgCreateSideChainValidatorWithVoteAddrOnBehalfOf = types.NewMsgCreateSideChainValidatorWithVoteAddrOnBehalfOf
@@ -138,9 +161,10 @@ var (
 	NewQuerier    = querier.NewQuerier
 	NewBaseParams = querier.NewBaseParams
 
-	FeeCollectorAddr  = keeper.FeeCollectorAddr
-	DelegationAccAddr = keeper.DelegationAccAddr
-	FeeForAllAccAddr  = keeper.FeeForAllBcValsAccAddr
+	FeeCollectorAddr   = keeper.FeeCollectorAddr
+	DelegationAccAddr  = keeper.DelegationAccAddr
+	FeeForAllAccAddr   = keeper.FeeForAllBcValsAccAddr
+	LiquidStakeAccAddr = keeper.LiquidStakeAccAddr
 )
 
 const (
@@ -158,8 +182,16 @@ const (
 	QueryPool                          = querier.QueryPool
 	QueryParameters                    = querier.QueryParameters
 	QueryCrossStakeInfo                = querier.QueryCrossStakeInfoByBscAddress
-
-	Topic = types.Topic
+	QueryRewardRestake                 = querier.QueryRewardRestake
+	QueryRewardHistory                 = querier.QueryRewardHistory
+	RewardHistoryDateLayout            = querier.RewardHistoryDateLayout
+	QueryPendingCommissions            = querier.QueryPendingCommissions
+	QueryLiquidStakeReceipts           = querier.QueryLiquidStakeReceipts
+	QueryCrossStakeRecords             = querier.QueryCrossStakeRecords
+	QueryCrossStakeRecord              = querier.QueryCrossStakeRecord
+
+	Topic                  = types.Topic
+	LiquidStakeDenomPrefix = types.LiquidStakeDenomPrefix
 )
 
 const (
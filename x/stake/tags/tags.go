@@ -13,4 +13,8 @@ var (
 	Moniker      = "moniker"
 	Identity     = "identity"
 	EndTime      = "end-time"
+
+	RewardRestake      = "reward-restake"
+	PendingCommission  = "pending-commission"
+	LiquidStakeReceipt = "liquid-stake-receipt"
 )
@@ -23,6 +23,10 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgSideChainDelegate{}, "cosmos-sdk/MsgSideChainDelegate", nil)
 	cdc.RegisterConcrete(MsgSideChainRedelegate{}, "cosmos-sdk/MsgSideChainRedelegate", nil)
 	cdc.RegisterConcrete(MsgSideChainUndelegate{}, "cosmos-sdk/MsgSideChainUndelegate", nil)
+	cdc.RegisterConcrete(MsgSetRewardRestake{}, "cosmos-sdk/MsgSetRewardRestake", nil)
+	cdc.RegisterConcrete(MsgSideChainLiquidDelegate{}, "cosmos-sdk/MsgSideChainLiquidDelegate", nil)
+	cdc.RegisterConcrete(MsgSideChainLiquidUndelegate{}, "cosmos-sdk/MsgSideChainLiquidUndelegate", nil)
+	cdc.RegisterConcrete(MsgRetryCrossStakeRefund{}, "cosmos-sdk/MsgRetryCrossStakeRefund", nil)
 
 	cdc.RegisterConcrete(&Params{}, "params/StakeParamSet", nil)
 }
@@ -16,6 +16,16 @@ type (
 		UpdateTime    time.Time `json:"update_time"`     // the last time the commission rate was changed
 	}
 
+	// PendingCommission is a raise of the commission rate of a validator, which applies at the breathe block when
+	// BreatheBlocksLeft counts down to 0, so that the delegators are noticed before it applies.
+	PendingCommission struct {
+		ValidatorAddr     sdk.ValAddress `json:"validator_addr"`
+		Rate              sdk.Dec        `json:"rate"`                // the new commission rate
+		RequestHeight     int64          `json:"request_height"`      // the height the raise is requested
+		RequestTime       time.Time      `json:"request_time"`        // the time the raise is requested
+		BreatheBlocksLeft int64          `json:"breathe_blocks_left"` // the count of breathe blocks before the raise applies
+	}
+
 	// CommissionMsg defines a commission message to be used for creating a
 	// validator.
 	CommissionMsg struct {
@@ -39,6 +39,9 @@ const (
 	RewardCAoBSalt   string = "Reward"
 
 	MinRewardThreshold int64 = 1e8
+
+	// MaxCrossStakeRefundRetries is the most breathe blocks at which a failed refund is retried before it is abandoned
+	MaxCrossStakeRefundRetries int64 = 7
 )
 
 type CrossStakeAckPackage struct {
@@ -93,6 +96,65 @@ type CrossStakeRefundPackage struct {
 	ErrorCode RefundError
 }
 
+type CrossStakeRecordStatus uint8
+type CrossStakeRefundStatus uint8
+
+const (
+	CrossStakeRecordSuccess CrossStakeRecordStatus = 0
+	CrossStakeRecordFailed  CrossStakeRecordStatus = 1
+	CrossStakeRecordCrashed CrossStakeRecordStatus = 2
+
+	CrossStakeNoRefund        CrossStakeRefundStatus = 0
+	CrossStakeRefunded        CrossStakeRefundStatus = 1
+	CrossStakeRefundPending   CrossStakeRefundStatus = 2
+	CrossStakeRefundAbandoned CrossStakeRefundStatus = 3
+)
+
+func (status CrossStakeRecordStatus) String() string {
+	switch status {
+	case CrossStakeRecordSuccess:
+		return "Success"
+	case CrossStakeRecordFailed:
+		return "Failed"
+	case CrossStakeRecordCrashed:
+		return "Crashed"
+	default:
+		return "Unknown"
+	}
+}
+
+func (status CrossStakeRefundStatus) String() string {
+	switch status {
+	case CrossStakeNoRefund:
+		return "None"
+	case CrossStakeRefunded:
+		return "Refunded"
+	case CrossStakeRefundPending:
+		return "Pending"
+	case CrossStakeRefundAbandoned:
+		return "Abandoned"
+	default:
+		return "Unknown"
+	}
+}
+
+// CrossStakeRecord is the record of a cross stake request from BSC, or of a refund of a cross stake distribution which
+// fails on BSC. It is keyed by the BSC address the request is from or the refund is to, and by the receive sequence of
+// the package on the cross stake channel. A refund which fails is retried at the breathe blocks, at most
+// MaxCrossStakeRefundRetries times, and then by MsgRetryCrossStakeRefund.
+type CrossStakeRecord struct {
+	BscAddress    sdk.SmartChainAddress  `json:"bsc_address"`
+	Sequence      uint64                 `json:"sequence"`
+	EventType     CrossStakeEventType    `json:"event_type"`
+	Height        int64                  `json:"height"`
+	Status        CrossStakeRecordStatus `json:"status"`
+	ErrorCode     uint8                  `json:"error_code"` // the error code acknowledged to BSC of a failed request
+	Amount        int64                  `json:"amount"`
+	RefundAddr    sdk.AccAddress         `json:"refund_addr,omitempty"`
+	RefundStatus  CrossStakeRefundStatus `json:"refund_status"`
+	RefundRetries int64                  `json:"refund_retries"`
+}
+
 func GetStakeCAoB(sourceAddr []byte, salt string) sdk.AccAddress {
 	saltBytes := []byte("Staking" + salt + "Address Anchor")
 	return sdk.XOR(tmhash.SumTruncated(saltBytes), sourceAddr)
@@ -126,3 +188,21 @@ func (dr CrossStakeInfoResponse) HumanReadableString() (string, error) {
 
 	return resp, nil
 }
+
+func (r CrossStakeRecord) HumanReadableString() (string, error) {
+	resp := "Cross Stake Record \n"
+	resp += fmt.Sprintf("BSC address: %s\n", r.BscAddress.String())
+	resp += fmt.Sprintf("Sequence: %d\n", r.Sequence)
+	resp += fmt.Sprintf("Event type: %d\n", r.EventType)
+	resp += fmt.Sprintf("Height: %d\n", r.Height)
+	resp += fmt.Sprintf("Status: %s\n", r.Status)
+	resp += fmt.Sprintf("Error code: %d\n", r.ErrorCode)
+	resp += fmt.Sprintf("Amount: %d\n", r.Amount)
+	resp += fmt.Sprintf("Refund status: %s", r.RefundStatus)
+	if r.RefundStatus != CrossStakeNoRefund {
+		resp += fmt.Sprintf("\nRefund address: %s\n", r.RefundAddr.String())
+		resp += fmt.Sprintf("Refund retries: %d", r.RefundRetries)
+	}
+
+	return resp, nil
+}
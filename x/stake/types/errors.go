@@ -280,3 +280,19 @@ func ErrNotSelfDelegate(codespace sdk.CodespaceType) sdk.Error {
 func ErrConsAddrUpdateTime() sdk.Error {
 	return sdk.NewError(DefaultCodespace, CodeInvalidConsAddrUpdateTime, "ConsAddr cannot be changed more than once in 30 days")
 }
+
+func ErrNoLiquidStakeReceipt(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidDelegation, "no liquid stake receipt for the validator")
+}
+
+func ErrBadLiquidStakeAmount(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidDelegation, msg)
+}
+
+func ErrNoCrossStakeRecord(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidInput, "no cross stake record for this (bsc address, sequence) pair")
+}
+
+func ErrRefundNotAbandoned(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidInput, "the refund of the cross stake record is not abandoned")
+}
@@ -8,9 +8,12 @@ const (
 	EventTypeDelegate             = "delegate"
 	EventTypeUnbond               = "unbond"
 	EventTypeRedelegate           = "redelegate"
+	EventTypeApplyCommission      = "apply_commission"
 
 	EventTypeCrossStake        = "cross_stake"
 	EventTypeTotalDistribution = "total_distribution"
+	EventTypeTotalRestake      = "total_restake"
+	EventTypeRetryRefund       = "retry_cross_stake_refund"
 
 	AttributeKeyValidator         = "validator"
 	AttributeKeyCommissionRate    = "commission_rate"
@@ -19,8 +22,14 @@ const (
 	AttributeKeyDstValidator      = "destination_validator"
 	AttributeKeyDelegator         = "delegator"
 	AttributeKeyCompletionTime    = "completion_time"
+	AttributeKeyBreatheBlocksLeft = "breathe_blocks_left"
 
 	AttributeKeySideChainId = "side_chain_id"
 
-	AttributeKeyRewardSum = "reward_sum"
+	AttributeKeyRewardSum  = "reward_sum"
+	AttributeKeyRestakeSum = "restake_sum"
+
+	AttributeKeyBscAddress   = "bsc_address"
+	AttributeKeySequence     = "sequence"
+	AttributeKeyRefundStatus = "refund_status"
 )
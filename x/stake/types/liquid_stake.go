@@ -0,0 +1,26 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// LiquidStakeDenomPrefix is the symbol of the receipt tokens of liquid staking. A receipt denom is suffixed with a
+// four to six digit hex sequence, so it never collides with the issued tokens whose suffix has three hex digits.
+const LiquidStakeDenomPrefix = "LST"
+
+// LiquidStakeReceipt is the receipt token of the liquid delegations to a validator of a side chain. The delegations are
+// held by the liquid stake account, and Supply receipt tokens in the accounts of the delegators represent them, so one
+// receipt token is worth the tokens of the delegation shares divided by Supply.
+type LiquidStakeReceipt struct {
+	Denom         string         `json:"denom"`
+	ChainId       string         `json:"chain_id"`
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+	Supply        int64          `json:"supply"` // the amount of receipt tokens in circulation
+}
+
+// GetLiquidStakeDenom returns the denom of the receipt token with the sequence.
+func GetLiquidStakeDenom(sequence int64) string {
+	return fmt.Sprintf("%s-%04X", LiquidStakeDenomPrefix, sequence)
+}
@@ -18,6 +18,10 @@ const (
 	MsgTypeSideChainDelegate                    = "side_delegate"
 	MsgTypeSideChainRedelegate                  = "side_redelegate"
 	MsgTypeSideChainUndelegate                  = "side_undelegate"
+	MsgTypeSetRewardRestake                     = "set_reward_restake"
+	MsgTypeSideChainLiquidDelegate              = "side_liquid_delegate"
+	MsgTypeSideChainLiquidUndelegate            = "side_liquid_undelegate"
+	MsgTypeRetryCrossStakeRefund                = "retry_cross_stake_refund"
 )
 
 type SideChainIder interface {
@@ -621,3 +625,211 @@ func (msg MsgSideChainUndelegate) GetInvolvedAddresses() []sdk.AccAddress {
 func (msg MsgSideChainUndelegate) GetSideChainId() string {
 	return msg.SideChainId
 }
+
+// ______________________________________________________________________
+
+// MsgSetRewardRestake opts a delegator in or out of delegating its rewards of a chain back to their validators.
+// The rewards of the beacon chain are restaked with ChainIDForBeaconChain as SideChainId.
+type MsgSetRewardRestake struct {
+	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
+	SideChainId   string         `json:"side_chain_id"`
+	Restake       bool           `json:"restake"`
+}
+
+func NewMsgSetRewardRestake(sideChainId string, delegatorAddr sdk.AccAddress, restake bool) MsgSetRewardRestake {
+	return MsgSetRewardRestake{
+		DelegatorAddr: delegatorAddr,
+		SideChainId:   sideChainId,
+		Restake:       restake,
+	}
+}
+
+// nolint
+func (msg MsgSetRewardRestake) Route() string { return MsgRoute }
+func (msg MsgSetRewardRestake) Type() string  { return MsgTypeSetRewardRestake }
+func (msg MsgSetRewardRestake) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddr}
+}
+
+func (msg MsgSetRewardRestake) GetSignBytes() []byte {
+	bz := MsgCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic implements the sdk.Msg interface.
+func (msg MsgSetRewardRestake) ValidateBasic() sdk.Error {
+	if len(msg.DelegatorAddr) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected delegator address length is %d, actual length is %d", sdk.AddrLen, len(msg.DelegatorAddr)))
+	}
+	if len(msg.SideChainId) == 0 || len(msg.SideChainId) > types.MaxSideChainIdLength {
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "side chain id must be included and max length is 20 bytes")
+	}
+	return nil
+}
+
+func (msg MsgSetRewardRestake) GetInvolvedAddresses() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddr}
+}
+
+func (msg MsgSetRewardRestake) GetSideChainId() string {
+	return msg.SideChainId
+}
+
+// ______________________________________________________________________
+
+// MsgSideChainLiquidDelegate delegates to a validator of a side chain, and mints the receipt tokens of the validator to
+// the delegator, which are transferable and redeemable by MsgSideChainLiquidUndelegate.
+type MsgSideChainLiquidDelegate struct {
+	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+	Delegation    sdk.Coin       `json:"delegation"`
+	SideChainId   string         `json:"side_chain_id"`
+}
+
+func NewMsgSideChainLiquidDelegate(sideChainId string, delAddr sdk.AccAddress, valAddr sdk.ValAddress, delegation sdk.Coin) MsgSideChainLiquidDelegate {
+	return MsgSideChainLiquidDelegate{
+		DelegatorAddr: delAddr,
+		ValidatorAddr: valAddr,
+		Delegation:    delegation,
+		SideChainId:   sideChainId,
+	}
+}
+
+// nolint
+func (msg MsgSideChainLiquidDelegate) Route() string { return MsgRoute }
+func (msg MsgSideChainLiquidDelegate) Type() string  { return MsgTypeSideChainLiquidDelegate }
+func (msg MsgSideChainLiquidDelegate) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddr}
+}
+
+func (msg MsgSideChainLiquidDelegate) GetSignBytes() []byte {
+	bz := MsgCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic implements the sdk.Msg interface.
+func (msg MsgSideChainLiquidDelegate) ValidateBasic() sdk.Error {
+	if len(msg.DelegatorAddr) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected delegator address length is %d, actual length is %d", sdk.AddrLen, len(msg.DelegatorAddr)))
+	}
+	if len(msg.ValidatorAddr) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected validator address length is %d, actual length is %d", sdk.AddrLen, len(msg.ValidatorAddr)))
+	}
+	if msg.Delegation.Amount <= 0 {
+		return ErrBadDelegationAmount(DefaultCodespace, "delegation amount must be positive")
+	}
+	if len(msg.SideChainId) == 0 || len(msg.SideChainId) > types.MaxSideChainIdLength {
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "side chain id must be included and max length is 20 bytes")
+	}
+	return nil
+}
+
+func (msg MsgSideChainLiquidDelegate) GetInvolvedAddresses() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddr, sdk.AccAddress(msg.ValidatorAddr)}
+}
+
+func (msg MsgSideChainLiquidDelegate) GetSideChainId() string {
+	return msg.SideChainId
+}
+
+// ______________________________________________________________________
+
+// MsgSideChainLiquidUndelegate burns the receipt tokens of a validator of a side chain, and unbonds the delegation they
+// represent to the delegator. Amount is in the receipt tokens.
+type MsgSideChainLiquidUndelegate struct {
+	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
+	ValidatorAddr sdk.ValAddress `json:"validator_addr"`
+	Amount        sdk.Coin       `json:"amount"`
+	SideChainId   string         `json:"side_chain_id"`
+}
+
+func NewMsgSideChainLiquidUndelegate(sideChainId string, delAddr sdk.AccAddress, valAddr sdk.ValAddress, amount sdk.Coin) MsgSideChainLiquidUndelegate {
+	return MsgSideChainLiquidUndelegate{
+		DelegatorAddr: delAddr,
+		ValidatorAddr: valAddr,
+		Amount:        amount,
+		SideChainId:   sideChainId,
+	}
+}
+
+// nolint
+func (msg MsgSideChainLiquidUndelegate) Route() string { return MsgRoute }
+func (msg MsgSideChainLiquidUndelegate) Type() string  { return MsgTypeSideChainLiquidUndelegate }
+func (msg MsgSideChainLiquidUndelegate) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddr}
+}
+
+func (msg MsgSideChainLiquidUndelegate) GetSignBytes() []byte {
+	bz := MsgCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic implements the sdk.Msg interface.
+func (msg MsgSideChainLiquidUndelegate) ValidateBasic() sdk.Error {
+	if len(msg.DelegatorAddr) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected delegator address length is %d, actual length is %d", sdk.AddrLen, len(msg.DelegatorAddr)))
+	}
+	if len(msg.ValidatorAddr) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected validator address length is %d, actual length is %d", sdk.AddrLen, len(msg.ValidatorAddr)))
+	}
+	if msg.Amount.Amount <= 0 {
+		return ErrBadDelegationAmount(DefaultCodespace, "undelegation amount must be positive")
+	}
+	if len(msg.SideChainId) == 0 || len(msg.SideChainId) > types.MaxSideChainIdLength {
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "side chain id must be included and max length is 20 bytes")
+	}
+	return nil
+}
+
+func (msg MsgSideChainLiquidUndelegate) GetInvolvedAddresses() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.DelegatorAddr, sdk.AccAddress(msg.ValidatorAddr)}
+}
+
+func (msg MsgSideChainLiquidUndelegate) GetSideChainId() string {
+	return msg.SideChainId
+}
+
+// ______________________________________________________________________
+
+// MsgRetryCrossStakeRefund pays an abandoned refund of a cross stake record from the peg account. Any account can send
+// it, the refund goes to the refund address of the record.
+type MsgRetryCrossStakeRefund struct {
+	From       sdk.AccAddress        `json:"from"`
+	BscAddress sdk.SmartChainAddress `json:"bsc_address"`
+	Sequence   uint64                `json:"sequence"`
+}
+
+func NewMsgRetryCrossStakeRefund(from sdk.AccAddress, bscAddr sdk.SmartChainAddress, sequence uint64) MsgRetryCrossStakeRefund {
+	return MsgRetryCrossStakeRefund{
+		From:       from,
+		BscAddress: bscAddr,
+		Sequence:   sequence,
+	}
+}
+
+// nolint
+func (msg MsgRetryCrossStakeRefund) Route() string { return MsgRoute }
+func (msg MsgRetryCrossStakeRefund) Type() string  { return MsgTypeRetryCrossStakeRefund }
+func (msg MsgRetryCrossStakeRefund) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From}
+}
+
+func (msg MsgRetryCrossStakeRefund) GetSignBytes() []byte {
+	bz := MsgCdc.MustMarshalJSON(msg)
+	return sdk.MustSortJSON(bz)
+}
+
+// ValidateBasic implements the sdk.Msg interface.
+func (msg MsgRetryCrossStakeRefund) ValidateBasic() sdk.Error {
+	if len(msg.From) != sdk.AddrLen {
+		return sdk.ErrInvalidAddress(fmt.Sprintf("Expected address length is %d, actual length is %d", sdk.AddrLen, len(msg.From)))
+	}
+	if msg.BscAddress.IsEmpty() {
+		return sdk.NewError(DefaultCodespace, CodeInvalidInput, "bsc address is missing")
+	}
+	return nil
+}
+
+func (msg MsgRetryCrossStakeRefund) GetInvolvedAddresses() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.From, sdk.PegAccount}
+}
@@ -32,20 +32,24 @@ const (
 	defaultRewardDistributionBatchSize = 1000
 
 	ConsAddrUpdateIntervalInHours = 24 * 30
+
+	// maxCommissionNoticeBreatheBlocks is the longest notice period of the commission raises, in breathe blocks
+	maxCommissionNoticeBreatheBlocks = 30
 )
 
 // nolint - Keys for parameter access
 var (
-	KeyUnbondingTime               = []byte("UnbondingTime")
-	KeyMaxValidators               = []byte("MaxValidators")
-	KeyBondDenom                   = []byte("BondDenom")
-	KeyMinSelfDelegation           = []byte("MinSelfDelegation")
-	KeyMinDelegationChange         = []byte("MinDelegationChanged")
-	KeyRewardDistributionBatchSize = []byte("RewardDistributionBatchSize")
-	KeyMaxStakeSnapshots           = []byte("MaxStakeSnapshots")
-	KeyBaseProposerRewardRatio     = []byte("BaseProposerRewardRatio")
-	KeyBonusProposerRewardRatio    = []byte("BonusProposerRewardRatio")
-	KeyFeeFromBscToBcRatio         = []byte("FeeFromBscToBcRatio")
+	KeyUnbondingTime                 = []byte("UnbondingTime")
+	KeyMaxValidators                 = []byte("MaxValidators")
+	KeyBondDenom                     = []byte("BondDenom")
+	KeyMinSelfDelegation             = []byte("MinSelfDelegation")
+	KeyMinDelegationChange           = []byte("MinDelegationChanged")
+	KeyRewardDistributionBatchSize   = []byte("RewardDistributionBatchSize")
+	KeyMaxStakeSnapshots             = []byte("MaxStakeSnapshots")
+	KeyBaseProposerRewardRatio       = []byte("BaseProposerRewardRatio")
+	KeyBonusProposerRewardRatio      = []byte("BonusProposerRewardRatio")
+	KeyFeeFromBscToBcRatio           = []byte("FeeFromBscToBcRatio")
+	KeyCommissionNoticeBreatheBlocks = []byte("CommissionNoticeBreatheBlocks")
 )
 
 var _ params.ParamSet = (*Params)(nil)
@@ -64,6 +68,8 @@ type Params struct {
 	BaseProposerRewardRatio  types.Dec `json:"base_proposer_reward_ratio"`  // the base proposer reward ratio
 	BonusProposerRewardRatio types.Dec `json:"bonus_proposer_reward_ratio"` // the bonus proposer reward ratio
 	FeeFromBscToBcRatio      types.Dec `json:"fee_from_bsc_to_bc_ratio"`    // the fee from bsc to bc ratio
+	// added in CommissionChangeNotice
+	CommissionNoticeBreatheBlocks int64 `json:"commission_notice_breathe_blocks"` // the count of breathe blocks before a commission raise applies
 }
 
 func (p *Params) GetBCParamAttribute() string {
@@ -110,6 +116,9 @@ func (p *Params) UpdateCheck() error {
 	if p.FeeFromBscToBcRatio.LT(types.ZeroDec()) {
 		return fmt.Errorf("the fee_from_bsc_to_bc_ratio should be no less than 0")
 	}
+	if p.CommissionNoticeBreatheBlocks < 0 || p.CommissionNoticeBreatheBlocks > maxCommissionNoticeBreatheBlocks {
+		return fmt.Errorf("the commission_notice_breathe_blocks should be in range 0 to %d", maxCommissionNoticeBreatheBlocks)
+	}
 
 	return nil
 }
@@ -127,6 +136,7 @@ func (p *Params) KeyValuePairs() params.KeyValuePairs {
 		{KeyBaseProposerRewardRatio, &p.BaseProposerRewardRatio},
 		{KeyBonusProposerRewardRatio, &p.BonusProposerRewardRatio},
 		{KeyFeeFromBscToBcRatio, &p.FeeFromBscToBcRatio},
+		{KeyCommissionNoticeBreatheBlocks, &p.CommissionNoticeBreatheBlocks},
 	}
 }
 
@@ -168,6 +178,7 @@ func (p Params) HumanReadableString() string {
 	resp += fmt.Sprintf("Base proposer reward ratio: %s\n", p.BaseProposerRewardRatio)
 	resp += fmt.Sprintf("Bonus proposer reward ratio: %s\n", p.BonusProposerRewardRatio)
 	resp += fmt.Sprintf("Fee from BSC to BC ratio: %s\n", p.FeeFromBscToBcRatio)
+	resp += fmt.Sprintf("Breathe blocks before a commission raise applies: %d\n", p.CommissionNoticeBreatheBlocks)
 	return resp
 }
 
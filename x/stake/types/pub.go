@@ -9,6 +9,17 @@ const (
 	Topic = pubsub.Topic("stake")
 )
 
+func init() {
+	for _, event := range []pubsub.Event{
+		ValidatorUpdateEvent{}, ValidatorRemovedEvent{}, DelegationUpdateEvent{}, DelegationRemovedEvent{},
+		UBDUpdateEvent{}, REDUpdateEvent{}, CompletedUBDEvent{}, CompletedREDEvent{}, DistributionEvent{},
+		DelegateEvent{}, ChainDelegateEvent{}, UndelegateEvent{}, ChainUndelegateEvent{},
+		RedelegateEvent{}, ChainRedelegateEvent{}, ElectedValidatorsEvent{}, CommissionChangeEvent{},
+	} {
+		pubsub.RegisterEvent(event)
+	}
+}
+
 type StakeEvent struct {
 	IsFromTx bool
 }
@@ -148,3 +159,14 @@ type ElectedValidatorsEvent struct {
 	Validators []Validator
 	ChainId    string
 }
+
+// commission raise of a validator, published when it is scheduled and when it applies
+type CommissionChangeEvent struct {
+	StakeEvent
+	Validator         sdk.ValAddress
+	ChainId           string
+	OldRate           sdk.Dec
+	NewRate           sdk.Dec
+	BreatheBlocksLeft int64
+	Applied           bool
+}
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"time"
+
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
@@ -24,6 +26,20 @@ type Reward struct {
 	Tokens     sdk.Dec // delegator Tokens will be published for downstream usage
 	Amount     int64
 	CrossStake bool
+	Restaked   bool // whether the reward is delegated back to the validator instead of paid
+}
+
+// RewardRecord is a reward distributed to a delegator, kept in the reward history of the node
+type RewardRecord struct {
+	ChainId    string         `json:"chain_id"`
+	Height     int64          `json:"height"`
+	Time       time.Time      `json:"time"`
+	ValAddr    sdk.ValAddress `json:"validator_addr"`
+	AccAddr    sdk.AccAddress `json:"delegator_addr"`
+	Tokens     sdk.Dec        `json:"tokens"`
+	Amount     int64          `json:"amount"`
+	CrossStake bool           `json:"cross_stake"`
+	Restaked   bool           `json:"restaked"`
 }
 
 type StoredValDistAddr struct {
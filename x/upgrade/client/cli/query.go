@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
+)
+
+// GetQueryCmd returns the group of upgrade query commands, queryRoute is the route of the upgrade querier.
+func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Querying commands for the upgrades known to the node",
+	}
+	cmd.AddCommand(client.GetCommands(
+		GetCmdQueryUpgrades(queryRoute, cdc),
+		GetCmdQueryUpgrade(queryRoute, cdc),
+		GetCmdQueryMsgType(queryRoute, cdc),
+	)...)
+	return cmd
+}
+
+// GetCmdQueryUpgrades implements the query upgrades command.
+func GetCmdQueryUpgrades(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the upgrades with their heights, status and the store keys and msg types they enable",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, upgrade.QueryUpgrades), nil)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+}
+
+// GetCmdQueryUpgrade implements the query upgrade command.
+func GetCmdQueryUpgrade(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "show [name]",
+		Short: "Query an upgrade by name",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bz, err := cdc.MarshalJSON(args[0])
+			if err != nil {
+				return err
+			}
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, upgrade.QueryUpgrade), bz)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+}
+
+// GetCmdQueryMsgType implements the query msg type command.
+func GetCmdQueryMsgType(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "msg-type [type]",
+		Short: "Query which upgrade enables a msg type and whether it is supported at the current height",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			bz, err := cdc.MarshalJSON(args[0])
+			if err != nil {
+				return err
+			}
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, upgrade.QueryMsgType), bz)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+}
@@ -0,0 +1,52 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
+)
+
+// REST Variable names
+// nolint
+const (
+	RestUpgradeName = "name"
+	RestMsgType     = "msgType"
+)
+
+// RegisterRoutes registers the upgrade query routes, queryRoute is the route of the upgrade querier.
+func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec, queryRoute string) {
+	r.HandleFunc("/upgrade/upgrades", queryHandlerFn(cdc, cliCtx, queryRoute, upgrade.QueryUpgrades, "")).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/upgrade/upgrades/{%s}", RestUpgradeName),
+		queryHandlerFn(cdc, cliCtx, queryRoute, upgrade.QueryUpgrade, RestUpgradeName)).Methods("GET")
+	r.HandleFunc(fmt.Sprintf("/upgrade/msg_types/{%s}", RestMsgType),
+		queryHandlerFn(cdc, cliCtx, queryRoute, upgrade.QueryMsgType, RestMsgType)).Methods("GET")
+}
+
+// queryHandlerFn queries path of the upgrade querier with the path variable named param, if any.
+func queryHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext, queryRoute, path, param string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var bz []byte
+		if param != "" {
+			var err error
+			bz, err = cdc.MarshalJSON(mux.Vars(r)[param])
+			if err != nil {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, path), bz)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		utils.PostProcessResponse(w, cdc, res, cliCtx.Indent)
+	}
+}
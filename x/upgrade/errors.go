@@ -0,0 +1,30 @@
+package upgrade
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	DefaultCodespace sdk.CodespaceType = 32
+
+	CodeInvalidPlan    sdk.CodeType = 101
+	CodeDuplicatedPlan sdk.CodeType = 102
+	CodeExpiredPlan    sdk.CodeType = 103
+	CodeUnknownUpgrade sdk.CodeType = 104
+)
+
+func ErrInvalidPlan(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidPlan, msg)
+}
+
+func ErrDuplicatedPlan(codespace sdk.CodespaceType, name string) sdk.Error {
+	return sdk.NewError(codespace, CodeDuplicatedPlan, "upgrade "+name+" is already scheduled")
+}
+
+func ErrExpiredPlan(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeExpiredPlan, msg)
+}
+
+func ErrUnknownUpgrade(codespace sdk.CodespaceType, name string) sdk.Error {
+	return sdk.NewError(codespace, CodeUnknownUpgrade, "unknown upgrade "+name)
+}
@@ -0,0 +1,39 @@
+package upgrade
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+)
+
+// UpgradePlanHooks validates the plan carried by the description of software upgrade proposals.
+type UpgradePlanHooks struct {
+	keeper Keeper
+}
+
+func NewUpgradePlanHooks(keeper Keeper) UpgradePlanHooks {
+	return UpgradePlanHooks{keeper}
+}
+
+var _ gov.GovHooks = UpgradePlanHooks{}
+
+func (hooks UpgradePlanHooks) OnProposalSubmitted(ctx sdk.Context, proposal gov.Proposal) error {
+	// software upgrade proposals are text proposals before GovUpgradePlan
+	if !sdk.IsUpgrade(sdk.GovUpgradePlan) {
+		return nil
+	}
+	if proposal.GetProposalType() != gov.ProposalTypeSoftwareUpgrade {
+		panic(fmt.Sprintf("received wrong type of proposal %x", proposal.GetProposalType()))
+	}
+
+	var plan sdk.UpgradePlan
+	err := hooks.keeper.cdc.UnmarshalJSON([]byte(proposal.GetDescription()), &plan)
+	if err != nil {
+		return fmt.Errorf("unmarshal upgrade plan error, err=%s", err.Error())
+	}
+	if err := hooks.keeper.checkPlan(ctx, plan); err != nil {
+		return err
+	}
+	return nil
+}
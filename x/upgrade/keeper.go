@@ -0,0 +1,186 @@
+package upgrade
+
+import (
+	"fmt"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+)
+
+// Keeper persists the upgrade plans scheduled by software upgrade proposals, so that
+// every node registers them to sdk.UpgradeMgr again when it starts.
+type Keeper struct {
+	storeKey  sdk.StoreKey
+	cdc       *codec.Codec
+	codespace sdk.CodespaceType
+
+	govKeeper *gov.Keeper
+}
+
+func NewKeeper(cdc *codec.Codec, storeKey sdk.StoreKey, codespace sdk.CodespaceType) Keeper {
+	return Keeper{
+		storeKey:  storeKey,
+		cdc:       cdc,
+		codespace: codespace,
+	}
+}
+
+func (k *Keeper) SetGovKeeper(govKeeper *gov.Keeper) {
+	k.govKeeper = govKeeper
+}
+
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", "upgrade")
+}
+
+// ScheduleUpgrade persists plan and registers it to sdk.UpgradeMgr.
+func (k Keeper) ScheduleUpgrade(ctx sdk.Context, plan sdk.UpgradePlan) sdk.Error {
+	if err := k.checkPlan(ctx, plan); err != nil {
+		return err
+	}
+	if err := sdk.UpgradeMgr.AddPlan(plan); err != nil {
+		return ErrInvalidPlan(k.codespace, err.Error())
+	}
+	k.setPlan(ctx, plan)
+	return nil
+}
+
+func (k Keeper) checkPlan(ctx sdk.Context, plan sdk.UpgradePlan) sdk.Error {
+	if err := plan.ValidateBasic(); err != nil {
+		return ErrInvalidPlan(k.codespace, err.Error())
+	}
+	if plan.Height <= ctx.BlockHeight() {
+		return ErrExpiredPlan(k.codespace,
+			fmt.Sprintf("upgrade height %d should be greater than the current height %d", plan.Height, ctx.BlockHeight()))
+	}
+	if sdk.UpgradeMgr.GetUpgradeHeight(plan.Name) != 0 {
+		return ErrDuplicatedPlan(k.codespace, plan.Name)
+	}
+	return nil
+}
+
+// LoadPlans registers the persisted plans to sdk.UpgradeMgr, it has to be called once the
+// latest version is loaded and before any block is executed.
+func (k Keeper) LoadPlans(ctx sdk.Context) error {
+	for _, plan := range k.GetPlans(ctx) {
+		if err := sdk.UpgradeMgr.AddPlan(plan); err != nil {
+			return fmt.Errorf("failed to load upgrade plan %s: %v", plan.Name, err)
+		}
+		k.Logger(ctx).Info("load upgrade plan", "name", plan.Name, "height", plan.Height,
+			"handler", sdk.UpgradeMgr.HasUpgradeHandler(plan.Name))
+	}
+	return nil
+}
+
+func (k Keeper) GetPlan(ctx sdk.Context, name string) (sdk.UpgradePlan, bool) {
+	var plan sdk.UpgradePlan
+	bz := ctx.KVStore(k.storeKey).Get(GetPlanKey(name))
+	if bz == nil {
+		return plan, false
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &plan)
+	return plan, true
+}
+
+// GetPlans returns every persisted plan ordered by name.
+func (k Keeper) GetPlans(ctx sdk.Context) []sdk.UpgradePlan {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), PlanKeyPrefix)
+	defer iterator.Close()
+
+	plans := make([]sdk.UpgradePlan, 0)
+	for ; iterator.Valid(); iterator.Next() {
+		var plan sdk.UpgradePlan
+		k.cdc.MustUnmarshalBinaryLengthPrefixed(iterator.Value(), &plan)
+		plans = append(plans, plan)
+	}
+	return plans
+}
+
+func (k Keeper) setPlan(ctx sdk.Context, plan sdk.UpgradePlan) {
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(plan)
+	ctx.KVStore(k.storeKey).Set(GetPlanKey(plan.Name), bz)
+}
+
+// EndBlock schedules the plans of the software upgrade proposals passed since the last one processed. The last
+// processed proposal only moves past the final proposals, so the proposals still in their deposit or voting period
+// are checked again in the next blocks, while the passed ones after them are marked as processed.
+func (k Keeper) EndBlock(ctx sdk.Context) {
+	if !sdk.IsUpgrade(sdk.GovUpgradePlan) || k.govKeeper == nil {
+		return
+	}
+
+	lastProposalID := k.getLastProposalID(ctx)
+	nextProposalID := lastProposalID
+	maxProposalID := k.govKeeper.GetLastProposalID(ctx)
+	final := true
+	for proposalID := lastProposalID + 1; proposalID <= maxProposalID; proposalID++ {
+		// the expired proposals are deleted
+		proposal := k.govKeeper.GetProposal(ctx, proposalID)
+		if proposal != nil && (proposal.GetStatus() == gov.StatusDepositPeriod || proposal.GetStatus() == gov.StatusVotingPeriod) {
+			final = false
+			continue
+		}
+		processed := k.isProposalProcessed(ctx, proposalID)
+		if !processed && proposal != nil && proposal.GetStatus() == gov.StatusPassed &&
+			proposal.GetProposalType() == gov.ProposalTypeSoftwareUpgrade {
+			k.processProposal(ctx, proposal)
+			if !final {
+				k.setProposalProcessed(ctx, proposalID)
+			}
+		}
+		if final {
+			if processed {
+				k.deleteProposalProcessed(ctx, proposalID)
+			}
+			nextProposalID = proposalID
+		}
+	}
+	if nextProposalID != lastProposalID {
+		k.setLastProposalID(ctx, nextProposalID)
+	}
+}
+
+// processProposal schedules the plan of a passed software upgrade proposal, an invalid plan is skipped.
+func (k Keeper) processProposal(ctx sdk.Context, proposal gov.Proposal) {
+	var plan sdk.UpgradePlan
+	if err := k.cdc.UnmarshalJSON([]byte(proposal.GetDescription()), &plan); err != nil {
+		k.Logger(ctx).Error("Get broken data when unmarshal upgrade plan, will skip.", "proposalId", proposal.GetProposalID(), "err", err)
+		return
+	}
+	if err := k.ScheduleUpgrade(ctx, plan); err != nil {
+		k.Logger(ctx).Error("The upgrade plan is invalid, will skip.", "proposalId", proposal.GetProposalID(), "plan", plan, "err", err)
+		return
+	}
+	k.Logger(ctx).Info("schedule upgrade plan", "proposalId", proposal.GetProposalID(), "plan", plan,
+		"handler", sdk.UpgradeMgr.HasUpgradeHandler(plan.Name))
+}
+
+func (k Keeper) isProposalProcessed(ctx sdk.Context, proposalID int64) bool {
+	return ctx.KVStore(k.storeKey).Has(GetProcessedProposalKey(proposalID))
+}
+
+func (k Keeper) setProposalProcessed(ctx sdk.Context, proposalID int64) {
+	ctx.KVStore(k.storeKey).Set(GetProcessedProposalKey(proposalID), []byte{})
+}
+
+func (k Keeper) deleteProposalProcessed(ctx sdk.Context, proposalID int64) {
+	ctx.KVStore(k.storeKey).Delete(GetProcessedProposalKey(proposalID))
+}
+
+func (k Keeper) getLastProposalID(ctx sdk.Context) int64 {
+	var proposalID int64
+	bz := ctx.KVStore(k.storeKey).Get(KeyLastProposalID)
+	if bz == nil {
+		return 0
+	}
+	k.cdc.MustUnmarshalBinaryLengthPrefixed(bz, &proposalID)
+	return proposalID
+}
+
+func (k Keeper) setLastProposalID(ctx sdk.Context, proposalID int64) {
+	bz := k.cdc.MustMarshalBinaryLengthPrefixed(proposalID)
+	ctx.KVStore(k.storeKey).Set(KeyLastProposalID, bz)
+}
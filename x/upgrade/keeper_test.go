@@ -0,0 +1,131 @@
+package upgrade
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+type testDelegationSet struct{}
+
+func (testDelegationSet) GetValidatorSet() sdk.ValidatorSet { return nil }
+
+func (testDelegationSet) IterateDelegations(sdk.Context, sdk.AccAddress, func(int64, sdk.Delegation) bool) {
+}
+
+func createTestInput(t *testing.T) (sdk.Context, Keeper, gov.Keeper) {
+	key := sdk.NewKVStoreKey(StoreKey)
+	keyGov := sdk.NewKVStoreKey("gov")
+	keyParams := sdk.NewKVStoreKey("params")
+	tkeyParams := sdk.NewTransientStoreKey("transient_params")
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyGov, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(tkeyParams, sdk.StoreTypeTransient, db)
+	err := ms.LoadLatestVersion()
+	require.Nil(t, err)
+
+	cdc := codec.New()
+	gov.RegisterCodec(cdc)
+	paramsKeeper := params.NewKeeper(cdc, keyParams, tkeyParams)
+	govKeeper := gov.NewKeeper(cdc, keyGov, paramsKeeper, paramsKeeper.Subspace(gov.DefaultParamSpace),
+		nil, testDelegationSet{}, gov.DefaultCodespace, &sdk.Pool{})
+
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "foochainid", Height: 100}, sdk.RunTxModeDeliver, log.NewNopLogger())
+	require.Nil(t, govKeeper.SetInitialProposalID(ctx, 1))
+
+	k := NewKeeper(cdc, key, DefaultCodespace)
+	k.SetGovKeeper(&govKeeper)
+
+	sdk.UpgradeMgr = sdk.NewUpgradeManager(sdk.UpgradeConfig{})
+	sdk.UpgradeMgr.AddUpgradeHeight(sdk.GovUpgradePlan, 1)
+	sdk.UpgradeMgr.SetHeight(ctx.BlockHeight())
+	return ctx, k, govKeeper
+}
+
+func TestKeeper_ScheduleUpgrade(t *testing.T) {
+	ctx, keeper, _ := createTestInput(t)
+
+	require.NotNil(t, keeper.ScheduleUpgrade(ctx, sdk.UpgradePlan{Name: "test", Height: 100}))
+	require.NotNil(t, keeper.ScheduleUpgrade(ctx, sdk.UpgradePlan{Name: sdk.GovUpgradePlan, Height: 200}))
+
+	plan := sdk.UpgradePlan{Name: "test", Height: 200, AddedStoreKeys: []string{"new"}}
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, plan))
+	require.NotNil(t, keeper.ScheduleUpgrade(ctx, plan))
+	require.Equal(t, int64(200), sdk.UpgradeMgr.GetUpgradeHeight("test"))
+	require.Equal(t, int64(200), sdk.UpgradeMgr.GetStoreKeyHeight("new"))
+
+	stored, found := keeper.GetPlan(ctx, "test")
+	require.True(t, found)
+	require.Equal(t, plan, stored)
+
+	// plans are registered again by a restarted node
+	sdk.UpgradeMgr = sdk.NewUpgradeManager(sdk.UpgradeConfig{})
+	require.Nil(t, keeper.LoadPlans(ctx))
+	require.Equal(t, int64(200), sdk.UpgradeMgr.GetUpgradeHeight("test"))
+	require.Equal(t, int64(200), sdk.UpgradeMgr.GetStoreKeyHeight("new"))
+}
+
+func TestKeeper_EndBlock(t *testing.T) {
+	ctx, keeper, govKeeper := createTestInput(t)
+	hooks := NewUpgradePlanHooks(keeper)
+
+	submit := func(description string, status gov.ProposalStatus) gov.Proposal {
+		proposal := govKeeper.NewTextProposal(ctx, "upgrade", description, gov.ProposalTypeSoftwareUpgrade, 1000)
+		proposal.SetStatus(status)
+		govKeeper.SetProposal(ctx, proposal)
+		return proposal
+	}
+
+	valid := `{"name":"test","height":"200","added_store_keys":["new"]}`
+	require.Nil(t, hooks.OnProposalSubmitted(ctx, submit(valid, gov.StatusPassed)))
+	require.NotNil(t, hooks.OnProposalSubmitted(ctx, submit(`{"name":"expired","height":"10"}`, gov.StatusPassed)))
+	require.NotNil(t, hooks.OnProposalSubmitted(ctx, submit("text", gov.StatusPassed)))
+	submit(`{"name":"rejected","height":"200"}`, gov.StatusRejected)
+
+	keeper.EndBlock(ctx)
+	plans := keeper.GetPlans(ctx)
+	require.Len(t, plans, 1)
+	require.Equal(t, "test", plans[0].Name)
+	require.Equal(t, int64(200), sdk.UpgradeMgr.GetUpgradeHeight("test"))
+	require.Equal(t, int64(0), sdk.UpgradeMgr.GetUpgradeHeight("rejected"))
+	require.Equal(t, int64(4), keeper.getLastProposalID(ctx))
+
+	// the plan name is taken now
+	require.NotNil(t, hooks.OnProposalSubmitted(ctx, submit(valid, gov.StatusPassed)))
+
+	submit(`{"name":"next","height":"300"}`, gov.StatusPassed)
+	keeper.EndBlock(ctx)
+	require.Len(t, keeper.GetPlans(ctx), 2)
+	require.Equal(t, int64(300), sdk.UpgradeMgr.GetUpgradeHeight("next"))
+	require.Equal(t, int64(6), keeper.getLastProposalID(ctx))
+
+	// a proposal in voting period holds the last proposal processed, the passed ones after it are scheduled
+	voting := submit(`{"name":"voting","height":"400"}`, gov.StatusVotingPeriod)
+	submit(`{"name":"after","height":"500"}`, gov.StatusPassed)
+	keeper.EndBlock(ctx)
+	require.Equal(t, int64(0), sdk.UpgradeMgr.GetUpgradeHeight("voting"))
+	require.Equal(t, int64(500), sdk.UpgradeMgr.GetUpgradeHeight("after"))
+	require.Equal(t, int64(6), keeper.getLastProposalID(ctx))
+	require.True(t, keeper.isProposalProcessed(ctx, 8))
+
+	voting.SetStatus(gov.StatusPassed)
+	govKeeper.SetProposal(ctx, voting)
+	keeper.EndBlock(ctx)
+	require.Equal(t, int64(400), sdk.UpgradeMgr.GetUpgradeHeight("voting"))
+	require.Equal(t, int64(8), keeper.getLastProposalID(ctx))
+	require.False(t, keeper.isProposalProcessed(ctx, 8))
+	require.Len(t, keeper.GetPlans(ctx), 4)
+}
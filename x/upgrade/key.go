@@ -0,0 +1,24 @@
+package upgrade
+
+import "encoding/binary"
+
+const (
+	StoreKey = "upgrade"
+)
+
+var (
+	PlanKeyPrefix     = []byte{0x01} // prefix for each key to an upgrade plan, by name
+	KeyLastProposalID = []byte{0x02} // key for the last proposal processed, all the proposals before are final
+	// prefix for each key to a software upgrade proposal processed after the last proposal processed, by id
+	ProcessedProposalKeyPrefix = []byte{0x03}
+)
+
+func GetPlanKey(name string) []byte {
+	return append(PlanKeyPrefix, []byte(name)...)
+}
+
+func GetProcessedProposalKey(proposalID int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(proposalID))
+	return append(ProcessedProposalKeyPrefix, bz...)
+}
@@ -0,0 +1,154 @@
+package upgrade
+
+import (
+	"sort"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	QueryUpgrades = "upgrades"
+	QueryUpgrade  = "upgrade"
+	QueryMsgType  = "msgType"
+)
+
+const (
+	StatusActive  = "active"
+	StatusPending = "pending"
+	// StatusUnknown is a pending plan scheduled by governance which the binary has no handler of,
+	// the node halts at its height.
+	StatusUnknown = "unknown"
+)
+
+// UpgradeInfo describes an upgrade of sdk.UpgradeMgr at the height of the query.
+// Store keys and msg types are the ones enabled at the height of the upgrade.
+type UpgradeInfo struct {
+	Name             string   `json:"name"`
+	Height           int64    `json:"height"`
+	Status           string   `json:"status"`
+	Active           bool     `json:"active"`
+	Governance       bool     `json:"governance"`
+	Info             string   `json:"info,omitempty"`
+	StoreKeys        []string `json:"store_keys,omitempty"`
+	DeletedStoreKeys []string `json:"deleted_store_keys,omitempty"`
+	MsgTypes         []string `json:"msg_types,omitempty"`
+}
+
+// MsgTypeInfo describes the upgrade gating of a msg type, Height is 0 if it's not gated.
+type MsgTypeInfo struct {
+	MsgType   string   `json:"msg_type"`
+	Height    int64    `json:"height"`
+	Upgrades  []string `json:"upgrades,omitempty"`
+	Supported bool     `json:"supported"`
+}
+
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+		if len(path) == 0 {
+			return nil, sdk.ErrUnknownRequest("unknown upgrade query endpoint")
+		}
+		switch path[0] {
+		case QueryUpgrades:
+			return queryUpgrades(ctx, k)
+		case QueryUpgrade:
+			var name string
+			if err := k.cdc.UnmarshalJSON(req.Data, &name); err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+			}
+			return queryUpgrade(ctx, k, name)
+		case QueryMsgType:
+			var msgType string
+			if err := k.cdc.UnmarshalJSON(req.Data, &msgType); err != nil {
+				return nil, sdk.ErrUnknownRequest(sdk.AppendMsgToErr("incorrectly formatted request data", err.Error()))
+			}
+			return queryMsgType(ctx, k, msgType)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown upgrade query endpoint")
+		}
+	}
+}
+
+func queryUpgrades(ctx sdk.Context, k Keeper) ([]byte, sdk.Error) {
+	upgrades := make([]UpgradeInfo, 0, len(sdk.UpgradeMgr.Config.HeightMap))
+	for name, height := range sdk.UpgradeMgr.Config.HeightMap {
+		if height != 0 {
+			upgrades = append(upgrades, getUpgradeInfo(ctx, name))
+		}
+	}
+	sort.Slice(upgrades, func(i, j int) bool {
+		if upgrades[i].Height != upgrades[j].Height {
+			return upgrades[i].Height < upgrades[j].Height
+		}
+		return upgrades[i].Name < upgrades[j].Name
+	})
+	return marshalResult(k.cdc, upgrades)
+}
+
+func queryUpgrade(ctx sdk.Context, k Keeper, name string) ([]byte, sdk.Error) {
+	if sdk.UpgradeMgr.GetUpgradeHeight(name) == 0 {
+		return nil, ErrUnknownUpgrade(k.codespace, name)
+	}
+	return marshalResult(k.cdc, getUpgradeInfo(ctx, name))
+}
+
+func queryMsgType(ctx sdk.Context, k Keeper, msgType string) ([]byte, sdk.Error) {
+	info := MsgTypeInfo{
+		MsgType:   msgType,
+		Height:    sdk.UpgradeMgr.GetMsgTypeHeight(msgType),
+		Supported: true,
+	}
+	if info.Height != 0 {
+		info.Upgrades = namesAtHeight(sdk.UpgradeMgr.Config.HeightMap, info.Height)
+		info.Supported = ctx.BlockHeight() >= info.Height
+	}
+	return marshalResult(k.cdc, info)
+}
+
+func getUpgradeInfo(ctx sdk.Context, name string) UpgradeInfo {
+	height := sdk.UpgradeMgr.GetUpgradeHeight(name)
+	info := UpgradeInfo{
+		Name:             name,
+		Height:           height,
+		Active:           ctx.BlockHeight() >= height,
+		StoreKeys:        namesAtHeight(sdk.UpgradeMgr.Config.StoreKeyMap, height),
+		DeletedStoreKeys: namesAtHeight(sdk.UpgradeMgr.Config.DeletedStoreKeyMap, height),
+		MsgTypes:         namesAtHeight(sdk.UpgradeMgr.Config.MsgTypeMap, height),
+	}
+	plan, isPlan := sdk.UpgradeMgr.GetPlan(name)
+	if isPlan {
+		info.Governance = true
+		info.Info = plan.Info
+	}
+	switch {
+	case info.Active:
+		info.Status = StatusActive
+	case isPlan && !sdk.UpgradeMgr.HasUpgradeHandler(name):
+		info.Status = StatusUnknown
+	default:
+		info.Status = StatusPending
+	}
+	return info
+}
+
+// namesAtHeight returns the sorted keys of heights which are mapped to height.
+func namesAtHeight(heights map[string]int64, height int64) []string {
+	var names []string
+	for name, h := range heights {
+		if h == height {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func marshalResult(cdc *codec.Codec, result interface{}) ([]byte, sdk.Error) {
+	bz, err := codec.MarshalJSONIndent(cdc, result)
+	if err != nil {
+		return nil, sdk.ErrInternal(sdk.AppendMsgToErr("could not marshal result to JSON", err.Error()))
+	}
+	return bz, nil
+}
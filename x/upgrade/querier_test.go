@@ -0,0 +1,56 @@
+package upgrade
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestQuerier(t *testing.T) {
+	ctx, keeper, _ := createTestInput(t)
+	querier := NewQuerier(keeper)
+
+	sdk.UpgradeMgr.AddUpgradeHeight("hardcoded", 300)
+	sdk.UpgradeMgr.RegisterMsgTypes("hardcoded", "gatedMsg")
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, sdk.UpgradePlan{Name: "withHandler", Height: 200, AddedStoreKeys: []string{"new"}}))
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, sdk.UpgradePlan{Name: "withoutHandler", Height: 200, DeletedStoreKeys: []string{"old"}}))
+	sdk.UpgradeMgr.RegisterUpgradeHandler("withHandler", func(sdk.Context) {})
+
+	bz, err := querier(ctx, []string{QueryUpgrades}, abci.RequestQuery{})
+	require.Nil(t, err)
+	var upgrades []UpgradeInfo
+	require.Nil(t, keeper.cdc.UnmarshalJSON(bz, &upgrades))
+	require.Len(t, upgrades, 4)
+	require.Equal(t, UpgradeInfo{Name: sdk.GovUpgradePlan, Height: 1, Status: StatusActive, Active: true}, upgrades[0])
+	require.Equal(t, UpgradeInfo{Name: "withHandler", Height: 200, Status: StatusPending, Governance: true,
+		StoreKeys: []string{"new"}, DeletedStoreKeys: []string{"old"}}, upgrades[1])
+	require.Equal(t, StatusUnknown, upgrades[2].Status)
+	require.Equal(t, UpgradeInfo{Name: "hardcoded", Height: 300, Status: StatusPending, MsgTypes: []string{"gatedMsg"}}, upgrades[3])
+
+	data, _ := keeper.cdc.MarshalJSON("withoutHandler")
+	bz, err = querier(ctx, []string{QueryUpgrade}, abci.RequestQuery{Data: data})
+	require.Nil(t, err)
+	var upgrade UpgradeInfo
+	require.Nil(t, keeper.cdc.UnmarshalJSON(bz, &upgrade))
+	require.Equal(t, upgrades[2], upgrade)
+
+	data, _ = keeper.cdc.MarshalJSON("missing")
+	_, err = querier(ctx, []string{QueryUpgrade}, abci.RequestQuery{Data: data})
+	require.NotNil(t, err)
+
+	data, _ = keeper.cdc.MarshalJSON("gatedMsg")
+	bz, err = querier(ctx, []string{QueryMsgType}, abci.RequestQuery{Data: data})
+	require.Nil(t, err)
+	var msgType MsgTypeInfo
+	require.Nil(t, keeper.cdc.UnmarshalJSON(bz, &msgType))
+	require.Equal(t, MsgTypeInfo{MsgType: "gatedMsg", Height: 300, Upgrades: []string{"hardcoded"}}, msgType)
+
+	data, _ = keeper.cdc.MarshalJSON("send")
+	bz, err = querier(ctx, []string{QueryMsgType}, abci.RequestQuery{Data: data})
+	require.Nil(t, err)
+	require.Nil(t, keeper.cdc.UnmarshalJSON(bz, &msgType))
+	require.Equal(t, MsgTypeInfo{MsgType: "send", Supported: true}, msgType)
+}